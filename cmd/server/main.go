@@ -2,12 +2,14 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"github.com/teamcubation/go-items-challenge/internal/adapters/http/middleware"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -15,20 +17,86 @@ import (
 	"github.com/joho/godotenv"
 	httpSwagger "github.com/swaggo/http-swagger"
 	"github.com/teamcubation/go-items-challenge/internal/adapters/client"
+	"github.com/teamcubation/go-items-challenge/internal/adapters/export"
 	httphdl "github.com/teamcubation/go-items-challenge/internal/adapters/http"
+	"github.com/teamcubation/go-items-challenge/internal/adapters/jwtauth"
+	"github.com/teamcubation/go-items-challenge/internal/adapters/notify"
 	"github.com/teamcubation/go-items-challenge/internal/adapters/repository"
+	"github.com/teamcubation/go-items-challenge/internal/adapters/storage"
 	"github.com/teamcubation/go-items-challenge/internal/application"
 	"github.com/teamcubation/go-items-challenge/internal/domain/item"
 	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"github.com/teamcubation/go-items-challenge/internal/domain/warehouse"
+	reqlog "github.com/teamcubation/go-items-challenge/pkg/log"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 func runMigrations(db *gorm.DB) {
-	err := db.AutoMigrate(&user.User{}, &item.Item{})
+	if err := repository.MigrateUserRoles(db); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+	err := db.AutoMigrate(&user.User{}, &item.Item{}, &item.ExportJob{}, &item.AuditEntry{}, &item.StockMovement{}, &item.Reservation{},
+		&warehouse.Warehouse{}, &item.StockLevel{}, &item.CategoryReorderPoint{}, &item.StockAlert{}, &item.CodeSequence{}, &item.ItemGrant{},
+		&user.RefreshToken{}, &user.RevokedToken{}, &user.APIKey{}, &user.SigningKey{})
 	if err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
+	if err := repository.MigrateItems(db); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := repository.MigrateAudit(db); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := repository.MigrateStockMovements(db); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := repository.MigrateWarehouses(db); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+}
+
+// durationEnv reads a duration such as "720h" from the environment.
+func durationEnv(name string, def time.Duration) time.Duration {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return def
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		log.Fatalf("Invalid %s: %s", name, raw)
+	}
+	return d
+}
+
+// jwtConfig reads the configuration of the access tokens. HS256 is the
+// default and needs JWT_SECRET; RS256 and EdDSA keys are generated and rotated
+// every JWT_ROTATION_INTERVAL, and stored encrypted with the base64 key of
+// JWT_KEY_ENCRYPTION_KEY.
+func jwtConfig() jwtauth.Config {
+	cfg := jwtauth.Config{
+		Algorithm:        jwtauth.AlgorithmHS256,
+		Secret:           []byte(os.Getenv("JWT_SECRET")),
+		Issuer:           os.Getenv("JWT_ISSUER"),
+		Audience:         os.Getenv("JWT_AUDIENCE"),
+		TTL:              durationEnv("ACCESS_TOKEN_TTL", jwtauth.DefaultAccessTokenTTL),
+		RotationInterval: durationEnv("JWT_ROTATION_INTERVAL", jwtauth.DefaultRotationInterval),
+	}
+	if raw := os.Getenv("JWT_ALGORITHM"); raw != "" {
+		alg, err := jwtauth.ParseAlgorithm(raw)
+		if err != nil {
+			log.Fatalf("Invalid JWT_ALGORITHM: %v", err)
+		}
+		cfg.Algorithm = alg
+	}
+	if raw := os.Getenv("JWT_KEY_ENCRYPTION_KEY"); raw != "" {
+		kek, err := base64.StdEncoding.DecodeString(raw)
+		if err != nil {
+			log.Fatalf("Invalid JWT_KEY_ENCRYPTION_KEY: %v", err)
+		}
+		cfg.KeyEncryptionKey = kek
+	}
+	return cfg
 }
 
 func main() {
@@ -51,34 +119,141 @@ func main() {
 
 	runMigrations(db)
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	jwtManager, err := jwtauth.New(jwtConfig(), repository.NewSigningKeyRepository(db))
+	if err != nil {
+		log.Fatalf("Invalid JWT configuration: %v", err)
+	}
+	if err := jwtManager.Load(ctx); err != nil {
+		log.Fatalf("Failed to load signing keys: %v", err)
+	}
+	jwksHandler := httphdl.NewJWKSHandler(jwtManager)
+
 	userRepo := repository.NewUserRepository(db)
-	userSrv := application.NewAuthService(userRepo)
+	tokenRepo := repository.NewTokenRepository(db)
+	userSrv := application.NewAuthService(userRepo, jwtManager,
+		application.WithRefreshTokens(tokenRepo, durationEnv("REFRESH_TOKEN_TTL", application.DefaultRefreshTokenTTL)),
+		application.WithAPIKeys(repository.NewAPIKeyRepository(db)))
 	authHandler := httphdl.NewAuthHandler(userSrv)
+	// ADMIN_USERNAME names the first admin, who must have registered before
+	if username := os.Getenv("ADMIN_USERNAME"); username != "" {
+		if err := userSrv.BootstrapAdmin(ctx, username); err != nil {
+			log.Printf("Could not make %s an admin: %v", username, err)
+		}
+	}
+
+	warehouseRepo := repository.NewWarehouseRepository(db)
+	warehouseHandler := httphdl.NewWarehouseHandler(application.NewWarehouseService(warehouseRepo))
 
 	itemRepo := repository.NewItemRepository(db)
 	categoryClient := client.NewCategoryClient("http://mockapi:8000")
-	itemSrv := application.NewItemService(itemRepo, categoryClient)
+	alertNotifier := notify.NewLogNotifier()
+	if url := os.Getenv("STOCK_ALERT_WEBHOOK_URL"); url != "" {
+		alertNotifier = notify.NewWebhookNotifier(url)
+	}
+	itemOpts := []application.ItemServiceOption{
+		application.WithAuditLog(repository.NewAuditRepository(db)),
+		application.WithStockLedger(repository.NewStockMovementRepository(db)),
+		application.WithReservations(repository.NewReservationRepository(db)),
+		application.WithWarehouses(warehouseRepo),
+		application.WithStockAlerts(repository.NewStockAlertRepository(db), alertNotifier),
+		application.WithOwnership(repository.NewItemGrantRepository(db)),
+	}
+	if raw := os.Getenv("ITEM_PUBLIC_ID"); raw != "" {
+		strategy, err := item.ParseIDStrategy(raw)
+		if err != nil {
+			log.Fatalf("Invalid ITEM_PUBLIC_ID: %v", err)
+		}
+		itemOpts = append(itemOpts, application.WithPublicIDs(strategy))
+	}
+	if raw := os.Getenv("ITEM_CODE_PATTERN"); raw != "" {
+		pattern, err := item.ParseCodePattern(raw)
+		if err != nil {
+			log.Fatalf("Invalid ITEM_CODE_PATTERN: %v", err)
+		}
+		itemOpts = append(itemOpts, application.WithCodeGenerator(pattern, repository.NewCodeSequenceRepository(db)))
+	}
+	itemSrv := application.NewItemService(itemRepo, categoryClient, itemOpts...)
 	itemHandler := httphdl.NewItemHandler(itemSrv)
-	exportHandler := httphdl.NewExportHandler(itemSrv)
+	exportHandler := httphdl.NewExportHandler(itemSrv, export.DefaultRegistry())
+	importHandler := httphdl.NewImportHandler(itemSrv)
+
+	exportDir := os.Getenv("EXPORT_DIR")
+	if exportDir == "" {
+		exportDir = "/app/exports"
+	}
+	exportStorage, err := storage.NewLocalStorage(exportDir)
+	if err != nil {
+		log.Fatalf("Failed to create export storage: %v", err)
+	}
+	exportWorkers := 2
+	if raw := os.Getenv("EXPORT_WORKERS"); raw != "" {
+		if exportWorkers, err = strconv.Atoi(raw); err != nil || exportWorkers < 1 {
+			log.Fatalf("Invalid EXPORT_WORKERS: %s", raw)
+		}
+	}
+	exportJobRepo := repository.NewExportJobRepository(db)
+	exportJobSrv := application.NewExportJobService(exportJobRepo, itemRepo, export.DefaultRegistry(), exportStorage)
+	exportJobHandler := httphdl.NewExportJobHandler(exportJobSrv)
 
 	r := mux.NewRouter()
+	r.Use(reqlog.Middleware)
 
 	r.PathPrefix("/swagger").Handler(httpSwagger.WrapHandler)
 	r.HandleFunc("/register", authHandler.Register).Methods("POST")
 	r.HandleFunc("/login", authHandler.Login).Methods("POST")
+	r.HandleFunc("/refresh", authHandler.Refresh).Methods("POST")
+	r.HandleFunc("/logout", authHandler.Logout).Methods("POST")
+	r.HandleFunc("/.well-known/jwks.json", jwksHandler.JWKS).Methods("GET")
 
 	api := r.PathPrefix("/api").Subrouter()
-	api.Use(middleware.AuthMiddleware)
+	api.Use(middleware.NewAuthenticator(jwtManager, tokenRepo, middleware.WithAPIKeys(userSrv)).AuthMiddleware)
+	allow := middleware.RequirePermission
 
-	api.HandleFunc("/items/export", exportHandler.Export).Methods("GET")
-	api.HandleFunc("/items", itemHandler.CreateItem).Methods("POST")
-	api.HandleFunc("/items/{id}", itemHandler.UpdateItem).Methods("PUT")
-	api.HandleFunc("/items/{id}", itemHandler.DeleteItem).Methods("DELETE")
-	api.HandleFunc("/items/{id}", itemHandler.GetItemByID).Methods("GET")
-	api.HandleFunc("/items", itemHandler.ListItems).Methods("GET")
-
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	api.Handle("/items/export", allow(user.PermExportsRun, exportHandler.Export)).Methods("GET")
+	api.Handle("/items/import", allow(user.PermItemsWrite, importHandler.Import)).Methods("POST")
+	api.Handle("/items/search", allow(user.PermItemsRead, itemHandler.SearchItems)).Methods("GET")
+	api.Handle("/items/bulk", allow(user.PermItemsWrite, itemHandler.BulkCreateItems)).Methods("POST")
+	api.Handle("/items/bulk", allow(user.PermItemsWrite, itemHandler.BulkUpdateItems)).Methods("PUT")
+	api.Handle("/items/bulk", allow(user.PermItemsDelete, itemHandler.BulkDeleteItems)).Methods("DELETE")
+	api.Handle("/items", allow(user.PermItemsWrite, itemHandler.CreateItem)).Methods("POST")
+	api.Handle("/items/{id}", allow(user.PermItemsWrite, itemHandler.UpdateItem)).Methods("PUT")
+	api.Handle("/items/{id}", allow(user.PermItemsWrite, itemHandler.PatchItem)).Methods("PATCH")
+	api.Handle("/items/{id}", allow(user.PermItemsDelete, itemHandler.DeleteItem)).Methods("DELETE")
+	api.Handle("/items/{id}", allow(user.PermItemsRead, itemHandler.GetItemByID)).Methods("GET")
+	api.Handle("/items/{id}/restore", allow(user.PermItemsDelete, itemHandler.RestoreItem)).Methods("POST")
+	api.Handle("/items/{id}/history", allow(user.PermItemsRead, itemHandler.ItemHistory)).Methods("GET")
+	api.Handle("/items/{id}/revisions/{rev}/revert", allow(user.PermItemsWrite, itemHandler.RevertItem)).Methods("POST")
+	api.Handle("/items/{id}/transitions", allow(user.PermItemsWrite, itemHandler.TransitionItem)).Methods("POST")
+	api.Handle("/items/{id}/stock-movements", allow(user.PermItemsWrite, itemHandler.CreateStockMovement)).Methods("POST")
+	api.Handle("/items/{id}/stock-movements", allow(user.PermItemsRead, itemHandler.ListStockMovements)).Methods("GET")
+	api.Handle("/items/{id}/stock-levels", allow(user.PermItemsRead, itemHandler.ListStockLevels)).Methods("GET")
+	api.Handle("/items/{id}/stock-transfers", allow(user.PermItemsWrite, itemHandler.TransferStock)).Methods("POST")
+	api.Handle("/items/{id}/grants", allow(user.PermItemsWrite, itemHandler.GrantItem)).Methods("POST")
+	api.Handle("/items/{id}/grants", allow(user.PermItemsWrite, itemHandler.ListItemGrants)).Methods("GET")
+	api.Handle("/items/{id}/grants/{user_id}", allow(user.PermItemsWrite, itemHandler.RevokeItemGrant)).Methods("DELETE")
+	api.Handle("/items/{id}/reservations", allow(user.PermItemsWrite, itemHandler.CreateReservation)).Methods("POST")
+	api.Handle("/items", allow(user.PermItemsRead, itemHandler.ListItems)).Methods("GET")
+	api.Handle("/reservations/{id}", allow(user.PermItemsRead, itemHandler.GetReservation)).Methods("GET")
+	api.Handle("/reservations/{id}/confirm", allow(user.PermItemsWrite, itemHandler.ConfirmReservation)).Methods("POST")
+	api.Handle("/reservations/{id}/release", allow(user.PermItemsWrite, itemHandler.ReleaseReservation)).Methods("POST")
+	api.Handle("/categories/{id}/reorder-point", allow(user.PermItemsRead, itemHandler.GetCategoryReorderPoint)).Methods("GET")
+	api.Handle("/categories/{id}/reorder-point", allow(user.PermItemsWrite, itemHandler.SetCategoryReorderPoint)).Methods("PUT")
+	api.Handle("/categories/{id}/reorder-point", allow(user.PermItemsWrite, itemHandler.DeleteCategoryReorderPoint)).Methods("DELETE")
+	api.Handle("/warehouses", allow(user.PermWarehousesWrite, warehouseHandler.CreateWarehouse)).Methods("POST")
+	api.Handle("/warehouses", allow(user.PermItemsRead, warehouseHandler.ListWarehouses)).Methods("GET")
+	api.Handle("/warehouses/{id}", allow(user.PermItemsRead, warehouseHandler.GetWarehouse)).Methods("GET")
+	api.Handle("/warehouses/{id}", allow(user.PermWarehousesWrite, warehouseHandler.UpdateWarehouse)).Methods("PUT")
+	api.Handle("/warehouses/{id}", allow(user.PermWarehousesWrite, warehouseHandler.DeleteWarehouse)).Methods("DELETE")
+	api.Handle("/exports", allow(user.PermExportsRun, exportJobHandler.CreateExportJob)).Methods("POST")
+	api.Handle("/exports/{id}", allow(user.PermExportsRun, exportJobHandler.GetExportJob)).Methods("GET")
+	api.Handle("/exports/{id}/download", allow(user.PermExportsRun, exportJobHandler.DownloadExport)).Methods("GET")
+	api.Handle("/users/{id}/role", allow(user.PermUsersManage, authHandler.SetUserRole)).Methods("PUT")
+	api.Handle("/api-keys", allow(user.PermAPIKeysManage, authHandler.CreateAPIKey)).Methods("POST")
+	api.Handle("/api-keys", allow(user.PermAPIKeysManage, authHandler.ListAPIKeys)).Methods("GET")
+	api.Handle("/api-keys/{id}", allow(user.PermAPIKeysManage, authHandler.RevokeAPIKey)).Methods("DELETE")
 
 	go func() {
 		sigChan := make(chan os.Signal, 1)
@@ -88,6 +263,17 @@ func main() {
 		cancel()
 	}()
 
+	workersDone := make(chan struct{})
+	go func() {
+		defer close(workersDone)
+		exportJobSrv.Run(ctx, exportWorkers)
+	}()
+	go itemSrv.RunPurge(ctx, durationEnv("ITEM_RETENTION", 30*24*time.Hour), durationEnv("ITEM_PURGE_INTERVAL", time.Hour))
+	go itemSrv.RunReservationReaper(ctx, durationEnv("RESERVATION_REAP_INTERVAL", 30*time.Second))
+	go userSrv.RunTokenCleanup(ctx, durationEnv("TOKEN_CLEANUP_INTERVAL", time.Hour))
+	go jwtManager.Run(ctx, time.Minute)
+	go itemSrv.RunStockAlertDispatcher(ctx, durationEnv("STOCK_ALERT_INTERVAL", 30*time.Second))
+
 	log.Println("Server running on port 8080")
 
 	srv := &http.Server{
@@ -108,5 +294,6 @@ func main() {
 		log.Printf("Server forced to shutdown: %v", err)
 		return
 	}
+	<-workersDone
 	log.Println("Server exiting")
 }
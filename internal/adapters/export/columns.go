@@ -0,0 +1,47 @@
+package export
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+// column describes one field of the tabular formats (CSV and XLSX). The
+// headers match the columns understood by the CSV import.
+type column struct {
+	header  string
+	numeric bool
+	value   func(itm *item.Item) string
+}
+
+var columns = []column{
+	{header: "ID", numeric: true, value: func(itm *item.Item) string { return strconv.Itoa(itm.ID) }},
+	{header: "Code", value: func(itm *item.Item) string { return itm.Code }},
+	{header: "Title", value: func(itm *item.Item) string { return itm.Title }},
+	{header: "Description", value: func(itm *item.Item) string { return itm.Description }},
+	{header: "Price", numeric: true, value: func(itm *item.Item) string { return strconv.FormatFloat(itm.Price, 'f', 2, 64) }},
+	{header: "Stock", numeric: true, value: func(itm *item.Item) string { return strconv.Itoa(itm.Stock) }},
+	{header: "CategoryID", numeric: true, value: func(itm *item.Item) string { return strconv.Itoa(itm.CategoryID) }},
+	{header: "Status", value: func(itm *item.Item) string { return string(itm.Status) }},
+	{header: "CreatedAt", value: func(itm *item.Item) string { return itm.CreatedAt.Format(time.RFC3339) }},
+	{header: "UpdatedAt", value: func(itm *item.Item) string { return itm.UpdatedAt.Format(time.RFC3339) }},
+	{header: "CreatedBy", numeric: true, value: func(itm *item.Item) string { return strconv.Itoa(itm.CreatedBy) }},
+	{header: "UpdatedBy", numeric: true, value: func(itm *item.Item) string { return strconv.Itoa(itm.UpdatedBy) }},
+}
+
+func headers() []string {
+	h := make([]string, len(columns))
+	for i, c := range columns {
+		h[i] = c.header
+	}
+	return h
+}
+
+func record(itm *item.Item) []string {
+	r := make([]string, len(columns))
+	for i, c := range columns {
+		r[i] = c.value(itm)
+	}
+	return r
+}
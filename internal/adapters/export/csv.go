@@ -0,0 +1,39 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+)
+
+type CSVExporter struct{}
+
+func (CSVExporter) ContentType() string   { return "text/csv" }
+func (CSVExporter) FileExtension() string { return "csv" }
+
+func (CSVExporter) NewEncoder(w io.Writer) (out.ItemEncoder, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(headers()); err != nil {
+		return nil, err
+	}
+	return &csvEncoder{writer: writer}, nil
+}
+
+type csvEncoder struct {
+	writer *csv.Writer
+}
+
+func (e *csvEncoder) Encode(itm *item.Item) error {
+	return e.writer.Write(record(itm))
+}
+
+func (e *csvEncoder) Flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *csvEncoder) Close() error {
+	return e.Flush()
+}
@@ -0,0 +1,143 @@
+package export_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/adapters/export"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+)
+
+var items = []item.Item{
+	{
+		ID: 1, Code: "ABC", Title: "Shirt & tie", Price: 10.5, Stock: 5, CategoryID: 2, Status: "ACTIVE",
+		CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), UpdatedAt: time.Date(2026, 1, 3, 3, 4, 5, 0, time.UTC),
+	},
+	{ID: 2, Code: "XYZ", Status: "INACTIVE"},
+}
+
+func encode(t *testing.T, exporter out.ItemExporter, items []item.Item) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	encoder, err := exporter.NewEncoder(&buf)
+	require.NoError(t, err)
+	for i := range items {
+		require.NoError(t, encoder.Encode(&items[i]))
+	}
+	require.NoError(t, encoder.Close())
+	return buf.Bytes()
+}
+
+func TestRegistry_Lookup(t *testing.T) {
+	registry := export.DefaultRegistry()
+
+	exporter, ok := registry.Lookup("xlsx")
+	assert.True(t, ok)
+	assert.Equal(t, "xlsx", exporter.FileExtension())
+
+	_, ok = registry.Lookup("PDF")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"CSV", "JSON", "NDJSON", "XLSX", "XML"}, registry.Formats())
+}
+
+func TestRegistry_Negotiate(t *testing.T) {
+	tests := []struct {
+		accept string
+		format string
+		ok     bool
+	}{
+		{accept: "application/json", format: "JSON", ok: true},
+		{accept: "text/csv;q=0.2, application/xml;q=0.8", format: "XML", ok: true},
+		{accept: "application/x-ndjson;q=0, text/csv", format: "CSV", ok: true},
+		{accept: "*/*", ok: false},
+		{accept: "application/pdf", ok: false},
+	}
+
+	registry := export.DefaultRegistry()
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			format, _, ok := registry.Negotiate(tt.accept)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.format, format)
+		})
+	}
+}
+
+func TestJSONExporter(t *testing.T) {
+	assert.Equal(t, "[]\n", string(encode(t, export.JSONExporter{}, nil)))
+
+	out := encode(t, export.JSONExporter{}, items)
+	assert.Contains(t, string(out), `[{"id":1,"code":"ABC"`)
+	assert.Contains(t, string(out), `},{"id":2,"code":"XYZ"`)
+}
+
+func TestNDJSONExporter(t *testing.T) {
+	out := encode(t, export.NDJSONExporter{}, items)
+	lines := bytes.Split(bytes.TrimSpace(out), []byte("\n"))
+	require.Len(t, lines, 2)
+	assert.Contains(t, string(lines[1]), `"code":"XYZ"`)
+}
+
+func TestXMLExporter(t *testing.T) {
+	var decoded struct {
+		Items []struct {
+			ID    int     `xml:"id,attr"`
+			Code  string  `xml:"code"`
+			Title string  `xml:"title"`
+			Price float64 `xml:"price"`
+		} `xml:"item"`
+	}
+	require.NoError(t, xml.Unmarshal(encode(t, export.XMLExporter{}, items), &decoded))
+
+	require.Len(t, decoded.Items, 2)
+	assert.Equal(t, "Shirt & tie", decoded.Items[0].Title)
+	assert.Equal(t, 10.5, decoded.Items[0].Price)
+	assert.Equal(t, 2, decoded.Items[1].ID)
+	assert.Equal(t, "XYZ", decoded.Items[1].Code)
+}
+
+func TestXLSXExporter(t *testing.T) {
+	out := encode(t, export.XLSXExporter{}, items)
+
+	archive, err := zip.NewReader(bytes.NewReader(out), int64(len(out)))
+	require.NoError(t, err)
+
+	var sheet []byte
+	names := make([]string, 0, len(archive.File))
+	for _, f := range archive.File {
+		names = append(names, f.Name)
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			sheet, err = io.ReadAll(rc)
+			require.NoError(t, err)
+			rc.Close()
+		}
+	}
+	assert.Contains(t, names, "[Content_Types].xml")
+	assert.Contains(t, names, "xl/workbook.xml")
+
+	var decoded struct {
+		Rows []struct {
+			Cells []struct {
+				Type   string `xml:"t,attr"`
+				Value  string `xml:"v"`
+				Inline string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	require.NoError(t, xml.Unmarshal(sheet, &decoded))
+	require.Len(t, decoded.Rows, 3)
+	assert.Equal(t, "ID", decoded.Rows[0].Cells[0].Inline)
+	assert.Equal(t, "1", decoded.Rows[1].Cells[0].Value)
+	assert.Equal(t, "Shirt & tie", decoded.Rows[1].Cells[2].Inline)
+	assert.Equal(t, "inlineStr", decoded.Rows[1].Cells[2].Type)
+}
@@ -0,0 +1,83 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+)
+
+// JSONExporter writes a single JSON array of items.
+type JSONExporter struct{}
+
+func (JSONExporter) ContentType() string   { return "application/json" }
+func (JSONExporter) FileExtension() string { return "json" }
+
+func (JSONExporter) NewEncoder(w io.Writer) (out.ItemEncoder, error) {
+	buf := bufio.NewWriter(w)
+	if _, err := buf.WriteString("["); err != nil {
+		return nil, err
+	}
+	return &jsonEncoder{buf: buf}, nil
+}
+
+type jsonEncoder struct {
+	buf     *bufio.Writer
+	written bool
+}
+
+func (e *jsonEncoder) Encode(itm *item.Item) error {
+	raw, err := json.Marshal(itm)
+	if err != nil {
+		return err
+	}
+	if e.written {
+		if err := e.buf.WriteByte(','); err != nil {
+			return err
+		}
+	}
+	e.written = true
+	_, err = e.buf.Write(raw)
+	return err
+}
+
+func (e *jsonEncoder) Flush() error {
+	return e.buf.Flush()
+}
+
+func (e *jsonEncoder) Close() error {
+	if _, err := e.buf.WriteString("]\n"); err != nil {
+		return err
+	}
+	return e.buf.Flush()
+}
+
+// NDJSONExporter writes one JSON item per line.
+type NDJSONExporter struct{}
+
+func (NDJSONExporter) ContentType() string   { return "application/x-ndjson" }
+func (NDJSONExporter) FileExtension() string { return "ndjson" }
+
+func (NDJSONExporter) NewEncoder(w io.Writer) (out.ItemEncoder, error) {
+	buf := bufio.NewWriter(w)
+	return &ndjsonEncoder{buf: buf, encoder: json.NewEncoder(buf)}, nil
+}
+
+type ndjsonEncoder struct {
+	buf     *bufio.Writer
+	encoder *json.Encoder
+}
+
+func (e *ndjsonEncoder) Encode(itm *item.Item) error {
+	return e.encoder.Encode(itm)
+}
+
+func (e *ndjsonEncoder) Flush() error {
+	return e.buf.Flush()
+}
+
+func (e *ndjsonEncoder) Close() error {
+	return e.buf.Flush()
+}
@@ -0,0 +1,93 @@
+package export
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+)
+
+// Registry holds the available exporters by format name.
+type Registry struct {
+	exporters map[string]out.ItemExporter
+}
+
+var _ out.NegotiatedItemExporters = (*Registry)(nil)
+
+func NewRegistry() *Registry {
+	return &Registry{exporters: make(map[string]out.ItemExporter)}
+}
+
+// DefaultRegistry returns a registry with every built-in format.
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.Register("CSV", CSVExporter{})
+	r.Register("JSON", JSONExporter{})
+	r.Register("NDJSON", NDJSONExporter{})
+	r.Register("XLSX", XLSXExporter{})
+	r.Register("XML", XMLExporter{})
+	return r
+}
+
+// Register adds an exporter under a case-insensitive format name, replacing
+// any exporter previously registered with that name.
+func (r *Registry) Register(format string, exporter out.ItemExporter) {
+	r.exporters[strings.ToUpper(format)] = exporter
+}
+
+func (r *Registry) Lookup(format string) (out.ItemExporter, bool) {
+	exporter, ok := r.exporters[strings.ToUpper(format)]
+	return exporter, ok
+}
+
+// Formats returns the registered format names in alphabetical order.
+func (r *Registry) Formats() []string {
+	formats := make([]string, 0, len(r.exporters))
+	for format := range r.exporters {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// Negotiate picks the exporter for the preferred media type of an Accept
+// header. Wildcards are ignored, so a client must name a format explicitly.
+func (r *Registry) Negotiate(accept string) (string, out.ItemExporter, bool) {
+	type mediaRange struct {
+		mediaType string
+		quality   float64
+	}
+
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		fields := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(fields[0]))
+		if mediaType == "" || strings.Contains(mediaType, "*") {
+			continue
+		}
+		quality := 1.0
+		for _, param := range fields[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if name == "q" {
+				if q, err := strconv.ParseFloat(value, 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality > 0 {
+			ranges = append(ranges, mediaRange{mediaType: mediaType, quality: quality})
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].quality > ranges[j].quality })
+
+	for _, mr := range ranges {
+		for _, format := range r.Formats() {
+			exporter := r.exporters[format]
+			if exporter.ContentType() == mr.mediaType {
+				return format, exporter, true
+			}
+		}
+	}
+	return "", nil, false
+}
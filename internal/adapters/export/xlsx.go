@@ -0,0 +1,138 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+)
+
+// XLSXExporter writes a spreadsheet with a single sheet. The workbook parts
+// are static, so the sheet is streamed row by row using inline strings and no
+// shared string table.
+type XLSXExporter struct{}
+
+func (XLSXExporter) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+func (XLSXExporter) FileExtension() string { return "xlsx" }
+
+var xlsxStaticParts = []struct {
+	name    string
+	content string
+}{
+	{
+		name: "[Content_Types].xml",
+		content: xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+			`</Types>`,
+	},
+	{
+		name: "_rels/.rels",
+		content: xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`,
+	},
+	{
+		name: "xl/workbook.xml",
+		content: xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Items" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+	},
+	{
+		name: "xl/_rels/workbook.xml.rels",
+		content: xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`</Relationships>`,
+	},
+}
+
+func (XLSXExporter) NewEncoder(w io.Writer) (out.ItemEncoder, error) {
+	zw := zip.NewWriter(w)
+	for _, part := range xlsxStaticParts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	e := &xlsxEncoder{zip: zw, buf: bufio.NewWriter(sheet)}
+	if _, err := e.buf.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`); err != nil {
+		return nil, err
+	}
+
+	header := headers()
+	cells := make([]string, len(header))
+	for i, h := range header {
+		cells[i] = inlineStringCell(h)
+	}
+	if err := e.writeRow(cells); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+type xlsxEncoder struct {
+	zip  *zip.Writer
+	buf  *bufio.Writer
+	rows int
+}
+
+func (e *xlsxEncoder) Encode(itm *item.Item) error {
+	cells := make([]string, len(columns))
+	for i, c := range columns {
+		if c.numeric {
+			cells[i] = `<c><v>` + c.value(itm) + `</v></c>`
+		} else {
+			cells[i] = inlineStringCell(c.value(itm))
+		}
+	}
+	return e.writeRow(cells)
+}
+
+func (e *xlsxEncoder) writeRow(cells []string) error {
+	e.rows++
+	_, err := e.buf.WriteString(`<row r="` + strconv.Itoa(e.rows) + `">` + strings.Join(cells, "") + `</row>`)
+	return err
+}
+
+// Flush pushes the buffered rows through the compressor to the underlying
+// writer. The file is only readable once Close writes the zip directory.
+func (e *xlsxEncoder) Flush() error {
+	if err := e.buf.Flush(); err != nil {
+		return err
+	}
+	return e.zip.Flush()
+}
+
+func (e *xlsxEncoder) Close() error {
+	if _, err := e.buf.WriteString(`</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	if err := e.buf.Flush(); err != nil {
+		return err
+	}
+	return e.zip.Close()
+}
+
+func inlineStringCell(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return `<c t="inlineStr"><is><t xml:space="preserve">` + b.String() + `</t></is></c>`
+}
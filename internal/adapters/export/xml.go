@@ -0,0 +1,80 @@
+package export
+
+import (
+	"bufio"
+	"encoding/xml"
+	"io"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+)
+
+// XMLExporter writes an <items> document with one <item> element per item.
+type XMLExporter struct{}
+
+func (XMLExporter) ContentType() string   { return "application/xml" }
+func (XMLExporter) FileExtension() string { return "xml" }
+
+type xmlItem struct {
+	XMLName     xml.Name  `xml:"item"`
+	ID          int       `xml:"id,attr"`
+	Code        string    `xml:"code"`
+	Title       string    `xml:"title"`
+	Description string    `xml:"description"`
+	CategoryID  int       `xml:"category_id"`
+	Price       float64   `xml:"price"`
+	Stock       int       `xml:"stock"`
+	Status      string    `xml:"status"`
+	CreatedAt   time.Time `xml:"created_at"`
+	UpdatedAt   time.Time `xml:"updated_at"`
+	CreatedBy   int       `xml:"created_by"`
+	UpdatedBy   int       `xml:"updated_by"`
+}
+
+func (XMLExporter) NewEncoder(w io.Writer) (out.ItemEncoder, error) {
+	buf := bufio.NewWriter(w)
+	if _, err := buf.WriteString(xml.Header + "<items>"); err != nil {
+		return nil, err
+	}
+	return &xmlEncoder{buf: buf, encoder: xml.NewEncoder(buf)}, nil
+}
+
+type xmlEncoder struct {
+	buf     *bufio.Writer
+	encoder *xml.Encoder
+}
+
+func (e *xmlEncoder) Encode(itm *item.Item) error {
+	return e.encoder.Encode(xmlItem{
+		ID:          itm.ID,
+		Code:        itm.Code,
+		Title:       itm.Title,
+		Description: itm.Description,
+		CategoryID:  itm.CategoryID,
+		Price:       itm.Price,
+		Stock:       itm.Stock,
+		Status:      string(itm.Status),
+		CreatedAt:   itm.CreatedAt,
+		UpdatedAt:   itm.UpdatedAt,
+		CreatedBy:   itm.CreatedBy,
+		UpdatedBy:   itm.UpdatedBy,
+	})
+}
+
+func (e *xmlEncoder) Flush() error {
+	if err := e.encoder.Flush(); err != nil {
+		return err
+	}
+	return e.buf.Flush()
+}
+
+func (e *xmlEncoder) Close() error {
+	if err := e.encoder.Flush(); err != nil {
+		return err
+	}
+	if _, err := e.buf.WriteString("</items>\n"); err != nil {
+		return err
+	}
+	return e.buf.Flush()
+}
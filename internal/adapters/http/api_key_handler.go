@@ -0,0 +1,120 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"github.com/teamcubation/go-items-challenge/internal/utils"
+)
+
+type apiKeyRequest struct {
+	Name      string     `json:"name" validate:"required,max=64" example:"nightly-import"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1" example:"items:read,items:write"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// CreateAPIKey cria uma chave de API
+// @Summary Cria uma chave de API
+// @Description Cria uma chave de API para o usuário autenticado, para clientes sem interação humana. A chave é enviada no cabeçalho X-API-Key ou como "Authorization: ApiKey <chave>" e só é devolvida nesta resposta. Os escopos limitam as permissões da chave às concedidas pelo papel do usuário. Chaves de API não podem criar outras chaves.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param key body apiKeyRequest true "Nome, escopos e expiração opcional da chave"
+// @Success 201 {object} user.CreatedAPIKey "Chave criada"
+// @Failure 400 {string} string "Nome, escopo ou expiração inválidos"
+// @Failure 403 {object} middleware.ForbiddenError "Permissão negada, ou requisição feita com uma chave de API"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /api-keys [post]
+func (h *AuthHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req apiKeyRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		http.Error(w, "invalid fields name and/or scopes", http.StatusBadRequest)
+		return
+	}
+	scopes := make([]user.Permission, 0, len(req.Scopes))
+	for _, raw := range req.Scopes {
+		scope, err := user.ParsePermission(raw)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		scopes = append(scopes, scope)
+	}
+
+	key, err := h.srv.CreateAPIKey(r.Context(), req.Name, scopes, req.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, user.ErrInvalidScope) || errors.Is(err, user.ErrInvalidExpiry) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, user.ErrAPIKeyCaller) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(key); err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// ListAPIKeys lista as chaves de API
+// @Summary Lista as chaves de API
+// @Description Lista as chaves de API não revogadas do usuário autenticado, sem a chave em si.
+// @Tags auth
+// @Produce json
+// @Success 200 {array} user.APIKey "Chaves do usuário"
+// @Failure 403 {object} middleware.ForbiddenError "Permissão negada"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /api-keys [get]
+func (h *AuthHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.srv.ListAPIKeys(r.Context())
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(keys); err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// RevokeAPIKey revoga uma chave de API
+// @Summary Revoga uma chave de API
+// @Description Revoga uma chave de API do usuário autenticado; as requisições feitas com ela passam a ser rejeitadas.
+// @Tags auth
+// @Param id path string true "ID da chave"
+// @Success 204
+// @Failure 403 {object} middleware.ForbiddenError "Permissão negada"
+// @Failure 404 {string} string "Chave não encontrada"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /api-keys/{id} [delete]
+func (h *AuthHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	if err := h.srv.RevokeAPIKey(r.Context(), mux.Vars(r)["id"]); err != nil {
+		if errors.Is(err, user.ErrAPIKeyNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
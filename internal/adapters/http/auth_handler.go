@@ -5,6 +5,9 @@ import (
 	"errors"
 	"github.com/teamcubation/go-items-challenge/internal/utils"
 	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
 
 	"github.com/teamcubation/go-items-challenge/internal/application"
 	"github.com/teamcubation/go-items-challenge/internal/domain/user"
@@ -67,7 +70,7 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param user body user.Credentials true "Credenciais do usuário"
-// @Success 200 {object} map[string]string "Token de autenticação"
+// @Success 200 {object} user.TokenPair "Token de acesso e token de renovação"
 // @Failure 400 {string} string "Credenciais inválidas"
 // @Failure 401 {string} string "Usuário não encontrado"
 // @Failure 500 {string} string "Erro interno do servidor"
@@ -86,7 +89,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "invalid fields username and/or password", http.StatusBadRequest)
 		return
 	}
-	token, err := h.srv.Login(ctx, creds)
+	tokens, err := h.srv.Login(ctx, creds)
 	if err != nil {
 		if errors.Is(err, application.ErrUsernameNotFound) {
 			http.Error(w, "Username not found", http.StatusUnauthorized)
@@ -97,8 +100,139 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(map[string]string{"token": token}); err != nil {
+	if err := json.NewEncoder(w).Encode(tokens); err != nil {
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
 }
+
+type refreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+func decodeRefreshToken(r *http.Request) (string, error) {
+	var req refreshTokenRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		return "", err
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		return "", err
+	}
+	return req.RefreshToken, nil
+}
+
+// Refresh Renova os tokens de um usuário
+// @Summary Renova os tokens de um usuário
+// @Description Troca um token de renovação por um novo token de acesso e um novo token de renovação. Cada token de renovação só pode ser usado uma vez; reutilizá-lo revoga todos os tokens da mesma sessão.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token body refreshTokenRequest true "Token de renovação"
+// @Success 200 {object} user.TokenPair "Token de acesso e token de renovação"
+// @Failure 400 {string} string "Corpo da requisição inválido"
+// @Failure 401 {string} string "Token de renovação inválido, expirado ou reutilizado"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /refresh [post]
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	refreshToken, err := decodeRefreshToken(r)
+	if err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	tokens, err := h.srv.Refresh(r.Context(), refreshToken)
+	if err != nil {
+		writeTokenError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(tokens); err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// Logout Encerra a sessão de um usuário
+// @Summary Encerra a sessão de um usuário
+// @Description Revoga o token de renovação, os tokens renovados a partir do mesmo login e os tokens de acesso emitidos com eles.
+// @Tags auth
+// @Accept json
+// @Param token body refreshTokenRequest true "Token de renovação"
+// @Success 204
+// @Failure 400 {string} string "Corpo da requisição inválido"
+// @Failure 401 {string} string "Token de renovação inválido"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /logout [post]
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	refreshToken, err := decodeRefreshToken(r)
+	if err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	if err := h.srv.Logout(r.Context(), refreshToken); err != nil {
+		writeTokenError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeTokenError(w http.ResponseWriter, err error) {
+	if errors.Is(err, user.ErrInvalidRefreshToken) || errors.Is(err, user.ErrRefreshTokenReused) {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	http.Error(w, "Internal server error", http.StatusInternalServerError)
+}
+
+type roleRequest struct {
+	Role string `json:"role" example:"editor"`
+}
+
+// SetUserRole altera o papel de um usuário
+// @Summary Altera o papel de um usuário
+// @Description Define o papel (admin, editor ou viewer) de um usuário. O novo papel vale para os tokens emitidos a partir de então. Requer a permissão users:manage.
+// @Tags auth
+// @Accept json
+// @Param id path int true "ID do usuário"
+// @Param role body roleRequest true "Papel do usuário"
+// @Success 204
+// @Failure 400 {string} string "Papel inválido"
+// @Failure 403 {object} middleware.ForbiddenError "Permissão negada"
+// @Failure 404 {string} string "Usuário não encontrado"
+// @Failure 409 {string} string "Não é possível alterar o próprio papel"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /users/{id}/role [put]
+func (h *AuthHandler) SetUserRole(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+	var req roleRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	role, err := user.ParseRole(req.Role)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.srv.SetUserRole(r.Context(), id, role); err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, application.ErrOwnRole):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
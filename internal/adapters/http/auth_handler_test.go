@@ -6,7 +6,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/gorilla/mux"
 	http2 "github.com/teamcubation/go-items-challenge/internal/adapters/http"
 
 	"github.com/stretchr/testify/assert"
@@ -114,3 +116,111 @@ func TestAuthHandler_Register_UsernameExists(t *testing.T) {
 
 	mockService.AssertCalled(t, "RegisterUser", mock.Anything, mock.AnythingOfType("*user.User"))
 }
+
+func TestAuthHandler_Refresh(t *testing.T) {
+	mockService := new(mocks.AuthService)
+	handler := http2.NewAuthHandler(mockService)
+
+	pair := &user.TokenPair{AccessToken: "access", RefreshToken: "new", ExpiresIn: 3600}
+	mockService.On("Refresh", mock.Anything, "old").Return(pair, nil)
+	mockService.On("Refresh", mock.Anything, "stolen").Return(nil, user.ErrRefreshTokenReused)
+
+	req := httptest.NewRequest(http.MethodPost, "/refresh", bytes.NewBufferString(`{"refresh_token":"old"}`))
+	rec := httptest.NewRecorder()
+	handler.Refresh(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var response user.TokenPair
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, *pair, response)
+
+	req = httptest.NewRequest(http.MethodPost, "/refresh", bytes.NewBufferString(`{"refresh_token":"stolen"}`))
+	rec = httptest.NewRecorder()
+	handler.Refresh(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/refresh", bytes.NewBufferString(`{}`))
+	rec = httptest.NewRecorder()
+	handler.Refresh(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestAuthHandler_Logout(t *testing.T) {
+	mockService := new(mocks.AuthService)
+	handler := http2.NewAuthHandler(mockService)
+
+	mockService.On("Logout", mock.Anything, "rt").Return(nil)
+	mockService.On("Logout", mock.Anything, "unknown").Return(user.ErrInvalidRefreshToken)
+
+	req := httptest.NewRequest(http.MethodPost, "/logout", bytes.NewBufferString(`{"refresh_token":"rt"}`))
+	rec := httptest.NewRecorder()
+	handler.Logout(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/logout", bytes.NewBufferString(`{"refresh_token":"unknown"}`))
+	rec = httptest.NewRecorder()
+	handler.Logout(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAuthHandler_SetUserRole(t *testing.T) {
+	mockService := new(mocks.AuthService)
+	handler := http2.NewAuthHandler(mockService)
+	router := mux.NewRouter()
+	router.HandleFunc("/users/{id}/role", handler.SetUserRole).Methods(http.MethodPut)
+
+	mockService.On("SetUserRole", mock.Anything, 2, user.RoleEditor).Return(nil)
+	mockService.On("SetUserRole", mock.Anything, 9, user.RoleViewer).Return(user.ErrUserNotFound)
+
+	for body, status := range map[string]int{
+		`{"role":"editor"}`: http.StatusNoContent,
+		`{"role":"owner"}`:  http.StatusBadRequest,
+	} {
+		req := httptest.NewRequest(http.MethodPut, "/users/2/role", bytes.NewBufferString(body))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, status, rec.Code, body)
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/users/9/role", bytes.NewBufferString(`{"role":"viewer"}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAuthHandler_APIKeys(t *testing.T) {
+	mockService := new(mocks.AuthService)
+	handler := http2.NewAuthHandler(mockService)
+	router := mux.NewRouter()
+	router.HandleFunc("/api-keys", handler.CreateAPIKey).Methods(http.MethodPost)
+	router.HandleFunc("/api-keys/{id}", handler.RevokeAPIKey).Methods(http.MethodDelete)
+
+	created := &user.CreatedAPIKey{APIKey: user.APIKey{ID: "k1", Name: "nightly"}, Key: "gic_secret"}
+	mockService.On("CreateAPIKey", mock.Anything, "nightly", []user.Permission{user.PermItemsRead}, (*time.Time)(nil)).Return(created, nil)
+	mockService.On("CreateAPIKey", mock.Anything, "wide", []user.Permission{user.PermUsersManage}, (*time.Time)(nil)).Return(nil, user.ErrInvalidScope)
+	mockService.On("RevokeAPIKey", mock.Anything, "gone").Return(user.ErrAPIKeyNotFound)
+
+	for body, status := range map[string]int{
+		`{"name":"nightly","scopes":["items:read"]}`:     http.StatusCreated,
+		`{"name":"wide","scopes":["users:manage"]}`:      http.StatusBadRequest,
+		`{"name":"typo","scopes":["items:readall"]}`:     http.StatusBadRequest,
+		`{"name":"none","scopes":[]}`:                    http.StatusBadRequest,
+		`{"name":"extra","scopes":["items:read"],"x":1}`: http.StatusBadRequest,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api-keys", bytes.NewBufferString(body))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, status, rec.Code, body)
+		if status == http.StatusCreated {
+			var resp user.CreatedAPIKey
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, "gic_secret", resp.Key)
+			assert.NotContains(t, rec.Body.String(), "key_hash")
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/api-keys/gone", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
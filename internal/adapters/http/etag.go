@@ -0,0 +1,74 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+var (
+	errPreconditionRequired = errors.New("If-Match header is required")
+	errInvalidIfMatch       = errors.New("If-Match must be a single entity tag or *")
+)
+
+// itemETag is the strong entity tag of an item version.
+func itemETag(itm *item.Item) string {
+	return `"` + strconv.Itoa(itm.Version) + `"`
+}
+
+func setItemETag(w http.ResponseWriter, itm *item.Item) {
+	w.Header().Set("ETag", itemETag(itm))
+}
+
+// ifMatchVersion returns the item version required by the If-Match header.
+// "*" matches any version and is returned as zero.
+func ifMatchVersion(r *http.Request) (int, error) {
+	raw := strings.TrimSpace(r.Header.Get("If-Match"))
+	switch {
+	case raw == "":
+		return 0, errPreconditionRequired
+	case raw == "*":
+		return 0, nil
+	case strings.Contains(raw, ","), len(raw) < 3, raw[0] != '"', raw[len(raw)-1] != '"':
+		return 0, errInvalidIfMatch
+	}
+	version, err := strconv.Atoi(raw[1 : len(raw)-1])
+	if err != nil || version < 1 {
+		// a valid tag that is not one of ours can never match
+		return 0, item.ErrVersionConflict
+	}
+	return version, nil
+}
+
+// writeIfMatchError answers 428 when If-Match is missing, 412 when it cannot
+// match any version and 400 when it is malformed.
+func writeIfMatchError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errPreconditionRequired):
+		http.Error(w, err.Error(), http.StatusPreconditionRequired)
+	case errors.Is(err, item.ErrVersionConflict):
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
+
+// noneMatch reports whether If-None-Match lets the request through, that is,
+// whether none of its entity tags matches etag. Weak comparison is used, as
+// RFC 9110 requires for If-None-Match.
+func noneMatch(r *http.Request, etag string) bool {
+	raw := r.Header.Get("If-None-Match")
+	if raw == "" {
+		return true
+	}
+	for _, tag := range strings.Split(raw, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return false
+		}
+	}
+	return true
+}
@@ -1,65 +1,147 @@
 package http
 
 import (
-	"encoding/csv"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
-	"strconv"
+	"strings"
 
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
 	"github.com/teamcubation/go-items-challenge/internal/ports/in"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
 )
 
+// exportFlushEvery is the number of items buffered before flushing the response.
+const exportFlushEvery = 500
+
 type ExportHandler struct {
 	itemService in.ItemService
+	exporters   out.NegotiatedItemExporters
 }
 
-func NewExportHandler(itemService in.ItemService) *ExportHandler {
-	return &ExportHandler{itemService: itemService}
+func NewExportHandler(itemService in.ItemService, exporters out.NegotiatedItemExporters) *ExportHandler {
+	return &ExportHandler{itemService: itemService, exporters: exporters}
 }
 
+// Export exporta os itens
+// @Summary Exporta os itens
+// @Description Exporta todos os itens que atendem aos filtros, com os mesmos parâmetros da listagem (sem paginação). Sem status, exporta itens de qualquer status. O formato vem do parâmetro format ou, se ausente, do header Accept.
+// @Tags items
+// @Produce text/csv
+// @Produce application/json
+// @Produce application/x-ndjson
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/xml
+// @Param format query string false "Formato do arquivo: CSV, JSON, NDJSON, XLSX ou XML"
+// @Param status query string false "Status do item"
+// @Param category_id query int false "ID da categoria"
+// @Param min_price query number false "Preço mínimo"
+// @Param max_price query number false "Preço máximo"
+// @Param min_stock query int false "Estoque mínimo"
+// @Param max_stock query int false "Estoque máximo"
+// @Param created_by query int false "ID do usuário que criou o item"
+// @Param created_from query string false "Criado a partir de (RFC 3339)"
+// @Param created_to query string false "Criado até (RFC 3339)"
+// @Param updated_from query string false "Atualizado a partir de (RFC 3339)"
+// @Param updated_to query string false "Atualizado até (RFC 3339)"
+// @Param code_prefix query string false "Prefixo do código"
+// @Param low_stock query bool false "Somente itens no ponto de reposição ou abaixo dele"
+// @Param mine query bool false "Somente itens criados pelo usuário ou compartilhados com ele"
+// @Param sort query string false "Ordenação, ex.: created_at:desc,price"
+// @Success 200 {file} file
+// @Failure 400 {string} string "Parâmetros inválidos"
+// @Failure 406 {string} string "Nenhum formato aceito pelo header Accept"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /items/export [get]
 func (h *ExportHandler) Export(w http.ResponseWriter, r *http.Request) {
 	format := r.URL.Query().Get("format")
-	if format == "" {
+	var exporter out.ItemExporter
+	var ok bool
+	switch {
+	case format != "":
+		exporter, ok = h.exporters.Lookup(format)
+		if !ok {
+			http.Error(w, "Unsupported format", http.StatusBadRequest)
+			return
+		}
+	case r.Header.Get("Accept") != "":
+		format, exporter, ok = h.exporters.Negotiate(r.Header.Get("Accept"))
+		if !ok {
+			msg := fmt.Sprintf("none of the accepted media types is supported; use the format query parameter (%s)",
+				strings.Join(h.exporters.Formats(), ", "))
+			http.Error(w, msg, http.StatusNotAcceptable)
+			return
+		}
+	default:
 		http.Error(w, "format query parameter is required", http.StatusBadRequest)
 		return
 	}
 
-	switch format {
-	case "CSV":
-		h.ExportCSV(w, r)
-	default:
-		http.Error(w, "Unsupported format", http.StatusBadRequest)
+	query, err := parseListFilters(r.URL.Query())
+	if err == nil {
+		err = query.ValidateFilters()
 	}
-}
-
-func (h *ExportHandler) ExportCSV(w http.ResponseWriter, r *http.Request) {
-	items, _, err := h.itemService.ListItems(r.Context(), "", 100, 1)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	w.Header().Set("Content-Type", "text/csv")
-	w.Header().Set("Content-Disposition", "attachment;filename=items.csv")
-	writer := csv.NewWriter(w)
-	defer writer.Flush()
+	h.stream(w, r, strings.ToUpper(format), exporter, query)
+}
 
-	writer.Write([]string{"ID", "Code", "Title", "Description", "Price", "Stock", "CategoryID"})
+// stream writes the matching items with the exporter. Headers are only sent
+// with the first item, so failures before any item is read still produce an
+// error status. Later failures abort the connection, so the client sees the
+// export was cut short instead of a complete-looking 200.
+func (h *ExportHandler) stream(w http.ResponseWriter, r *http.Request, format string, exporter out.ItemExporter, query item.ListQuery) {
+	flusher, _ := w.(http.Flusher)
+	var encoder out.ItemEncoder
+	start := func() (err error) {
+		w.Header().Set("Content-Type", exporter.ContentType())
+		w.Header().Set("Content-Disposition", "attachment;filename=items."+exporter.FileExtension())
+		encoder, err = exporter.NewEncoder(w)
+		return err
+	}
 
-	for _, item := range items {
-		err := writer.Write([]string{
-			strconv.Itoa(item.ID),
-			item.Code,
-			item.Title,
-			item.Description,
-			strconv.FormatFloat(item.Price, 'f', 2, 64),
-			strconv.Itoa(item.Stock),
-			strconv.Itoa(item.CategoryID),
-		})
-		if err != nil {
-			log.Printf("Error writing CSV: %v", err)
-			http.Error(w, "Error writing CSV", http.StatusInternalServerError)
+	count := 0
+	err := h.itemService.ExportItems(r.Context(), query, func(itm *item.Item) error {
+		if encoder == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := encoder.Encode(itm); err != nil {
+			return err
+		}
+		count++
+		if count%exportFlushEvery == 0 {
+			if err := encoder.Flush(); err != nil {
+				return err
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		return nil
+	})
+	if err == nil && encoder == nil {
+		err = start()
+	}
+	if err != nil {
+		log.Printf("Error writing %s export: %v", format, err)
+		if encoder != nil {
+			panic(http.ErrAbortHandler)
+		}
+		if errors.Is(err, item.ErrInvalidQuery) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
+		http.Error(w, "Error writing "+format, http.StatusInternalServerError)
+		return
+	}
+	if err := encoder.Close(); err != nil {
+		log.Printf("Error writing %s export: %v", format, err)
+		panic(http.ErrAbortHandler)
 	}
 }
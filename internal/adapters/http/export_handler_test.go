@@ -0,0 +1,216 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/teamcubation/go-items-challenge/internal/adapters/export"
+	http2 "github.com/teamcubation/go-items-challenge/internal/adapters/http"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/in/mocks"
+)
+
+var exportedItems = []item.Item{
+	{
+		ID: 1, Code: "ABC", Title: "Blue shirt", Description: "Cotton, blue", Price: 10.5, Stock: 5, CategoryID: 2,
+		Status: "ACTIVE", CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), UpdatedAt: time.Date(2026, 1, 3, 3, 4, 5, 0, time.UTC),
+		CreatedBy: 7, UpdatedBy: 8,
+	},
+	{ID: 2, Code: "XYZ", Status: "INACTIVE"},
+}
+
+func streamItems(items []item.Item) func(context.Context, item.ListQuery, func(*item.Item) error) error {
+	return func(_ context.Context, _ item.ListQuery, fn func(*item.Item) error) error {
+		for i := range items {
+			if err := fn(&items[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func TestExportHandler_ExportCSV(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	handler := http2.NewExportHandler(mockService, export.DefaultRegistry())
+
+	categoryID := 2
+	expectedQuery := item.ListQuery{CategoryID: &categoryID, Sort: []item.SortField{{Field: "price", Desc: true}}}
+	mockService.On("ExportItems", mock.Anything, expectedQuery, mock.Anything).Return(streamItems(exportedItems))
+
+	req := httptest.NewRequest(http.MethodGet, "/items/export?format=CSV&category_id=2&sort=-price", nil)
+	rec := httptest.NewRecorder()
+	handler.Export(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+	expected := "ID,Code,Title,Description,Price,Stock,CategoryID,Status,CreatedAt,UpdatedAt,CreatedBy,UpdatedBy\n" +
+		"1,ABC,Blue shirt,\"Cotton, blue\",10.50,5,2,ACTIVE,2026-01-02T03:04:05Z,2026-01-03T03:04:05Z,7,8\n" +
+		"2,XYZ,,,0.00,0,0,INACTIVE,0001-01-01T00:00:00Z,0001-01-01T00:00:00Z,0,0\n"
+	assert.Equal(t, expected, rec.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestExportHandler_ExportCSV_CanBeImported(t *testing.T) {
+	exportService := new(mocks.ItemService)
+	exportService.On("ExportItems", mock.Anything, mock.Anything, mock.Anything).Return(streamItems(exportedItems[:1]))
+	exportRec := httptest.NewRecorder()
+	http2.NewExportHandler(exportService, export.DefaultRegistry()).Export(exportRec, httptest.NewRequest(http.MethodGet, "/items/export?format=CSV", nil))
+
+	importService := new(mocks.ItemService)
+	expectedRows := []item.ImportRow{
+		{Line: 2, Item: &item.Item{Code: "ABC", Title: "Blue shirt", Description: "Cotton, blue", Price: 10.5, Stock: 5, CategoryID: 2}},
+	}
+	importService.On("ImportItems", mock.Anything, expectedRows, item.BulkAtomic, false).
+		Return(&item.BulkReport{Mode: item.BulkAtomic, Succeeded: 1}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/items/import?format=CSV", strings.NewReader(exportRec.Body.String()))
+	rec := httptest.NewRecorder()
+	http2.NewImportHandler(importService).Import(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	importService.AssertExpectations(t)
+}
+
+func TestExportHandler_ExportCSV_FailsBeforeFirstRow(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	handler := http2.NewExportHandler(mockService, export.DefaultRegistry())
+
+	mockService.On("ExportItems", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("connection refused"))
+
+	req := httptest.NewRequest(http.MethodGet, "/items/export?format=CSV", nil)
+	rec := httptest.NewRecorder()
+	handler.Export(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestExportHandler_ExportCSV_FailsAfterFirstRow(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	handler := http2.NewExportHandler(mockService, export.DefaultRegistry())
+
+	mockService.On("ExportItems", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, _ item.ListQuery, fn func(*item.Item) error) error {
+			if err := fn(&exportedItems[0]); err != nil {
+				return err
+			}
+			return errors.New("connection reset")
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/items/export?format=CSV", nil)
+	rec := httptest.NewRecorder()
+	// the connection is aborted, as the status was already sent
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() { handler.Export(rec, req) })
+}
+
+func TestExportHandler_ExportCSV_InvalidFilter(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	handler := http2.NewExportHandler(mockService, export.DefaultRegistry())
+
+	req := httptest.NewRequest(http.MethodGet, "/items/export?format=CSV&status=SOLD", nil)
+	rec := httptest.NewRecorder()
+	handler.Export(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockService.AssertNotCalled(t, "ExportItems", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExportHandler_ExportFormats(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		accept      string
+		contentType string
+		filename    string
+		body        string
+	}{
+		{
+			name:        "JSON by format parameter",
+			url:         "/items/export?format=json",
+			contentType: "application/json",
+			filename:    "items.json",
+			body:        `[{"id":2,"code":"XYZ"`,
+		},
+		{
+			name:        "NDJSON by Accept header",
+			url:         "/items/export",
+			accept:      "application/x-ndjson",
+			contentType: "application/x-ndjson",
+			filename:    "items.ndjson",
+			body:        `{"id":2,"code":"XYZ"`,
+		},
+		{
+			name:        "XML by Accept header with quality",
+			url:         "/items/export",
+			accept:      "text/csv;q=0.5, application/xml, */*;q=0.1",
+			contentType: "application/xml",
+			filename:    "items.xml",
+			body:        `<items><item id="2"><code>XYZ</code>`,
+		},
+		{
+			name:        "format parameter wins over Accept header",
+			url:         "/items/export?format=CSV",
+			accept:      "application/json",
+			contentType: "text/csv",
+			filename:    "items.csv",
+			body:        "2,XYZ,,,0.00,0,0,INACTIVE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ItemService)
+			handler := http2.NewExportHandler(mockService, export.DefaultRegistry())
+			mockService.On("ExportItems", mock.Anything, mock.Anything, mock.Anything).Return(streamItems(exportedItems[1:]))
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			handler.Export(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.contentType, rec.Header().Get("Content-Type"))
+			assert.Equal(t, "attachment;filename="+tt.filename, rec.Header().Get("Content-Disposition"))
+			assert.Contains(t, rec.Body.String(), tt.body)
+		})
+	}
+}
+
+func TestExportHandler_ExportFormatErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		accept string
+		status int
+	}{
+		{name: "missing format", url: "/items/export", status: http.StatusBadRequest},
+		{name: "unknown format", url: "/items/export?format=PDF", status: http.StatusBadRequest},
+		{name: "unsupported Accept header", url: "/items/export", accept: "application/pdf, */*", status: http.StatusNotAcceptable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ItemService)
+			handler := http2.NewExportHandler(mockService, export.DefaultRegistry())
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			handler.Export(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			mockService.AssertNotCalled(t, "ExportItems", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
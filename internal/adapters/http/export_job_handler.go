@@ -0,0 +1,139 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path/filepath"
+
+	"github.com/gorilla/mux"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/in"
+)
+
+type ExportJobHandler struct {
+	exportJobService in.ExportJobService
+}
+
+func NewExportJobHandler(exportJobService in.ExportJobService) *ExportJobHandler {
+	return &ExportJobHandler{exportJobService: exportJobService}
+}
+
+// CreateExportJob cria uma exportação assíncrona
+// @Summary Cria uma exportação assíncrona
+// @Description Agenda a exportação dos itens que atendem aos filtros, com os mesmos parâmetros de /items/export. O arquivo é gerado em segundo plano; acompanhe o progresso em /exports/{id}.
+// @Tags exports
+// @Produce json
+// @Param format query string true "Formato do arquivo: CSV, JSON, NDJSON, XLSX ou XML"
+// @Param status query string false "Status do item"
+// @Param category_id query int false "ID da categoria"
+// @Param min_price query number false "Preço mínimo"
+// @Param max_price query number false "Preço máximo"
+// @Param min_stock query int false "Estoque mínimo"
+// @Param max_stock query int false "Estoque máximo"
+// @Param created_by query int false "ID do usuário que criou o item"
+// @Param created_from query string false "Criado a partir de (RFC 3339)"
+// @Param created_to query string false "Criado até (RFC 3339)"
+// @Param updated_from query string false "Atualizado a partir de (RFC 3339)"
+// @Param updated_to query string false "Atualizado até (RFC 3339)"
+// @Param code_prefix query string false "Prefixo do código"
+// @Param low_stock query bool false "Somente itens no ponto de reposição ou abaixo dele"
+// @Param mine query bool false "Somente itens criados pelo usuário ou compartilhados com ele"
+// @Param sort query string false "Ordenação, ex.: created_at:desc,price"
+// @Success 202 {object} item.ExportJob
+// @Failure 400 {string} string "Parâmetros inválidos"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /exports [post]
+func (h *ExportJobHandler) CreateExportJob(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		http.Error(w, "format query parameter is required", http.StatusBadRequest)
+		return
+	}
+	query, err := parseListFilters(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.exportJobService.CreateExportJob(r.Context(), format, query)
+	if err != nil {
+		if errors.Is(err, item.ErrInvalidQuery) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/api/exports/"+job.ID)
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(job); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetExportJob consulta uma exportação
+// @Summary Consulta uma exportação
+// @Description Retorna o status e o progresso (itens processados de total) de uma exportação do usuário
+// @Tags exports
+// @Produce json
+// @Param id path string true "ID da exportação"
+// @Success 200 {object} item.ExportJob
+// @Failure 404 {string} string "Exportação não encontrada"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /exports/{id} [get]
+func (h *ExportJobHandler) GetExportJob(w http.ResponseWriter, r *http.Request) {
+	job, err := h.exportJobService.GetExportJob(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeExportJobError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(job); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// DownloadExport baixa o arquivo de uma exportação
+// @Summary Baixa o arquivo de uma exportação
+// @Description Retorna o arquivo gerado por uma exportação concluída
+// @Tags exports
+// @Produce octet-stream
+// @Param id path string true "ID da exportação"
+// @Success 200 {file} file
+// @Failure 404 {string} string "Exportação não encontrada"
+// @Failure 409 {string} string "Exportação ainda não concluída"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /exports/{id}/download [get]
+func (h *ExportJobHandler) DownloadExport(w http.ResponseWriter, r *http.Request) {
+	job, file, err := h.exportJobService.OpenExportFile(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeExportJobError(w, err)
+		return
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", job.ContentType)
+	w.Header().Set("Content-Disposition", "attachment;filename=items"+filepath.Ext(job.FileName))
+	modTime := job.CreatedAt
+	if job.FinishedAt != nil {
+		modTime = *job.FinishedAt
+	}
+	http.ServeContent(w, r, job.FileName, modTime, file)
+}
+
+func writeExportJobError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, item.ErrExportJobNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, item.ErrExportNotReady):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,110 @@
+package http_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	http2 "github.com/teamcubation/go-items-challenge/internal/adapters/http"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/in/mocks"
+)
+
+func setupExportJobRouter(service *mocks.ExportJobService) *mux.Router {
+	handler := http2.NewExportJobHandler(service)
+	r := mux.NewRouter()
+	r.HandleFunc("/api/exports", handler.CreateExportJob).Methods("POST")
+	r.HandleFunc("/api/exports/{id}", handler.GetExportJob).Methods("GET")
+	r.HandleFunc("/api/exports/{id}/download", handler.DownloadExport).Methods("GET")
+	return r
+}
+
+type readSeekCloser struct {
+	*strings.Reader
+}
+
+func (readSeekCloser) Close() error { return nil }
+
+func TestExportJobHandler_CreateExportJob(t *testing.T) {
+	service := new(mocks.ExportJobService)
+	router := setupExportJobRouter(service)
+
+	stock := 1
+	query := item.ListQuery{Status: "ACTIVE", MinStock: &stock}
+	service.On("CreateExportJob", mock.Anything, "XLSX", query).
+		Return(&item.ExportJob{ID: "job-1", Format: "XLSX", Status: item.ExportJobPending}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/exports?format=XLSX&status=ACTIVE&min_stock=1", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, "/api/exports/job-1", rec.Header().Get("Location"))
+	var job item.ExportJob
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&job))
+	assert.Equal(t, item.ExportJobPending, job.Status)
+	service.AssertExpectations(t)
+}
+
+func TestExportJobHandler_CreateExportJob_Invalid(t *testing.T) {
+	service := new(mocks.ExportJobService)
+	router := setupExportJobRouter(service)
+
+	service.On("CreateExportJob", mock.Anything, "PDF", mock.Anything).Return(nil, item.ErrInvalidQuery)
+
+	for _, url := range []string{"/api/exports", "/api/exports?format=CSV&min_price=abc", "/api/exports?format=PDF"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, url, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, url)
+	}
+}
+
+func TestExportJobHandler_GetExportJob(t *testing.T) {
+	service := new(mocks.ExportJobService)
+	router := setupExportJobRouter(service)
+
+	service.On("GetExportJob", mock.Anything, "job-1").
+		Return(&item.ExportJob{ID: "job-1", Status: item.ExportJobRunning, Total: 10, Processed: 4}, nil)
+	service.On("GetExportJob", mock.Anything, "job-2").Return(nil, item.ErrExportJobNotFound)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/exports/job-1", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"total":10,"processed":4`)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/exports/job-2", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestExportJobHandler_DownloadExport(t *testing.T) {
+	service := new(mocks.ExportJobService)
+	router := setupExportJobRouter(service)
+
+	finishedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	job := &item.ExportJob{
+		ID: "job-1", Status: item.ExportJobCompleted, FileName: "job-1.csv", ContentType: "text/csv", FinishedAt: &finishedAt,
+	}
+	service.On("OpenExportFile", mock.Anything, "job-1").Return(job, readSeekCloser{strings.NewReader("ID,Code\n")}, nil)
+	service.On("OpenExportFile", mock.Anything, "job-2").Return(nil, nil, item.ErrExportNotReady)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/exports/job-1/download", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "attachment;filename=items.csv", rec.Header().Get("Content-Disposition"))
+	body, _ := io.ReadAll(rec.Body)
+	assert.Equal(t, "ID,Code\n", string(body))
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/exports/job-2/download", nil))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
@@ -0,0 +1,171 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+const maxImportRows = 10000
+
+var errTooManyRows = fmt.Errorf("at most %d rows are allowed per import", maxImportRows)
+
+// csvColumnSetters map the normalized CSV header names written by the
+// exporter to the item field they fill.
+var csvColumnSetters = map[string]func(itm *item.Item, value string) error{
+	"code":        func(itm *item.Item, v string) error { itm.Code = v; return nil },
+	"title":       func(itm *item.Item, v string) error { itm.Title = v; return nil },
+	"description": func(itm *item.Item, v string) error { itm.Description = v; return nil },
+	"price": func(itm *item.Item, v string) (err error) {
+		itm.Price, err = parseCSVFloat(v)
+		return err
+	},
+	"stock": func(itm *item.Item, v string) (err error) {
+		itm.Stock, err = parseCSVInt(v)
+		return err
+	},
+	"categoryid": func(itm *item.Item, v string) (err error) {
+		itm.CategoryID, err = parseCSVInt(v)
+		return err
+	},
+}
+
+// csvIgnoredColumns are exported but managed by the server, so their values
+// are not imported.
+var csvIgnoredColumns = map[string]bool{
+	"id":        true,
+	"status":    true,
+	"createdat": true,
+	"updatedat": true,
+	"createdby": true,
+	"updatedby": true,
+}
+
+// decodeCSVItems reads items from a CSV file whose first line is a header with
+// the column names used by the CSV export. Rows that cannot be decoded are
+// returned with their error so they can be reported by line number.
+func decodeCSVItems(r io.Reader) ([]item.ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+
+	setters := make([]func(*item.Item, string) error, len(header))
+	hasCode := false
+	for i, name := range header {
+		key := strings.NewReplacer("_", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+		if csvIgnoredColumns[key] {
+			continue
+		}
+		setter, ok := csvColumnSetters[key]
+		if !ok {
+			return nil, fmt.Errorf("line 1: unknown column %q", name)
+		}
+		setters[i] = setter
+		hasCode = hasCode || key == "code"
+	}
+	if !hasCode {
+		return nil, errors.New("line 1: the Code column is required")
+	}
+
+	var rows []item.ImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if len(rows) == maxImportRows {
+			return nil, errTooManyRows
+		}
+
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount) {
+				rows = append(rows, item.ImportRow{Line: parseErr.StartLine, Error: "wrong number of fields"})
+				continue
+			}
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := item.ImportRow{Line: line, Item: &item.Item{}}
+		for i, value := range record {
+			if setters[i] == nil {
+				continue
+			}
+			if err := setters[i](row.Item, value); err != nil {
+				row = item.ImportRow{Line: line, Error: fmt.Sprintf("column %s: %v", header[i], err)}
+				break
+			}
+		}
+		rows = append(rows, row)
+	}
+}
+
+// decodeNDJSONItems reads one JSON item per line. Blank lines are skipped.
+func decodeNDJSONItems(r io.Reader) ([]item.ImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var rows []item.ImportRow
+	for line := 1; scanner.Scan(); line++ {
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		if len(rows) == maxImportRows {
+			return nil, errTooManyRows
+		}
+
+		var itm item.Item
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&itm); err != nil {
+			rows = append(rows, item.ImportRow{Line: line, Error: fmt.Sprintf("invalid JSON: %v", err)})
+			continue
+		}
+		rows = append(rows, item.ImportRow{Line: line, Item: importableFields(itm)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// importableFields drops the fields managed by the server, matching the
+// columns ignored by the CSV import.
+func importableFields(itm item.Item) *item.Item {
+	return &item.Item{
+		Code:        itm.Code,
+		Title:       itm.Title,
+		Description: itm.Description,
+		CategoryID:  itm.CategoryID,
+		Price:       itm.Price,
+		Stock:       itm.Stock,
+	}
+}
+
+func parseCSVFloat(v string) (float64, error) {
+	if v == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(v, 64)
+}
+
+func parseCSVInt(v string) (int, error) {
+	if v == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(v)
+}
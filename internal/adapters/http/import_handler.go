@@ -0,0 +1,91 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/in"
+)
+
+const maxImportSize = 32 << 20
+
+type ImportHandler struct {
+	itemService in.ItemService
+}
+
+func NewImportHandler(itemService in.ItemService) *ImportHandler {
+	return &ImportHandler{itemService: itemService}
+}
+
+// Import importa itens de um arquivo CSV ou NDJSON
+// @Summary Importa itens de um arquivo CSV ou NDJSON
+// @Description Cria ou atualiza itens pelo código a partir de um arquivo com o mesmo layout do export CSV, ou de um JSON por linha. O estoque só é usado nos itens criados; o dos itens existentes é alterado apenas por movimentações. As atualizações sobrescrevem o item sem checar a versão, a menos que a linha JSON informe uma version. Erros de validação são reportados com o número da linha.
+// @Tags items
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Param format query string false "CSV ou NDJSON; se omitido, é inferido do Content-Type"
+// @Param mode query string false "atomic ou best_effort" default(atomic)
+// @Param dry_run query bool false "Apenas valida, sem gravar"
+// @Success 200 {object} item.BulkReport
+// @Success 207 {object} item.BulkReport "Alguma linha falhou"
+// @Failure 400 {string} string "Arquivo, formato ou parâmetros inválidos"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /items/import [post]
+func (h *ImportHandler) Import(w http.ResponseWriter, r *http.Request) {
+	mode, err := item.ParseBulkMode(r.URL.Query().Get("mode"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dryRun := false
+	if raw := r.URL.Query().Get("dry_run"); raw != "" {
+		dryRun, err = strconv.ParseBool(raw)
+		if err != nil {
+			http.Error(w, "Invalid dry_run", http.StatusBadRequest)
+			return
+		}
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxImportSize)
+	var rows []item.ImportRow
+	switch importFormat(r) {
+	case "CSV":
+		rows, err = decodeCSVItems(body)
+	case "NDJSON":
+		rows, err = decodeNDJSONItems(body)
+	default:
+		http.Error(w, "Unsupported format", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(rows) == 0 {
+		http.Error(w, "the file has no rows to import", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.itemService.ImportItems(r.Context(), rows, mode, dryRun)
+	writeBulkReport(w, report, err)
+}
+
+// importFormat returns the format query parameter or, when absent, the format
+// matching the Content-Type of the request.
+func importFormat(r *http.Request) string {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return strings.ToUpper(format)
+	}
+	contentType := strings.TrimSpace(strings.Split(r.Header.Get("Content-Type"), ";")[0])
+	switch contentType {
+	case "text/csv":
+		return "CSV"
+	case "application/x-ndjson", "application/ndjson":
+		return "NDJSON"
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	http2 "github.com/teamcubation/go-items-challenge/internal/adapters/http"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/in/mocks"
+)
+
+func TestImportHandler_CSV(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	handler := http2.NewImportHandler(mockService)
+
+	body := "ID,Code,Title,Description,Price,Stock,CategoryID\n" +
+		"1,ABC,Blue shirt,Cotton,10.50,5,2\n" +
+		",XYZ,Red shirt,,abc,1,2\n" +
+		",QWE,Hat\n"
+	expectedRows := []item.ImportRow{
+		{Line: 2, Item: &item.Item{Code: "ABC", Title: "Blue shirt", Description: "Cotton", Price: 10.5, Stock: 5, CategoryID: 2}},
+		{Line: 3, Error: `column Price: strconv.ParseFloat: parsing "abc": invalid syntax`},
+		{Line: 4, Error: "wrong number of fields"},
+	}
+	report := &item.BulkReport{Mode: item.BulkBestEffort, DryRun: true, Succeeded: 1, Failed: 2}
+	mockService.On("ImportItems", mock.Anything, expectedRows, item.BulkBestEffort, true).Return(report, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/items/import?mode=best_effort&dry_run=true", strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv")
+	rec := httptest.NewRecorder()
+	handler.Import(rec, req)
+
+	assert.Equal(t, http.StatusMultiStatus, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestImportHandler_NDJSON(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	handler := http2.NewImportHandler(mockService)
+
+	body := `{"id": 7, "code": "ABC", "price": 3, "status": "ACTIVE"}` + "\n\n" + `{"code": 12}` + "\n"
+	expectedRows := []item.ImportRow{
+		{Line: 1, Item: &item.Item{Code: "ABC", Price: 3}},
+		{Line: 3, Error: "invalid JSON: json: cannot unmarshal number into Go struct field Item.code of type string"},
+	}
+	report := &item.BulkReport{Mode: item.BulkAtomic, Succeeded: 1}
+	mockService.On("ImportItems", mock.Anything, expectedRows, item.BulkAtomic, false).Return(report, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/items/import?format=ndjson", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.Import(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestImportHandler_UnknownColumn(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	handler := http2.NewImportHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/items/import?format=csv", strings.NewReader("Code,Colour\nABC,red\n"))
+	rec := httptest.NewRecorder()
+	handler.Import(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), `unknown column "Colour"`)
+	mockService.AssertNotCalled(t, "ImportItems", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
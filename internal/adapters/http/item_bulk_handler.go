@@ -0,0 +1,126 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+const maxBulkItems = 1000
+
+// BulkCreateItems cria vários itens
+// @Summary Cria vários itens
+// @Description Cria os itens enviados em um array. No modo atomic (padrão) todos são gravados em uma única transação; no modo best_effort cada item é gravado de forma independente.
+// @Tags items
+// @Accept json
+// @Produce json
+// @Param mode query string false "atomic ou best_effort" default(atomic)
+// @Param items body []item.Item true "Itens a criar"
+// @Success 200 {object} item.BulkReport
+// @Success 207 {object} item.BulkReport "Algum item falhou"
+// @Failure 400 {string} string "Corpo ou modo inválido"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /items/bulk [post]
+func (h *ItemHandler) BulkCreateItems(w http.ResponseWriter, r *http.Request) {
+	mode, err := item.ParseBulkMode(r.URL.Query().Get("mode"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var items []*item.Item
+	if err := decodeBulkBody(r, &items); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	report, err := h.itemService.BulkCreateItems(r.Context(), items, mode)
+	writeBulkReport(w, report, err)
+}
+
+// BulkUpdateItems atualiza vários itens
+// @Summary Atualiza vários itens
+// @Description Atualiza os itens enviados em um array; cada item deve informar seu ID e a versão em que se baseia, e falha se o item foi alterado desde então. No modo atomic (padrão) todos são gravados em uma única transação; no modo best_effort cada item é gravado de forma independente.
+// @Tags items
+// @Accept json
+// @Produce json
+// @Param mode query string false "atomic ou best_effort" default(atomic)
+// @Param items body []item.Item true "Itens a atualizar"
+// @Success 200 {object} item.BulkReport
+// @Success 207 {object} item.BulkReport "Algum item falhou"
+// @Failure 400 {string} string "Corpo ou modo inválido"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /items/bulk [put]
+func (h *ItemHandler) BulkUpdateItems(w http.ResponseWriter, r *http.Request) {
+	mode, err := item.ParseBulkMode(r.URL.Query().Get("mode"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var items []*item.Item
+	if err := decodeBulkBody(r, &items); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	report, err := h.itemService.BulkUpdateItems(r.Context(), items, mode)
+	writeBulkReport(w, report, err)
+}
+
+// BulkDeleteItems deleta vários itens
+// @Summary Deleta vários itens
+// @Description Deleta os itens enviados em um array; cada entrada deve informar o ID e a versão em que se baseia, e falha se o item foi alterado desde então. No modo atomic (padrão) todos são removidos em uma única transação; no modo best_effort cada item é removido de forma independente.
+// @Tags items
+// @Accept json
+// @Produce json
+// @Param mode query string false "atomic ou best_effort" default(atomic)
+// @Param items body []item.BulkDeleteEntry true "IDs e versões dos itens"
+// @Success 200 {object} item.BulkReport
+// @Success 207 {object} item.BulkReport "Algum item falhou"
+// @Failure 400 {string} string "Corpo ou modo inválido"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /items/bulk [delete]
+func (h *ItemHandler) BulkDeleteItems(w http.ResponseWriter, r *http.Request) {
+	mode, err := item.ParseBulkMode(r.URL.Query().Get("mode"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var entries []item.BulkDeleteEntry
+	if err := decodeBulkBody(r, &entries); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	report, err := h.itemService.BulkDeleteItems(r.Context(), entries, mode)
+	writeBulkReport(w, report, err)
+}
+
+// decodeBulkBody decodes a JSON array into dst, which must point to a slice,
+// and enforces maxBulkItems.
+func decodeBulkBody[T any](r *http.Request, dst *[]T) error {
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	if len(*dst) == 0 {
+		return fmt.Errorf("request body must be a non-empty array")
+	}
+	if len(*dst) > maxBulkItems {
+		return fmt.Errorf("at most %d entries are allowed per request", maxBulkItems)
+	}
+	return nil
+}
+
+// writeBulkReport answers 200 when every entry succeeded and 207 otherwise.
+func writeBulkReport(w http.ResponseWriter, report *item.BulkReport, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if report.Failed > 0 {
+		w.WriteHeader(http.StatusMultiStatus)
+	}
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,125 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+type grantRequest struct {
+	UserID int `json:"user_id" example:"7"`
+}
+
+// GrantItem compartilha um item com outro usuário
+// @Summary Compartilha um item com outro usuário
+// @Description Permite que o usuário altere o item como o seu criador, exceto deletá-lo ou gerenciar os compartilhamentos. Somente o criador do item ou um admin podem compartilhá-lo.
+// @Tags items
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do item"
+// @Param grant body grantRequest true "Usuário com quem o item é compartilhado"
+// @Success 201 {object} item.ItemGrant
+// @Failure 400 {string} string "Requisição inválida"
+// @Failure 403 {string} string "O item pertence a outro usuário"
+// @Failure 404 {string} string "Item não encontrado"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /items/{id}/grants [post]
+func (h *ItemHandler) GrantItem(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+	var req grantRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil || req.UserID <= 0 {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	grant, err := h.itemService.GrantItem(r.Context(), id, req.UserID)
+	if err != nil {
+		writeGrantError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(grant); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// ListItemGrants lista os compartilhamentos de um item
+// @Summary Lista os compartilhamentos de um item
+// @Tags items
+// @Produce json
+// @Param id path int true "ID do item"
+// @Success 200 {array} item.ItemGrant
+// @Failure 400 {string} string "ID de item inválido"
+// @Failure 403 {string} string "O item pertence a outro usuário"
+// @Failure 404 {string} string "Item não encontrado"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /items/{id}/grants [get]
+func (h *ItemHandler) ListItemGrants(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+	grants, err := h.itemService.ListItemGrants(r.Context(), id)
+	if err != nil {
+		writeGrantError(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(grants); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// RevokeItemGrant desfaz o compartilhamento de um item
+// @Summary Desfaz o compartilhamento de um item
+// @Tags items
+// @Param id path int true "ID do item"
+// @Param user_id path int true "ID do usuário"
+// @Success 204
+// @Failure 400 {string} string "Parâmetros inválidos"
+// @Failure 403 {string} string "O item pertence a outro usuário"
+// @Failure 404 {string} string "Item ou compartilhamento não encontrado"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /items/{id}/grants/{user_id} [delete]
+func (h *ItemHandler) RevokeItemGrant(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+	userID, err := strconv.Atoi(vars["user_id"])
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+	if err := h.itemService.RevokeItemGrant(r.Context(), id, userID); err != nil {
+		writeGrantError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeGrantError maps the errors of item grants to a status.
+func writeGrantError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, item.ErrItemNotFound), errors.Is(err, item.ErrGrantNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, item.ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -2,9 +2,12 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/teamcubation/go-items-challenge/internal/utils"
 	"net/http"
+	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/teamcubation/go-items-challenge/internal/domain/item"
@@ -22,12 +25,14 @@ func NewItemHandler(itemService in.ItemService) *ItemHandler {
 
 // CreateItem cria um novo item
 // @Summary Cria um novo item
-// @Description Cria um novo item com os dados fornecidos no corpo da requisição
+// @Description Cria um novo item com os dados fornecidos no corpo da requisição. O item é criado como ACTIVE, ou OUT_OF_STOCK sem estoque, a menos que o status enviado seja DRAFT. O ID e o public_id são atribuídos pelo servidor; sem código, ele é gerado a partir do padrão configurado, quando houver.
 // @Tags items
 // @Accept json
 // @Produce json
 // @Param item body item.Item true "Informações do item"
 // @Success 200 {object} item.Item
+// @Failure 400 {string} string "Campos ausentes ou inválidos"
+// @Failure 409 {string} string "Já existe um item com este código"
 // @Failure 500 {string} string "Erro interno do servidor"
 // @Router /items [post]
 func (h *ItemHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
@@ -42,9 +47,17 @@ func (h *ItemHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
 	}
 	createdItem, err := h.itemService.CreateItem(r.Context(), &itm)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, item.ErrInvalidItem):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, item.ErrDuplicateCode):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
 		return
 	}
+	setItemETag(w, createdItem)
 	if err := json.NewEncoder(w).Encode(createdItem); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -53,14 +66,20 @@ func (h *ItemHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
 
 // UpdateItem atualiza um item existente
 // @Summary Atualiza um item existente
-// @Description Atualiza um item existente com os dados fornecidos no corpo da requisição
+// @Description Substitui um item existente pelos dados fornecidos no corpo da requisição; campos omitidos ficam com valor zero. O código do item não pode ser alterado, e o estoque, alterado apenas por movimentações, é ignorado.
 // @Tags items
 // @Accept json
 // @Produce json
 // @Param id path int true "ID do item"
+// @Param If-Match header string true "ETag da versão do item, ou *"
 // @Param item body item.Item true "Informações do item"
 // @Success 200 {object} item.Item
+// @Failure 400 {string} string "Campos inválidos, ou código alterado"
+// @Failure 403 {string} string "O item pertence a outro usuário"
 // @Failure 404 {string} string "ID de item inválido"
+// @Failure 409 {string} string "O item está arquivado"
+// @Failure 412 {string} string "O item foi alterado por outra requisição"
+// @Failure 428 {string} string "If-Match ausente"
 // @Failure 500 {string} string "Erro interno do servidor"
 // @Router /items/{id} [put]
 func (h *ItemHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
@@ -70,21 +89,28 @@ func (h *ItemHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid item ID", http.StatusBadRequest)
 		return
 	}
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeIfMatchError(w, err)
+		return
+	}
 	var itm item.Item
 	if err := json.NewDecoder(r.Body).Decode(&itm); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	if err := utils.ValidateStruct(&itm); err != nil {
+	if err := utils.ValidateStruct(&itm); err != nil || itm.Code == "" {
 		http.Error(w, "missing or invalid fields in the body", http.StatusBadRequest)
 		return
 	}
 	itm.ID = id
+	itm.Version = version
 	updatedItem, err := h.itemService.UpdateItem(r.Context(), &itm)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeItemWriteError(w, err)
 		return
 	}
+	setItemETag(w, updatedItem)
 	if err := json.NewEncoder(w).Encode(updatedItem); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -93,13 +119,17 @@ func (h *ItemHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
 
 // DeleteItem deleta um item existente
 // @Summary Deleta um item existente
-// @Description Deleta um item existente com o ID fornecido
+// @Description Deleta um item existente com o ID fornecido. O item é marcado como deletado e pode ser restaurado até ser expurgado.
 // @Tags items
 // @Accept json
 // @Produce json
 // @Param id path int true "ID do item"
+// @Param If-Match header string true "ETag da versão do item, ou *"
 // @Success 200 {object} item.Item
 // @Failure 400 {string} string "ID de Item não encontrado"
+// @Failure 403 {string} string "O item pertence a outro usuário"
+// @Failure 412 {string} string "O item foi alterado por outra requisição"
+// @Failure 428 {string} string "If-Match ausente"
 // @Failure 500 {string} string "Erro interno do servidor"
 // @Router /items/{id} [delete]
 func (h *ItemHandler) DeleteItem(w http.ResponseWriter, r *http.Request) {
@@ -109,9 +139,14 @@ func (h *ItemHandler) DeleteItem(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid item ID", http.StatusBadRequest)
 		return
 	}
-	deletedItem, err := h.itemService.DeleteItem(r.Context(), id)
+	version, err := ifMatchVersion(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeIfMatchError(w, err)
+		return
+	}
+	deletedItem, err := h.itemService.DeleteItem(r.Context(), id, version)
+	if err != nil {
+		writeItemWriteError(w, err)
 		return
 	}
 	if err := json.NewEncoder(w).Encode(deletedItem); err != nil {
@@ -120,16 +155,179 @@ func (h *ItemHandler) DeleteItem(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// RestoreItem restaura um item deletado
+// @Summary Restaura um item deletado
+// @Description Desfaz a deleção de um item que ainda não foi expurgado. If-Match é opcional; se enviado, deve conter a versão atual do item deletado.
+// @Tags items
+// @Produce json
+// @Param id path int true "ID do item"
+// @Param If-Match header string false "ETag da versão do item, ou *"
+// @Success 200 {object} item.Item
+// @Failure 403 {string} string "O item pertence a outro usuário"
+// @Failure 404 {string} string "Item não encontrado"
+// @Failure 409 {string} string "O item não está deletado"
+// @Failure 412 {string} string "O item foi alterado por outra requisição"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /items/{id}/restore [post]
+func (h *ItemHandler) RestoreItem(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+	version, err := ifMatchVersion(r)
+	if err != nil && !errors.Is(err, errPreconditionRequired) {
+		writeIfMatchError(w, err)
+		return
+	}
+
+	restoredItem, err := h.itemService.RestoreItem(r.Context(), id, version)
+	if err != nil {
+		if errors.Is(err, item.ErrItemNotDeleted) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		writeItemWriteError(w, err)
+		return
+	}
+	setItemETag(w, restoredItem)
+	if err := json.NewEncoder(w).Encode(restoredItem); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// RevertItem reverte um item para uma revisão anterior
+// @Summary Reverte um item para uma revisão anterior
+// @Description Substitui os campos editáveis do item pelos da revisão informada, com as mesmas validações da atualização. A revisão é a versão do item registrada no histórico. If-Match é opcional; se enviado, deve conter a versão atual do item.
+// @Tags items
+// @Produce json
+// @Param id path int true "ID do item"
+// @Param rev path int true "Revisão do item"
+// @Param If-Match header string false "ETag da versão do item, ou *"
+// @Success 200 {object} item.Item
+// @Failure 400 {string} string "Parâmetros inválidos"
+// @Failure 403 {string} string "O item pertence a outro usuário"
+// @Failure 404 {string} string "Item ou revisão não encontrados"
+// @Failure 409 {string} string "O item não tem histórico, ou está arquivado"
+// @Failure 412 {string} string "O item foi alterado por outra requisição"
+// @Failure 422 {string} string "A revisão não é mais válida"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /items/{id}/revisions/{rev}/revert [post]
+func (h *ItemHandler) RevertItem(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+	revision, err := strconv.Atoi(vars["rev"])
+	if err != nil {
+		http.Error(w, "Invalid revision", http.StatusBadRequest)
+		return
+	}
+	version, err := ifMatchVersion(r)
+	if err != nil && !errors.Is(err, errPreconditionRequired) {
+		writeIfMatchError(w, err)
+		return
+	}
+
+	revertedItem, err := h.itemService.RevertItem(r.Context(), id, revision, version)
+	if err != nil {
+		switch {
+		case errors.Is(err, item.ErrRevisionNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, item.ErrNoHistory):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case errors.Is(err, item.ErrInvalidItem):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		default:
+			writeItemWriteError(w, err)
+		}
+		return
+	}
+	setItemETag(w, revertedItem)
+	if err := json.NewEncoder(w).Encode(revertedItem); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+type transitionRequest struct {
+	Status string `json:"status" example:"DISCONTINUED"`
+}
+
+// TransitionItem altera o status de um item
+// @Summary Altera o status de um item
+// @Description Move o item para outro status do ciclo de vida: DRAFT → ACTIVE → OUT_OF_STOCK → DISCONTINUED → ARCHIVED. Um rascunho também pode ser arquivado. ACTIVE e OUT_OF_STOCK seguem o estoque: ao publicar um rascunho (status ACTIVE) sem estoque, ele fica OUT_OF_STOCK. If-Match é opcional; se enviado, deve conter a versão atual do item.
+// @Tags items
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do item"
+// @Param transition body transitionRequest true "Novo status"
+// @Param If-Match header string false "ETag da versão do item, ou *"
+// @Success 200 {object} item.Item
+// @Failure 400 {string} string "Status inválido"
+// @Failure 403 {string} string "O item pertence a outro usuário"
+// @Failure 404 {string} string "Item não encontrado"
+// @Failure 409 {string} string "Transição não permitida a partir do status atual"
+// @Failure 412 {string} string "O item foi alterado por outra requisição"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /items/{id}/transitions [post]
+func (h *ItemHandler) TransitionItem(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+	version, err := ifMatchVersion(r)
+	if err != nil && !errors.Is(err, errPreconditionRequired) {
+		writeIfMatchError(w, err)
+		return
+	}
+	var req transitionRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	status, err := item.ParseStatus(req.Status)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	transitioned, err := h.itemService.TransitionItem(r.Context(), id, status, version)
+	if err != nil {
+		if errors.Is(err, item.ErrInvalidTransition) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		writeItemWriteError(w, err)
+		return
+	}
+	setItemETag(w, transitioned)
+	if err := json.NewEncoder(w).Encode(transitioned); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
 // GetItemById recupera um item pelo ID
 // @Summary Recupera um item pelo ID
-// @Description Recupera um item existente com o ID fornecido
+// @Description Recupera um item existente com o ID fornecido. A versão é devolvida no header ETag; com If-None-Match igual a ela, responde 304. Com as_of, devolve o item como estava no instante informado.
 // @Tags items
 // @Accept json
 // @Produce json
 // @Param id path int true "ID do item"
+// @Param as_of query string false "Instante no formato RFC 3339"
+// @Param If-None-Match header string false "ETag já conhecido pelo cliente"
 // @Success 200 {object} item.Item
+// @Success 304 "Item não modificado"
 // @Failute 400 {string} string "ID de item inválido"
 // @Failure 404 {string} string "Item não encontrado"
+// @Failure 409 {string} string "O item não tem histórico para as_of"
 // @Router /items/{id} [get]
 func (h *ItemHandler) GetItemByID(w http.ResponseWriter, r *http.Request) {
 	ctx := log.Context(r)
@@ -142,6 +340,10 @@ func (h *ItemHandler) GetItemByID(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid item ID", http.StatusBadRequest)
 		return
 	}
+	if r.URL.Query().Has("as_of") {
+		h.getItemAsOf(w, r, id)
+		return
+	}
 	itm, err := h.itemService.GetItemByID(r.Context(), id)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusNotFound)
@@ -151,6 +353,12 @@ func (h *ItemHandler) GetItemByID(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Item not found", http.StatusNotFound)
 		return
 	}
+	etag := itemETag(itm)
+	w.Header().Set("ETag", etag)
+	if !noneMatch(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
 	if err := json.NewEncoder(w).Encode(itm); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -159,40 +367,195 @@ func (h *ItemHandler) GetItemByID(w http.ResponseWriter, r *http.Request) {
 
 // ListItems lista os itens
 // @Summary Lista os itens
-// @Description Lista os itens com base nos parâmetros fornecidos
+// @Description Lista os itens com base nos filtros, ordenação e paginação fornecidos
 // @Tags items
 // @Accept json
 // @Produce json
-// @Param status query string false "Status do item"
-// @Param limit query int false "Limite de itens por página"
-// @Param page query int false "Página"
-// @Success 200 {object} []item.Item
-// @Failure 400 {string} string "Página inválida"
-// @Failure 400 {string} string "Limite inválido"
+// @Param status query string false "Status do item: DRAFT, ACTIVE, OUT_OF_STOCK, DISCONTINUED ou ARCHIVED" default(ACTIVE)
+// @Param limit query int true "Limite de itens por página"
+// @Param page query int false "Página (obrigatória sem cursor)"
+// @Param cursor query string false "Cursor opaco retornado em next_cursor; vazio inicia a paginação por cursor"
+// @Param category_id query int false "ID da categoria"
+// @Param min_price query number false "Preço mínimo"
+// @Param max_price query number false "Preço máximo"
+// @Param min_stock query int false "Estoque mínimo"
+// @Param max_stock query int false "Estoque máximo"
+// @Param created_by query int false "ID do usuário que criou o item"
+// @Param created_from query string false "Criado a partir de (RFC 3339)"
+// @Param created_to query string false "Criado até (RFC 3339)"
+// @Param updated_from query string false "Atualizado a partir de (RFC 3339)"
+// @Param updated_to query string false "Atualizado até (RFC 3339)"
+// @Param code_prefix query string false "Prefixo do código"
+// @Param low_stock query bool false "Somente itens no ponto de reposição ou abaixo dele"
+// @Param mine query bool false "Somente itens criados pelo usuário ou compartilhados com ele"
+// @Param sort query string false "Ordenação, ex.: created_at:desc,price ou -created_at"
+// @Param include_deleted query bool false "Inclui itens deletados (somente administradores)"
+// @Success 200 {object} item.Response
+// @Failure 400 {string} string "Parâmetros inválidos"
+// @Failure 403 {string} string "Somente administradores podem listar itens deletados"
 // @Failure 500 {string} string "Erro interno do servidor"
 // @Router /items [get]
 func (h *ItemHandler) ListItems(w http.ResponseWriter, r *http.Request) {
-	status := r.URL.Query().Get("status")
-	limitStr := r.URL.Query().Get("limit")
+	query, err := parseListQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	items, err := h.itemService.ListItems(r.Context(), query)
 	if err != nil {
-		http.Error(w, "Invalid page", http.StatusBadRequest)
+		switch {
+		case errors.Is(err, item.ErrInvalidQuery):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, item.ErrForbidden):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	if err := json.NewEncoder(w).Encode(items); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// SearchItems busca itens por texto
+// @Summary Busca itens por texto
+// @Description Busca textual no código, título e descrição dos itens com o status informado, ordenada por relevância. Cada termo é buscado como prefixo.
+// @Tags items
+// @Accept json
+// @Produce json
+// @Param q query string true "Texto da busca"
+// @Param status query string false "Status do item: DRAFT, ACTIVE, OUT_OF_STOCK, DISCONTINUED ou ARCHIVED" default(ACTIVE)
+// @Param limit query int false "Limite de itens por página" default(10)
+// @Param page query int false "Página" default(1)
+// @Success 200 {object} item.SearchResponse
+// @Failure 400 {string} string "Parâmetros inválidos"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /items/search [get]
+func (h *ItemHandler) SearchItems(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+	query := item.SearchQuery{Text: values.Get("q"), Status: values.Get("status"), Limit: 10, Page: 1}
+	if err := parsePageParams(values, &query.Limit, &query.Page); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	limit, err := strconv.Atoi(limitStr)
+	results, err := h.itemService.SearchItems(r.Context(), query)
 	if err != nil {
-		http.Error(w, "Invalid limit", http.StatusBadRequest)
+		if errors.Is(err, item.ErrInvalidQuery) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	items, _, err := h.itemService.ListItems(r.Context(), status, limit, page)
+}
+
+// getItemAsOf writes the snapshot of the item at the as_of query parameter.
+// Past states do not change, so no ETag is sent.
+func (h *ItemHandler) getItemAsOf(w http.ResponseWriter, r *http.Request, id int) {
+	asOf, err := time.Parse(time.RFC3339, r.URL.Query().Get("as_of"))
+	if err != nil {
+		http.Error(w, "Invalid as_of", http.StatusBadRequest)
+		return
+	}
+	itm, err := h.itemService.GetItemAsOf(r.Context(), id, asOf)
 	if err != nil {
+		switch {
+		case errors.Is(err, item.ErrItemNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, item.ErrNoHistory):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	if err := json.NewEncoder(w).Encode(itm); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	if err := json.NewEncoder(w).Encode(items); err != nil {
+}
+
+// ItemHistory lista o histórico de alterações de um item
+// @Summary Lista o histórico de alterações de um item
+// @Description Lista as entradas de auditoria do item, da mais recente para a mais antiga, com o autor, o horário, o ID da requisição e os campos alterados. O histórico é mantido mesmo após a deleção do item.
+// @Tags items
+// @Produce json
+// @Param id path int true "ID do item"
+// @Param limit query int false "Limite de entradas por página" default(20)
+// @Param page query int false "Página" default(1)
+// @Success 200 {object} item.HistoryResponse
+// @Failure 400 {string} string "Parâmetros inválidos"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /items/{id}/history [get]
+func (h *ItemHandler) ItemHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+	query := item.HistoryQuery{ItemID: id, Limit: 20, Page: 1}
+	if err := parsePageParams(r.URL.Query(), &query.Limit, &query.Page); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	history, err := h.itemService.ItemHistory(r.Context(), query)
+	if err != nil {
+		if errors.Is(err, item.ErrInvalidQuery) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if err := json.NewEncoder(w).Encode(history); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// parsePageParams overrides limit and page with the query parameters of the
+// same name, when present.
+func parsePageParams(values url.Values, limit, page *int) error {
+	if raw := values.Get("limit"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			return errors.New("Invalid limit")
+		}
+		*limit = v
+	}
+	if raw := values.Get("page"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			return errors.New("Invalid page")
+		}
+		*page = v
+	}
+	return nil
+}
+
+// writeItemWriteError maps the errors of item updates and deletes to a status.
+func writeItemWriteError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, item.ErrItemNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, item.ErrVersionConflict):
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+	case errors.Is(err, item.ErrDuplicateCode), errors.Is(err, item.ErrItemArchived):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, item.ErrStockNotEditable), errors.Is(err, item.ErrCodeImmutable):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, item.ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
 }
@@ -3,26 +3,54 @@ package http_test
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	http2 "github.com/teamcubation/go-items-challenge/internal/adapters/http"
 	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/domain/warehouse"
 	"github.com/teamcubation/go-items-challenge/internal/ports/in/mocks"
 )
 
 func setupRouter(handler *http2.ItemHandler) *mux.Router {
 	r := mux.NewRouter()
+	r.HandleFunc("/items/search", handler.SearchItems).Methods(http.MethodGet)
+	r.HandleFunc("/items/bulk", handler.BulkCreateItems).Methods(http.MethodPost)
+	r.HandleFunc("/items/bulk", handler.BulkUpdateItems).Methods(http.MethodPut)
+	r.HandleFunc("/items/bulk", handler.BulkDeleteItems).Methods(http.MethodDelete)
 	r.HandleFunc("/items", handler.CreateItem).Methods(http.MethodPost)
 	r.HandleFunc("/items/{id}", handler.UpdateItem).Methods(http.MethodPut)
+	r.HandleFunc("/items/{id}", handler.PatchItem).Methods(http.MethodPatch)
 	r.HandleFunc("/items/{id}", handler.DeleteItem).Methods(http.MethodDelete)
 	r.HandleFunc("/items/{id}", handler.GetItemByID).Methods(http.MethodGet)
+	r.HandleFunc("/items/{id}/restore", handler.RestoreItem).Methods(http.MethodPost)
+	r.HandleFunc("/items/{id}/history", handler.ItemHistory).Methods(http.MethodGet)
+	r.HandleFunc("/items/{id}/revisions/{rev}/revert", handler.RevertItem).Methods(http.MethodPost)
+	r.HandleFunc("/items/{id}/transitions", handler.TransitionItem).Methods(http.MethodPost)
+	r.HandleFunc("/items/{id}/stock-movements", handler.CreateStockMovement).Methods(http.MethodPost)
+	r.HandleFunc("/items/{id}/stock-movements", handler.ListStockMovements).Methods(http.MethodGet)
+	r.HandleFunc("/items/{id}/stock-levels", handler.ListStockLevels).Methods(http.MethodGet)
+	r.HandleFunc("/items/{id}/stock-transfers", handler.TransferStock).Methods(http.MethodPost)
+	r.HandleFunc("/items/{id}/reservations", handler.CreateReservation).Methods(http.MethodPost)
+	r.HandleFunc("/items/{id}/grants", handler.GrantItem).Methods(http.MethodPost)
+	r.HandleFunc("/items/{id}/grants", handler.ListItemGrants).Methods(http.MethodGet)
+	r.HandleFunc("/items/{id}/grants/{user_id}", handler.RevokeItemGrant).Methods(http.MethodDelete)
 	r.HandleFunc("/items", handler.ListItems).Methods(http.MethodGet)
+	r.HandleFunc("/categories/{id}/reorder-point", handler.GetCategoryReorderPoint).Methods(http.MethodGet)
+	r.HandleFunc("/categories/{id}/reorder-point", handler.SetCategoryReorderPoint).Methods(http.MethodPut)
+	r.HandleFunc("/categories/{id}/reorder-point", handler.DeleteCategoryReorderPoint).Methods(http.MethodDelete)
+	r.HandleFunc("/reservations/{id}", handler.GetReservation).Methods(http.MethodGet)
+	r.HandleFunc("/reservations/{id}/confirm", handler.ConfirmReservation).Methods(http.MethodPost)
+	r.HandleFunc("/reservations/{id}/release", handler.ReleaseReservation).Methods(http.MethodPost)
 	return r
 }
 
@@ -55,15 +83,17 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 	router := setupRouter(handler)
 
 	itemID := 1
-	existingItem := &item.Item{ID: itemID, Code: "XYZ", Stock: 10, Status: "ACTIVE"}
+	existingItem := &item.Item{ID: itemID, Code: "XYZ", Stock: 10, Status: "ACTIVE", Version: 2}
 	mockService.On("UpdateItem", mock.Anything, existingItem).Return(existingItem, nil)
 
 	reqBody, _ := json.Marshal(existingItem)
 	req := httptest.NewRequest(http.MethodPut, "/items/"+strconv.Itoa(itemID), bytes.NewReader(reqBody))
+	req.Header.Set("If-Match", `"2"`)
 	rec := httptest.NewRecorder()
 	router.ServeHTTP(rec, req)
 
 	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
 	var respItem item.Item
 	err := json.Unmarshal(rec.Body.Bytes(), &respItem)
 	assert.NoError(t, err)
@@ -72,6 +102,20 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestItemHandler_UpdateItem_CodeChanged(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	router := setupRouter(http2.NewItemHandler(mockService))
+	mockService.On("UpdateItem", mock.Anything, mock.Anything).Return(nil, item.ErrCodeImmutable)
+
+	req := httptest.NewRequest(http.MethodPut, "/items/1", strings.NewReader(`{"code":"NEW1","category_id":1}`))
+	req.Header.Set("If-Match", `"2"`)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), item.ErrCodeImmutable.Error())
+}
+
 func TestItemHandler_DeleteItem(t *testing.T) {
 	mockService := new(mocks.ItemService)
 	handler := http2.NewItemHandler(mockService)
@@ -79,9 +123,10 @@ func TestItemHandler_DeleteItem(t *testing.T) {
 
 	itemID := 1
 	deletedItem := &item.Item{ID: itemID, Code: "123"}
-	mockService.On("DeleteItem", mock.Anything, itemID).Return(deletedItem, nil)
+	mockService.On("DeleteItem", mock.Anything, itemID, 0).Return(deletedItem, nil)
 
 	req := httptest.NewRequest(http.MethodDelete, "/items/"+strconv.Itoa(itemID), nil)
+	req.Header.Set("If-Match", "*")
 	rec := httptest.NewRecorder()
 	router.ServeHTTP(rec, req)
 
@@ -121,22 +166,730 @@ func TestItemHandler_ListItems(t *testing.T) {
 	handler := http2.NewItemHandler(mockService)
 	router := setupRouter(handler)
 
-	// Create a list of pointers to items
-	items := []*item.Item{
-		{ID: 1, Code: "ABC", Stock: 10},
-		{ID: 2, Code: "XYZ", Stock: 15},
+	items := &item.Response{
+		TotalPages: 2,
+		HasMore:    true,
+		Data: []item.Item{
+			{ID: 1, Code: "ABC", Stock: 10},
+			{ID: 2, Code: "XYZ", Stock: 15},
+		},
 	}
-	mockService.On("ListItems", mock.Anything, "", 10, 1).Return(items, 2, nil)
+	mockService.On("ListItems", mock.Anything, item.ListQuery{Limit: 10, Page: 1}).Return(items, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/items?limit=10&page=1", nil)
 	rec := httptest.NewRecorder()
 	router.ServeHTTP(rec, req)
 
 	assert.Equal(t, http.StatusOK, rec.Code)
-	var respItems []*item.Item
+	var respItems item.Response
 	err := json.Unmarshal(rec.Body.Bytes(), &respItems)
 	assert.NoError(t, err)
-	assert.Equal(t, items, respItems)
+	assert.Equal(t, items, &respItems)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestItemHandler_ListItems_WithFilters(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	handler := http2.NewItemHandler(mockService)
+	router := setupRouter(handler)
+
+	categoryID := 3
+	minPrice, maxPrice := 10.0, 50.0
+	createdFrom := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	lowStock := true
+	expectedQuery := item.ListQuery{
+		CategoryID:  &categoryID,
+		MinPrice:    &minPrice,
+		MaxPrice:    &maxPrice,
+		CreatedFrom: &createdFrom,
+		CodePrefix:  "AB",
+		LowStock:    &lowStock,
+		Sort:        []item.SortField{{Field: "created_at", Desc: true}, {Field: "price"}},
+		Limit:       20,
+		Page:        2,
+	}
+	items := &item.Response{TotalPages: 1, Data: []item.Item{{ID: 1, Code: "AB1", CategoryID: 3, Price: 20}}}
+	mockService.On("ListItems", mock.Anything, expectedQuery).Return(items, nil)
+
+	url := "/items?limit=20&page=2&category_id=3&min_price=10&max_price=50" +
+		"&created_from=2026-01-01T00:00:00Z&code_prefix=AB&low_stock=true&sort=-created_at,price:asc"
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestItemHandler_ListItems_Cursor(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	handler := http2.NewItemHandler(mockService)
+	router := setupRouter(handler)
+
+	cursor := item.Cursor{UpdatedAt: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC), ID: 42}
+	next := item.Cursor{UpdatedAt: time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC), ID: 43}
+	items := &item.Response{
+		Data:       []item.Item{{ID: 43, Code: "ABC"}},
+		NextCursor: next.Encode(),
+		HasMore:    true,
+	}
+	mockService.On("ListItems", mock.Anything, item.ListQuery{Limit: 1, Cursor: &cursor}).Return(items, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/items?limit=1&cursor="+cursor.Encode(), nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp item.Response
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.True(t, resp.HasMore)
+	decoded, err := item.DecodeCursor(resp.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, next, decoded)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestItemHandler_ListItems_InvalidCursor(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	handler := http2.NewItemHandler(mockService)
+	router := setupRouter(handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/items?limit=10&cursor=not-a-cursor", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockService.AssertNotCalled(t, "ListItems", mock.Anything, mock.Anything)
+}
+
+func TestItemHandler_ListItems_InvalidSort(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	handler := http2.NewItemHandler(mockService)
+	router := setupRouter(handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/items?limit=10&page=1&sort=password", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "cannot sort by")
+	mockService.AssertNotCalled(t, "ListItems", mock.Anything, mock.Anything)
+}
+
+func TestItemHandler_SearchItems(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	handler := http2.NewItemHandler(mockService)
+	router := setupRouter(handler)
+
+	results := &item.SearchResponse{
+		TotalPages: 1,
+		Data: []item.SearchResult{
+			{Item: item.Item{ID: 1, Code: "SHIRT01", Title: "Blue shirt"}, Rank: 0.5, Snippet: "<mark>Blue</mark> shirt"},
+		},
+	}
+	mockService.On("SearchItems", mock.Anything, item.SearchQuery{Text: "blue", Limit: 10, Page: 1}).Return(results, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/items/search?q=blue", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp item.SearchResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, results, &resp)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestItemHandler_SearchItems_MissingText(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	handler := http2.NewItemHandler(mockService)
+	router := setupRouter(handler)
+
+	mockService.On("SearchItems", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("%w: search text is required", item.ErrInvalidQuery))
+
+	req := httptest.NewRequest(http.MethodGet, "/items/search", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestItemHandler_BulkCreateItems_PartialFailure(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	handler := http2.NewItemHandler(mockService)
+	router := setupRouter(handler)
+
+	items := []*item.Item{{Code: "A1", Price: 10}, {Code: "A2", Price: 20}}
+	report := &item.BulkReport{
+		Mode:      item.BulkBestEffort,
+		Succeeded: 1,
+		Failed:    1,
+		Results: []item.BulkResult{
+			{Index: 0, ID: 1, Code: "A1", Status: item.BulkCreated},
+			{Index: 1, Code: "A2", Status: item.BulkFailed, Error: "invalid Category"},
+		},
+	}
+	mockService.On("BulkCreateItems", mock.Anything, items, item.BulkBestEffort).Return(report, nil)
+
+	reqBody, _ := json.Marshal(items)
+	req := httptest.NewRequest(http.MethodPost, "/items/bulk?mode=best_effort", bytes.NewReader(reqBody))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusMultiStatus, rec.Code)
+	var resp item.BulkReport
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, report, &resp)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestItemHandler_BulkDeleteItems(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	handler := http2.NewItemHandler(mockService)
+	router := setupRouter(handler)
+
+	report := &item.BulkReport{
+		Mode:      item.BulkAtomic,
+		Succeeded: 2,
+		Results: []item.BulkResult{
+			{Index: 0, ID: 1, Status: item.BulkDeleted},
+			{Index: 1, ID: 2, Status: item.BulkDeleted},
+		},
+	}
+	entries := []item.BulkDeleteEntry{{ID: 1, Version: 3}, {ID: 2, Version: 1}}
+	mockService.On("BulkDeleteItems", mock.Anything, entries, item.BulkAtomic).Return(report, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/items/bulk", strings.NewReader(`[{"id": 1, "version": 3}, {"id": 2, "version": 1}]`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestItemHandler_BulkUpdateItems_InvalidMode(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	handler := http2.NewItemHandler(mockService)
+	router := setupRouter(handler)
+
+	req := httptest.NewRequest(http.MethodPut, "/items/bulk?mode=sometimes", bytes.NewReader([]byte(`[{"id": 1, "code": "A1"}]`)))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockService.AssertNotCalled(t, "BulkUpdateItems", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestItemHandler_PatchItem(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	router := setupRouter(http2.NewItemHandler(mockService))
+
+	patch := `{"price": 0, "description": null}`
+	patched := &item.Item{ID: 1, Code: "A1", Title: "Blue shirt"}
+	mockService.On("PatchItem", mock.Anything, 1, 4, item.MergePatch, []byte(patch)).Return(patched, nil)
+
+	req := httptest.NewRequest(http.MethodPatch, "/items/1", strings.NewReader(patch))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.Header.Set("If-Match", `"4"`)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestItemHandler_PatchItem_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		err         error
+		status      int
+	}{
+		{name: "unsupported content type", contentType: "text/plain", status: http.StatusUnsupportedMediaType},
+		{name: "invalid patch", contentType: "application/json-patch+json", err: item.ErrInvalidPatch, status: http.StatusBadRequest},
+		{name: "not found", contentType: "application/json", err: item.ErrItemNotFound, status: http.StatusNotFound},
+		{name: "version conflict", contentType: "application/json", err: item.ErrVersionConflict, status: http.StatusPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ItemService)
+			router := setupRouter(http2.NewItemHandler(mockService))
+			mockService.On("PatchItem", mock.Anything, 1, 1, mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			req := httptest.NewRequest(http.MethodPatch, "/items/1", strings.NewReader(`[]`))
+			req.Header.Set("Content-Type", tt.contentType)
+			req.Header.Set("If-Match", `"1"`)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
+
+func TestItemHandler_GetItemByID_ConditionalGet(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	router := setupRouter(http2.NewItemHandler(mockService))
+	mockService.On("GetItemByID", mock.Anything, 1).Return(&item.Item{ID: 1, Code: "456", Version: 3}, nil)
+
+	tests := []struct {
+		ifNoneMatch string
+		status      int
+	}{
+		{ifNoneMatch: "", status: http.StatusOK},
+		{ifNoneMatch: `"3"`, status: http.StatusNotModified},
+		{ifNoneMatch: `"1", W/"3"`, status: http.StatusNotModified},
+		{ifNoneMatch: `"2"`, status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ifNoneMatch, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+			if tt.status == http.StatusNotModified {
+				assert.Empty(t, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestItemHandler_IfMatchPreconditions(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		ifMatch string
+		status  int
+	}{
+		{name: "update without If-Match", method: http.MethodPut, status: http.StatusPreconditionRequired},
+		{name: "delete without If-Match", method: http.MethodDelete, status: http.StatusPreconditionRequired},
+		{name: "patch without If-Match", method: http.MethodPatch, status: http.StatusPreconditionRequired},
+		{name: "weak tag never matches", method: http.MethodDelete, ifMatch: `W/"2"`, status: http.StatusBadRequest},
+		{name: "foreign tag never matches", method: http.MethodPut, ifMatch: `"abc"`, status: http.StatusPreconditionFailed},
+		{name: "several tags", method: http.MethodDelete, ifMatch: `"1", "2"`, status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ItemService)
+			router := setupRouter(http2.NewItemHandler(mockService))
+
+			req := httptest.NewRequest(tt.method, "/items/1", strings.NewReader(`{"code":"A1"}`))
+			req.Header.Set("Content-Type", "application/merge-patch+json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Empty(t, mockService.Calls)
+		})
+	}
+}
+
+func TestItemHandler_DeleteItem_VersionConflict(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	router := setupRouter(http2.NewItemHandler(mockService))
+	mockService.On("DeleteItem", mock.Anything, 1, 2).Return(nil, item.ErrVersionConflict)
+
+	req := httptest.NewRequest(http.MethodDelete, "/items/1", nil)
+	req.Header.Set("If-Match", `"2"`)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+}
+
+func TestItemHandler_ListItems_IncludeDeleted(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	router := setupRouter(http2.NewItemHandler(mockService))
+
+	query := item.ListQuery{Limit: 10, Page: 1, IncludeDeleted: true}
+	mockService.On("ListItems", mock.Anything, query).Return(nil, item.ErrForbidden)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items?limit=10&page=1&include_deleted=true", nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items?limit=10&page=1&include_deleted=maybe", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockService.AssertNumberOfCalls(t, "ListItems", 1)
+}
+
+func TestItemHandler_RestoreItem(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch string
+		version int
+		err     error
+		status  int
+	}{
+		{name: "without If-Match", version: 0, status: http.StatusOK},
+		{name: "with If-Match", ifMatch: `"4"`, version: 4, status: http.StatusOK},
+		{name: "not deleted", version: 0, err: item.ErrItemNotDeleted, status: http.StatusConflict},
+		{name: "not found", version: 0, err: item.ErrItemNotFound, status: http.StatusNotFound},
+		{name: "version conflict", ifMatch: `"3"`, version: 3, err: item.ErrVersionConflict, status: http.StatusPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ItemService)
+			router := setupRouter(http2.NewItemHandler(mockService))
 
+			var restored *item.Item
+			if tt.err == nil {
+				restored = &item.Item{ID: 1, Code: "A1", Version: 5}
+			}
+			mockService.On("RestoreItem", mock.Anything, 1, tt.version).Return(restored, tt.err)
+
+			req := httptest.NewRequest(http.MethodPost, "/items/1/restore", nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			if tt.err == nil {
+				assert.Equal(t, `"5"`, rec.Header().Get("ETag"))
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_ItemHistory(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	router := setupRouter(http2.NewItemHandler(mockService))
+
+	query := item.HistoryQuery{ItemID: 1, Limit: 5, Page: 2}
+	history := &item.HistoryResponse{TotalPages: 2, Data: []item.AuditEntry{{ID: 9, ItemID: 1, Action: item.AuditUpdated, ActorID: 7}}}
+	mockService.On("ItemHistory", mock.Anything, query).Return(history, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/items/1/history?limit=5&page=2", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var got item.HistoryResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	assert.Equal(t, *history, got)
 	mockService.AssertExpectations(t)
 }
+
+func TestItemHandler_ItemHistory_DefaultsAndInvalidQuery(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	router := setupRouter(http2.NewItemHandler(mockService))
+
+	mockService.On("ItemHistory", mock.Anything, item.HistoryQuery{ItemID: 1, Limit: 20, Page: 1}).Return(&item.HistoryResponse{Data: []item.AuditEntry{}}, nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/items/1/history", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/items/1/history?limit=abc", nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	mockService.On("ItemHistory", mock.Anything, item.HistoryQuery{ItemID: 1, Limit: 0, Page: 1}).Return(nil, item.ErrInvalidQuery)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/items/1/history?limit=0", nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestItemHandler_GetItemByID_AsOf(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	router := setupRouter(http2.NewItemHandler(mockService))
+
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	snapshot := &item.Item{ID: 1, Code: "A1", Title: "Old", Version: 2}
+	mockService.On("GetItemAsOf", mock.Anything, 1, at).Return(snapshot, nil)
+	mockService.On("GetItemAsOf", mock.Anything, 2, at).Return(nil, item.ErrItemNotFound)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/items/1?as_of=2026-01-01T00:00:00Z", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Empty(t, rr.Header().Get("ETag"))
+	var got item.Item
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	assert.Equal(t, "Old", got.Title)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/items/2?as_of=2026-01-01T00:00:00Z", nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/items/1?as_of=yesterday", nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockService.AssertNotCalled(t, "GetItemByID", mock.Anything, mock.Anything)
+}
+
+func TestItemHandler_RevertItem(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	router := setupRouter(http2.NewItemHandler(mockService))
+
+	mockService.On("RevertItem", mock.Anything, 1, 2, 4).Return(&item.Item{ID: 1, Code: "A1", Version: 5}, nil)
+	mockService.On("RevertItem", mock.Anything, 1, 9, 0).Return(nil, item.ErrRevisionNotFound)
+	mockService.On("RevertItem", mock.Anything, 1, 3, 0).Return(nil, item.ErrInvalidItem)
+
+	req := httptest.NewRequest(http.MethodPost, "/items/1/revisions/2/revert", nil)
+	req.Header.Set("If-Match", `"4"`)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `"5"`, rr.Header().Get("ETag"))
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/items/1/revisions/9/revert", nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/items/1/revisions/3/revert", nil))
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+func TestItemHandler_CreateStockMovement(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	router := setupRouter(http2.NewItemHandler(mockService))
+
+	recorded := &item.StockMovement{ID: 3, ItemID: 1, Type: item.MovementReceipt, Quantity: 10, Delta: 10, Reason: "po 1", StockAfter: 15}
+	mockService.On("RecordStockMovement", mock.Anything, 1, &item.StockMovement{
+		Type: item.MovementReceipt, Quantity: 10, Reason: "po 1",
+	}).Return(recorded, nil)
+
+	body := `{"type": "receipt", "quantity": 10, "reason": "po 1"}`
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/items/1/stock-movements", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var got item.StockMovement
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	assert.Equal(t, *recorded, got)
+}
+
+func TestItemHandler_CreateStockMovement_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		err    error
+		status int
+	}{
+		{name: "server field", body: `{"type": "receipt", "quantity": 1, "reason": "x", "stock_after": 9}`, status: http.StatusBadRequest},
+		{name: "invalid movement", body: `{"type": "theft", "quantity": 1, "reason": "x"}`, err: item.ErrInvalidMovement, status: http.StatusBadRequest},
+		{name: "not found", body: `{"type": "sale", "quantity": 1, "reason": "x"}`, err: item.ErrItemNotFound, status: http.StatusNotFound},
+		{name: "insufficient stock", body: `{"type": "sale", "quantity": 1, "reason": "x"}`, err: item.ErrInsufficientStock, status: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ItemService)
+			router := setupRouter(http2.NewItemHandler(mockService))
+			mockService.On("RecordStockMovement", mock.Anything, 1, mock.Anything).Return(nil, tt.err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/items/1/stock-movements", strings.NewReader(tt.body)))
+			assert.Equal(t, tt.status, rr.Code)
+		})
+	}
+}
+
+func TestItemHandler_ListStockMovements(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	router := setupRouter(http2.NewItemHandler(mockService))
+
+	query := item.MovementQuery{ItemID: 1, Type: item.MovementSale, Limit: 20, Page: 1}
+	mockService.On("ListStockMovements", mock.Anything, query).Return(&item.MovementResponse{TotalPages: 1, Data: []item.StockMovement{{ID: 1}}}, nil)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/items/1/stock-movements?type=sale", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestItemHandler_TransferStock(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	router := setupRouter(http2.NewItemHandler(mockService))
+
+	request := &item.StockTransfer{FromWarehouseID: 1, ToWarehouseID: 2, Quantity: 5, Reason: "rebalance"}
+	transferred := &item.StockTransfer{ID: "t-1", ItemID: 1, FromWarehouseID: 1, ToWarehouseID: 2, Quantity: 5, Reason: "rebalance"}
+	mockService.On("TransferStock", mock.Anything, 1, request).Return(transferred, nil)
+	mockService.On("TransferStock", mock.Anything, 2, mock.Anything).Return(nil, warehouse.ErrWarehouseNotFound)
+
+	body := `{"from_warehouse_id": 1, "to_warehouse_id": 2, "quantity": 5, "reason": "rebalance"}`
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/items/1/stock-transfers", strings.NewReader(body)))
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var got item.StockTransfer
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	assert.Equal(t, *transferred, got)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/items/2/stock-transfers", strings.NewReader(body)))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestItemHandler_ListStockLevels(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	router := setupRouter(http2.NewItemHandler(mockService))
+
+	mockService.On("ListStockLevels", mock.Anything, 1).Return([]item.StockLevel{{ItemID: 1, WarehouseID: 1, Stock: 4}}, nil)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/items/1/stock-levels", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var got []item.StockLevel
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	assert.Equal(t, 4, got[0].Stock)
+}
+
+func TestItemHandler_CreateReservation(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	router := setupRouter(http2.NewItemHandler(mockService))
+
+	reservation := &item.Reservation{ID: "r-1", ItemID: 1, Quantity: 2, Status: item.ReservationHeld}
+	mockService.On("ReserveStock", mock.Anything, 1, 2, 90*time.Second, 0).Return(reservation, nil)
+	mockService.On("ReserveStock", mock.Anything, 1, 3, item.DefaultReservationTTL, 0).Return(nil, item.ErrInsufficientStock)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/items/1/reservations", strings.NewReader(`{"quantity": 2, "ttl_seconds": 90}`)))
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, "/api/reservations/r-1", rr.Header().Get("Location"))
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/items/1/reservations", strings.NewReader(`{"quantity": 3}`)))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestItemHandler_ResolveReservation(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	router := setupRouter(http2.NewItemHandler(mockService))
+
+	mockService.On("ConfirmReservation", mock.Anything, "r-1").Return(&item.Reservation{ID: "r-1", Status: item.ReservationConfirmed}, nil)
+	mockService.On("ReleaseReservation", mock.Anything, "r-1").Return(nil, item.ErrReservationClosed)
+	mockService.On("GetReservation", mock.Anything, "r-2").Return(nil, item.ErrReservationNotFound)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/reservations/r-1/confirm", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var got item.Reservation
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	assert.Equal(t, item.ReservationConfirmed, got.Status)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/reservations/r-1/release", nil))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/reservations/r-2", nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestItemHandler_CategoryReorderPoint(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	router := setupRouter(http2.NewItemHandler(mockService))
+
+	mockService.On("SetCategoryReorderPoint", mock.Anything, &item.CategoryReorderPoint{CategoryID: 3, ReorderPoint: 10}).
+		Return(&item.CategoryReorderPoint{CategoryID: 3, ReorderPoint: 10}, nil)
+	mockService.On("SetCategoryReorderPoint", mock.Anything, &item.CategoryReorderPoint{CategoryID: 3, ReorderPoint: -1}).
+		Return(nil, item.ErrInvalidReorderPoint)
+	mockService.On("GetCategoryReorderPoint", mock.Anything, 4).Return(nil, item.ErrReorderPointNotFound)
+	mockService.On("DeleteCategoryReorderPoint", mock.Anything, 3).Return(nil)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/categories/3/reorder-point", strings.NewReader(`{"reorder_point": 10}`)))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var got item.CategoryReorderPoint
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	assert.Equal(t, 10, got.ReorderPoint)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/categories/3/reorder-point", strings.NewReader(`{"reorder_point": -1}`)))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/categories/4/reorder-point", nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/categories/3/reorder-point", nil))
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+}
+
+func TestItemHandler_TransitionItem(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	router := setupRouter(http2.NewItemHandler(mockService))
+
+	mockService.On("TransitionItem", mock.Anything, 1, item.StatusDiscontinued, 4).
+		Return(&item.Item{ID: 1, Status: item.StatusDiscontinued, Version: 5}, nil)
+	mockService.On("TransitionItem", mock.Anything, 1, item.StatusDraft, 0).
+		Return(nil, fmt.Errorf("%w: cannot go from ACTIVE to DRAFT", item.ErrInvalidTransition))
+
+	req := httptest.NewRequest(http.MethodPost, "/items/1/transitions", strings.NewReader(`{"status": "discontinued"}`))
+	req.Header.Set("If-Match", `"4"`)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `"5"`, rr.Header().Get("ETag"))
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/items/1/transitions", strings.NewReader(`{"status": "DRAFT"}`)))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Contains(t, rr.Body.String(), "cannot go from ACTIVE to DRAFT")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/items/1/transitions", strings.NewReader(`{"status": "SOLD"}`)))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestItemHandler_ItemGrants(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	router := setupRouter(http2.NewItemHandler(mockService))
+
+	mockService.On("GrantItem", mock.Anything, 1, 6).Return(&item.ItemGrant{ItemID: 1, UserID: 6, GrantedBy: 5}, nil)
+	mockService.On("ListItemGrants", mock.Anything, 2).Return(nil, item.ErrForbidden)
+	mockService.On("RevokeItemGrant", mock.Anything, 1, 9).Return(item.ErrGrantNotFound)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/items/1/grants", strings.NewReader(`{"user_id": 6}`)))
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var grant item.ItemGrant
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &grant))
+	assert.Equal(t, 6, grant.UserID)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/items/1/grants", strings.NewReader(`{}`)))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/items/2/grants", nil))
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/items/1/grants/9", nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestItemHandler_DeleteItem_Forbidden(t *testing.T) {
+	mockService := new(mocks.ItemService)
+	router := setupRouter(http2.NewItemHandler(mockService))
+
+	mockService.On("DeleteItem", mock.Anything, 1, 0).Return(nil, item.ErrForbidden)
+
+	req := httptest.NewRequest(http.MethodDelete, "/items/1", nil)
+	req.Header.Set("If-Match", "*")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
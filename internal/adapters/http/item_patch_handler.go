@@ -0,0 +1,91 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+const maxPatchSize = 1 << 20
+
+// PatchItem atualiza parcialmente um item
+// @Summary Atualiza parcialmente um item
+// @Description Aplica um JSON Merge Patch (RFC 7396) ou um JSON Patch (RFC 6902) ao item. No merge patch, campos ausentes são mantidos, null limpa o campo e zero é gravado como zero. application/json é tratado como merge patch.
+// @Tags items
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Param id path int true "ID do item"
+// @Param If-Match header string true "ETag da versão do item, ou *"
+// @Param patch body object true "Patch a aplicar"
+// @Success 200 {object} item.Item
+// @Failure 400 {string} string "Patch inválido"
+// @Failure 403 {string} string "O item pertence a outro usuário"
+// @Failure 404 {string} string "Item não encontrado"
+// @Failure 409 {string} string "O item está arquivado"
+// @Failure 412 {string} string "O item foi alterado por outra requisição"
+// @Failure 415 {string} string "Tipo de patch não suportado"
+// @Failure 428 {string} string "If-Match ausente"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /items/{id} [patch]
+func (h *ItemHandler) PatchItem(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeIfMatchError(w, err)
+		return
+	}
+
+	patchType, ok := parsePatchType(r.Header.Get("Content-Type"))
+	if !ok {
+		w.Header().Set("Accept-Patch", string(item.MergePatch)+", "+string(item.JSONPatch))
+		http.Error(w, "Unsupported Content-Type", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	patch, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPatchSize))
+	if err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	updatedItem, err := h.itemService.PatchItem(r.Context(), id, version, patchType, patch)
+	if err != nil {
+		if errors.Is(err, item.ErrInvalidPatch) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeItemWriteError(w, err)
+		return
+	}
+	setItemETag(w, updatedItem)
+	if err := json.NewEncoder(w).Encode(updatedItem); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func parsePatchType(contentType string) (item.PatchType, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+	switch mediaType {
+	case string(item.MergePatch), "application/json":
+		return item.MergePatch, true
+	case string(item.JSONPatch):
+		return item.JSONPatch, true
+	}
+	return "", false
+}
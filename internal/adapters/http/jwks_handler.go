@@ -0,0 +1,38 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/teamcubation/go-items-challenge/internal/adapters/jwtauth"
+)
+
+// PublicKeys are the keys that verify the access tokens.
+type PublicKeys interface {
+	JWKS() jwtauth.JWKS
+}
+
+type JWKSHandler struct {
+	keys PublicKeys
+}
+
+func NewJWKSHandler(keys PublicKeys) *JWKSHandler {
+	return &JWKSHandler{keys: keys}
+}
+
+// JWKS Publica as chaves públicas dos tokens
+// @Summary Publica as chaves públicas dos tokens
+// @Description Devolve, no formato JWKS (RFC 7517), as chaves públicas que verificam os tokens de acesso, identificadas pelo kid do cabeçalho dos tokens. Novas chaves são publicadas antes de assinar tokens. Vazio quando os tokens são assinados com HS256.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} jwtauth.JWKS "Chaves públicas"
+// @Router /.well-known/jwks.json [get]
+func (h *JWKSHandler) JWKS(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(jwtauth.JWKSMaxAge.Seconds())))
+	if err := json.NewEncoder(w).Encode(h.keys.JWKS()); err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,141 @@
+package http
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+// parseListQuery builds an item.ListQuery from the query string of a listing
+// request. Dates are expected in RFC 3339 format. The presence of the cursor
+// parameter, even empty, switches the listing to keyset pagination.
+func parseListQuery(values url.Values) (item.ListQuery, error) {
+	query, err := parseListFilters(values)
+	if err != nil {
+		return query, err
+	}
+
+	if values.Has("cursor") {
+		cursor, err := item.DecodeCursor(values.Get("cursor"))
+		if err != nil {
+			return query, err
+		}
+		query.Cursor = &cursor
+	} else if query.Page, err = strconv.Atoi(values.Get("page")); err != nil {
+		return query, fmt.Errorf("invalid page")
+	}
+	if query.Limit, err = strconv.Atoi(values.Get("limit")); err != nil {
+		return query, fmt.Errorf("invalid limit")
+	}
+	if raw := values.Get("include_deleted"); raw != "" {
+		if query.IncludeDeleted, err = strconv.ParseBool(raw); err != nil {
+			return query, fmt.Errorf("invalid include_deleted")
+		}
+	}
+	return query, nil
+}
+
+// parseListFilters parses the filters and sorting shared by listings and
+// exports, leaving pagination unset.
+func parseListFilters(values url.Values) (item.ListQuery, error) {
+	query := item.ListQuery{
+		Status:     values.Get("status"),
+		CodePrefix: values.Get("code_prefix"),
+	}
+
+	var err error
+	if query.CategoryID, err = parseIntParam(values, "category_id"); err != nil {
+		return query, err
+	}
+	if query.MinPrice, err = parseFloatParam(values, "min_price"); err != nil {
+		return query, err
+	}
+	if query.MaxPrice, err = parseFloatParam(values, "max_price"); err != nil {
+		return query, err
+	}
+	if query.MinStock, err = parseIntParam(values, "min_stock"); err != nil {
+		return query, err
+	}
+	if query.MaxStock, err = parseIntParam(values, "max_stock"); err != nil {
+		return query, err
+	}
+	if query.CreatedBy, err = parseIntParam(values, "created_by"); err != nil {
+		return query, err
+	}
+	if query.CreatedFrom, err = parseTimeParam(values, "created_from"); err != nil {
+		return query, err
+	}
+	if query.CreatedTo, err = parseTimeParam(values, "created_to"); err != nil {
+		return query, err
+	}
+	if query.UpdatedFrom, err = parseTimeParam(values, "updated_from"); err != nil {
+		return query, err
+	}
+	if query.UpdatedTo, err = parseTimeParam(values, "updated_to"); err != nil {
+		return query, err
+	}
+	if query.LowStock, err = parseBoolParam(values, "low_stock"); err != nil {
+		return query, err
+	}
+	if mine, err := parseBoolParam(values, "mine"); err != nil {
+		return query, err
+	} else if mine != nil {
+		query.Mine = *mine
+	}
+	if query.Sort, err = item.ParseSort(values.Get("sort")); err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+func parseIntParam(values url.Values, name string) (*int, error) {
+	raw := values.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s", name)
+	}
+	return &v, nil
+}
+
+func parseFloatParam(values url.Values, name string) (*float64, error) {
+	raw := values.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s", name)
+	}
+	return &v, nil
+}
+
+func parseTimeParam(values url.Values, name string) (*time.Time, error) {
+	raw := values.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s", name)
+	}
+	return &v, nil
+}
+
+func parseBoolParam(values url.Values, name string) (*bool, error) {
+	raw := values.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s", name)
+	}
+	return &v, nil
+}
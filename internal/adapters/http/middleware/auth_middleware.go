@@ -2,27 +2,66 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
-	"github.com/golang-jwt/jwt"
+	"github.com/teamcubation/go-items-challenge/internal/adapters/jwtauth"
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
 )
 
-type contextKey string
+// TokenDenylist holds the access tokens revoked before they expire.
+type TokenDenylist interface {
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+// APIKeyVerifier resolves the API keys of machine clients. It returns
+// user.ErrInvalidAPIKey for unknown, expired or revoked keys.
+type APIKeyVerifier interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (*user.Principal, error)
+}
+
+// TokenVerifier checks the signature and the claims of the access tokens.
+type TokenVerifier interface {
+	VerifyAccessToken(tokenString string) (*jwtauth.Claims, error)
+}
 
-const UserContextKey contextKey = "userID"
+// Authenticator checks the access token or the API key of the requests.
+type Authenticator struct {
+	verifier TokenVerifier
+	denylist TokenDenylist
+	apiKeys  APIKeyVerifier
+}
+
+type AuthenticatorOption func(*Authenticator)
 
-var JwtKey = []byte("your_secret_key")
+// WithAPIKeys accepts API keys, in the X-API-Key header or as
+// "Authorization: ApiKey <key>", besides access tokens.
+func WithAPIKeys(keys APIKeyVerifier) AuthenticatorOption {
+	return func(a *Authenticator) {
+		a.apiKeys = keys
+	}
+}
 
-type Claims struct {
-	UserID int `json:"user_id"`
-	jwt.StandardClaims
+// NewAuthenticator rejects, besides the tokens verifier rejects, the tokens
+// whose jti is in denylist. denylist may be nil.
+func NewAuthenticator(verifier TokenVerifier, denylist TokenDenylist, opts ...AuthenticatorOption) *Authenticator {
+	a := &Authenticator{verifier: verifier, denylist: denylist}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
 }
 
-func AuthMiddleware(next http.Handler) http.Handler {
+func (a *Authenticator) AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// adding authentication logic here
 
+		if key, ok := apiKeyFromRequest(r); ok {
+			a.authenticateAPIKey(w, r, key, next)
+			return
+		}
+
 		tokenString := r.Header.Get("Authorization")
 		if tokenString == "" {
 			http.Error(w, "Missing token", http.StatusUnauthorized)
@@ -31,19 +70,59 @@ func AuthMiddleware(next http.Handler) http.Handler {
 
 		tokenString = strings.TrimPrefix(tokenString, "Bearer ")
 
-		claims := &Claims{}
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(_ *jwt.Token) (interface{}, error) {
-			return JwtKey, nil
-		})
-		if err != nil || !token.Valid {
+		claims, err := a.verifier.VerifyAccessToken(tokenString)
+		if err != nil || claims.UserID == 0 {
 			http.Error(w, "Invalid token", http.StatusUnauthorized)
 			return
 		}
-		if claims.UserID == 0 {
-			http.Error(w, "Invalid token", http.StatusUnauthorized)
-			return
+		// tokens issued before logout existed have no jti and expire on their own
+		if a.denylist != nil && claims.Id != "" {
+			revoked, err := a.denylist.IsTokenRevoked(r.Context(), claims.Id)
+			if err != nil {
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+			if revoked {
+				http.Error(w, "Token revoked", http.StatusUnauthorized)
+				return
+			}
 		}
-		ctx := context.WithValue(r.Context(), UserContextKey, claims.UserID)
-		next.ServeHTTP(w, r.WithContext(ctx))
+		role := claims.Role
+		if role == "" {
+			role = user.RoleViewer
+		}
+		next.ServeHTTP(w, r.WithContext(user.WithPrincipal(r.Context(), &user.Principal{UserID: claims.UserID, Role: role})))
 	})
 }
+
+func apiKeyFromRequest(r *http.Request) (string, bool) {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key, true
+	}
+	scheme, key, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if found && strings.EqualFold(scheme, "ApiKey") {
+		return strings.TrimSpace(key), true
+	}
+	return "", false
+}
+
+func (a *Authenticator) authenticateAPIKey(w http.ResponseWriter, r *http.Request, key string, next http.Handler) {
+	if a.apiKeys == nil || key == "" {
+		http.Error(w, "Invalid API key", http.StatusUnauthorized)
+		return
+	}
+	principal, err := a.apiKeys.AuthenticateAPIKey(r.Context(), key)
+	if err != nil {
+		if errors.Is(err, user.ErrInvalidAPIKey) {
+			http.Error(w, "Invalid API key", http.StatusUnauthorized)
+			return
+		}
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	// a key limited to no permission at all is still limited
+	if principal.Scopes == nil {
+		principal.Scopes = []user.Permission{}
+	}
+	next.ServeHTTP(w, r.WithContext(user.WithPrincipal(r.Context(), principal)))
+}
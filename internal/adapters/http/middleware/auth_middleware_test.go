@@ -1,29 +1,38 @@
 package middleware_test
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/teamcubation/go-items-challenge/internal/adapters/http/middleware"
+	"github.com/teamcubation/go-items-challenge/internal/adapters/jwtauth"
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
 )
 
-func createToken(userID int, secret []byte) (string, error) {
-	claims := &middleware.Claims{
-		UserID:         userID,
-		StandardClaims: jwt.StandardClaims{
-			// Additional claims can be added here as needed
-		},
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func newVerifier(t *testing.T) *jwtauth.Manager {
+	manager, err := jwtauth.New(jwtauth.Config{Algorithm: jwtauth.AlgorithmHS256, Secret: testSecret}, nil)
+	require.NoError(t, err)
+	return manager
+}
+
+func createToken(claims *jwtauth.Claims, secret []byte) (string, error) {
+	if claims.ExpiresAt == 0 {
+		claims.ExpiresAt = time.Now().Add(time.Hour).Unix()
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(secret)
 }
 
 func TestAuthMiddleware_ValidToken(t *testing.T) {
-	token, err := createToken(123, middleware.JwtKey)
+	token, _, err := newVerifier(t).SignAccessToken(123, user.RoleEditor, "jti")
 	require.NoError(t, err)
 
 	req := httptest.NewRequest("GET", "/", nil)
@@ -31,10 +40,8 @@ func TestAuthMiddleware_ValidToken(t *testing.T) {
 
 	rec := httptest.NewRecorder()
 
-	handler := middleware.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		userID, ok := r.Context().Value(middleware.UserContextKey).(int)
-		assert.True(t, ok)
-		assert.Equal(t, 123, userID)
+	handler := middleware.NewAuthenticator(newVerifier(t), nil).AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, 123, user.UserIDFromContext(r.Context()))
 		w.WriteHeader(http.StatusOK)
 	}))
 
@@ -47,7 +54,7 @@ func TestAuthMiddleware_MissingToken(t *testing.T) {
 	req := httptest.NewRequest("GET", "/", nil)
 	rec := httptest.NewRecorder()
 
-	handler := middleware.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	handler := middleware.NewAuthenticator(newVerifier(t), nil).AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
 
@@ -58,11 +65,39 @@ func TestAuthMiddleware_MissingToken(t *testing.T) {
 }
 
 func TestAuthMiddleware_InvalidToken(t *testing.T) {
+	// signed with another secret
+	otherSecret, err := createToken(&jwtauth.Claims{UserID: 1}, []byte("another_secret_of_thirty_two_bytes"))
+	require.NoError(t, err)
+	// expired
+	expired, err := createToken(&jwtauth.Claims{UserID: 1, StandardClaims: jwt.StandardClaims{ExpiresAt: time.Now().Add(-time.Hour).Unix()}}, testSecret)
+	require.NoError(t, err)
+
+	for _, tokenString := range []string{"invalid.token.here", otherSecret, expired} {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		rec := httptest.NewRecorder()
+
+		handler := middleware.NewAuthenticator(newVerifier(t), nil).AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Invalid token")
+	}
+}
+
+func TestAuthMiddleware_InvalidUserID(t *testing.T) {
+	// Create a token with an invalid (zero) user ID
+	tokenString, err := createToken(&jwtauth.Claims{UserID: 0}, testSecret)
+	require.NoError(t, err)
+
 	req := httptest.NewRequest("GET", "/", nil)
-	req.Header.Set("Authorization", "Bearer invalid.token.here")
+	req.Header.Set("Authorization", "Bearer "+tokenString)
 	rec := httptest.NewRecorder()
 
-	handler := middleware.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	handler := middleware.NewAuthenticator(newVerifier(t), nil).AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
 
@@ -72,25 +107,96 @@ func TestAuthMiddleware_InvalidToken(t *testing.T) {
 	assert.Contains(t, rec.Body.String(), "Invalid token")
 }
 
-func TestAuthMiddleware_InvalidUserID(t *testing.T) {
-	// Create a token with an invalid (zero) user ID
-	claims := &middleware.Claims{
-		UserID: 0,
-	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(middleware.JwtKey)
+func TestAuthMiddleware_RoleClaim(t *testing.T) {
+	tokenString, err := createToken(&jwtauth.Claims{UserID: 1, Role: user.RoleAdmin}, testSecret)
 	require.NoError(t, err)
 
-	req := httptest.NewRequest("GET", "/", nil)
-	req.Header.Set("Authorization", "Bearer "+tokenString)
-	rec := httptest.NewRecorder()
+	// tokens without a role are treated as viewers
+	userToken, err := createToken(&jwtauth.Claims{UserID: 2}, testSecret)
+	require.NoError(t, err)
 
-	handler := middleware.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	for tokenString, role := range map[string]user.Role{tokenString: user.RoleAdmin, userToken: user.RoleViewer} {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		rec := httptest.NewRecorder()
+
+		handler := middleware.NewAuthenticator(newVerifier(t), nil).AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, role, user.RoleFromContext(r.Context()))
+			assert.Equal(t, role == user.RoleAdmin, user.IsAdmin(r.Context()))
+			w.WriteHeader(http.StatusOK)
+		}))
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
+type denylist map[string]bool
+
+func (d denylist) IsTokenRevoked(_ context.Context, jti string) (bool, error) {
+	return d[jti], nil
+}
+
+func TestAuthenticator_RevokedToken(t *testing.T) {
+	auth := middleware.NewAuthenticator(newVerifier(t), denylist{"revoked": true})
+
+	for jti, status := range map[string]int{"revoked": http.StatusUnauthorized, "live": http.StatusOK, "": http.StatusOK} {
+		tokenString, err := createToken(&jwtauth.Claims{UserID: 1, StandardClaims: jwt.StandardClaims{Id: jti}}, testSecret)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		rec := httptest.NewRecorder()
+
+		handler := auth.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, status, rec.Code, jti)
+	}
+}
+
+type apiKeyVerifier map[string]*user.Principal
+
+func (v apiKeyVerifier) AuthenticateAPIKey(_ context.Context, key string) (*user.Principal, error) {
+	if principal, ok := v[key]; ok {
+		return principal, nil
+	}
+	return nil, user.ErrInvalidAPIKey
+}
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	scopes := []user.Permission{user.PermItemsRead}
+	auth := middleware.NewAuthenticator(newVerifier(t), nil, middleware.WithAPIKeys(apiKeyVerifier{
+		"gic_valid": {UserID: 7, Role: user.RoleEditor, Scopes: scopes},
+	}))
+	handler := auth.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, 7, user.UserIDFromContext(r.Context()))
+		assert.Equal(t, user.RoleEditor, user.RoleFromContext(r.Context()))
+		assert.Equal(t, scopes, user.ScopesFromContext(r.Context()))
 		w.WriteHeader(http.StatusOK)
 	}))
 
+	for header, value := range map[string]string{"X-API-Key": "gic_valid", "Authorization": "ApiKey gic_valid"} {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set(header, value)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code, header)
+	}
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-API-Key", "gic_revoked")
+	rec := httptest.NewRecorder()
 	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Invalid API key")
 
+	// without a verifier API keys are rejected
+	req = httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-API-Key", "gic_valid")
+	rec = httptest.NewRecorder()
+	middleware.NewAuthenticator(newVerifier(t), nil).AuthMiddleware(handler).ServeHTTP(rec, req)
 	assert.Equal(t, http.StatusUnauthorized, rec.Code)
-	assert.Contains(t, rec.Body.String(), "Invalid token")
 }
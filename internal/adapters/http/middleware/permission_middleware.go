@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+)
+
+// ForbiddenError is the body of the 403 responses of RequirePermission.
+type ForbiddenError struct {
+	Error      string          `json:"error" example:"forbidden"`
+	Permission user.Permission `json:"required_permission" example:"items:delete"`
+	Role       user.Role       `json:"role" example:"viewer"`
+	// Scopes are those of the API key of the request, if any.
+	Scopes []user.Permission `json:"scopes,omitempty"`
+}
+
+// RequirePermission lets the request through to next only when the role of
+// the authenticated user grants perm and, for API keys, the key is scoped to
+// it. It must run after AuthMiddleware.
+func RequirePermission(perm user.Permission, next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role := user.RoleFromContext(r.Context())
+		scopes := user.ScopesFromContext(r.Context())
+		if !role.Can(perm) || !user.ScopesAllow(scopes, perm) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(ForbiddenError{Error: "forbidden", Permission: perm, Role: role, Scopes: scopes})
+			return
+		}
+		next(w, r)
+	})
+}
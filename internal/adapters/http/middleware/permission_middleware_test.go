@@ -0,0 +1,53 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/adapters/http/middleware"
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+)
+
+func TestRequirePermission(t *testing.T) {
+	handler := middleware.RequirePermission(user.PermWarehousesWrite, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/warehouses", nil)
+	req = req.WithContext(user.WithPrincipal(req.Context(), &user.Principal{Role: user.RoleAdmin}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/warehouses", nil)
+	req = req.WithContext(user.WithPrincipal(req.Context(), &user.Principal{Role: user.RoleEditor}))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	var body middleware.ForbiddenError
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, middleware.ForbiddenError{Error: "forbidden", Permission: user.PermWarehousesWrite, Role: user.RoleEditor}, body)
+}
+
+func TestRequirePermission_Scopes(t *testing.T) {
+	handler := middleware.RequirePermission(user.PermItemsWrite, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// an editor whose API key only reads
+	ctx := user.WithPrincipal(context.Background(), &user.Principal{Role: user.RoleEditor, Scopes: []user.Permission{user.PermItemsRead}})
+	req := httptest.NewRequest(http.MethodPost, "/items", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	var body middleware.ForbiddenError
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, []user.Permission{user.PermItemsRead}, body.Scopes)
+}
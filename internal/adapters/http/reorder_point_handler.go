@@ -0,0 +1,115 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+type reorderPointRequest struct {
+	ReorderPoint int `json:"reorder_point" example:"10"`
+}
+
+// GetCategoryReorderPoint consulta o ponto de reposição de uma categoria
+// @Summary Consulta o ponto de reposição de uma categoria
+// @Tags categories
+// @Produce json
+// @Param id path int true "ID da categoria"
+// @Success 200 {object} item.CategoryReorderPoint
+// @Failure 404 {string} string "A categoria não tem ponto de reposição"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /categories/{id}/reorder-point [get]
+func (h *ItemHandler) GetCategoryReorderPoint(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid category ID", http.StatusBadRequest)
+		return
+	}
+
+	point, err := h.itemService.GetCategoryReorderPoint(r.Context(), id)
+	if err != nil {
+		writeReorderPointError(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(point); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// SetCategoryReorderPoint define o ponto de reposição de uma categoria
+// @Summary Define o ponto de reposição de uma categoria
+// @Description Define o ponto de reposição padrão dos itens da categoria que não têm um próprio. Um item fica com low_stock quando o estoque chega ao ponto de reposição, e um alerta é enviado uma única vez até o item ser reabastecido.
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path int true "ID da categoria"
+// @Param reorder_point body reorderPointRequest true "Ponto de reposição"
+// @Success 200 {object} item.CategoryReorderPoint
+// @Failure 400 {string} string "Ponto de reposição inválido"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /categories/{id}/reorder-point [put]
+func (h *ItemHandler) SetCategoryReorderPoint(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid category ID", http.StatusBadRequest)
+		return
+	}
+	var req reorderPointRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	point, err := h.itemService.SetCategoryReorderPoint(r.Context(), &item.CategoryReorderPoint{
+		CategoryID:   id,
+		ReorderPoint: req.ReorderPoint,
+	})
+	if err != nil {
+		writeReorderPointError(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(point); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// DeleteCategoryReorderPoint remove o ponto de reposição de uma categoria
+// @Summary Remove o ponto de reposição de uma categoria
+// @Tags categories
+// @Param id path int true "ID da categoria"
+// @Success 204
+// @Failure 404 {string} string "A categoria não tem ponto de reposição"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /categories/{id}/reorder-point [delete]
+func (h *ItemHandler) DeleteCategoryReorderPoint(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid category ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.itemService.DeleteCategoryReorderPoint(r.Context(), id); err != nil {
+		writeReorderPointError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeReorderPointError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, item.ErrInvalidReorderPoint):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, item.ErrReorderPointNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,144 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/domain/warehouse"
+)
+
+type reservationRequest struct {
+	Quantity int `json:"quantity" example:"2"`
+	// TTLSeconds defaults to 15 minutes.
+	TTLSeconds int `json:"ttl_seconds,omitempty" example:"900"`
+	// WarehouseID defaults to the default warehouse.
+	WarehouseID int `json:"warehouse_id,omitempty" example:"1"`
+}
+
+// CreateReservation reserva estoque de um item
+// @Summary Reserva estoque de um item
+// @Description Reserva parte do estoque disponível do item até a confirmação, a liberação ou a expiração da reserva. O TTL padrão é de 15 minutos e o máximo de 24 horas. Reservas expiradas são liberadas automaticamente. O estoque é reservado no depósito informado, ou no depósito padrão, e é vendido dele na confirmação.
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do item"
+// @Param reservation body reservationRequest true "Quantidade, TTL e depósito da reserva"
+// @Success 201 {object} item.Reservation
+// @Failure 400 {string} string "Reserva inválida"
+// @Failure 403 {string} string "O item pertence a outro usuário"
+// @Failure 404 {string} string "Item ou depósito não encontrado"
+// @Failure 409 {string} string "Estoque disponível insuficiente, ou o item não está ativo"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /items/{id}/reservations [post]
+func (h *ItemHandler) CreateReservation(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+	var req reservationRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	ttl := item.DefaultReservationTTL
+	if req.TTLSeconds != 0 {
+		ttl = time.Duration(req.TTLSeconds) * time.Second
+	}
+
+	reservation, err := h.itemService.ReserveStock(r.Context(), id, req.Quantity, ttl, req.WarehouseID)
+	if err != nil {
+		writeReservationError(w, err)
+		return
+	}
+	w.Header().Set("Location", "/api/reservations/"+reservation.ID)
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(reservation); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetReservation consulta uma reserva
+// @Summary Consulta uma reserva
+// @Tags reservations
+// @Produce json
+// @Param id path string true "ID da reserva"
+// @Success 200 {object} item.Reservation
+// @Failure 404 {string} string "Reserva não encontrada"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /reservations/{id} [get]
+func (h *ItemHandler) GetReservation(w http.ResponseWriter, r *http.Request) {
+	reservation, err := h.itemService.GetReservation(r.Context(), mux.Vars(r)["id"])
+	writeReservation(w, reservation, err)
+}
+
+// ConfirmReservation confirma uma reserva
+// @Summary Confirma uma reserva
+// @Description Baixa a quantidade reservada do estoque do item como uma venda, registrada nas movimentações de estoque.
+// @Tags reservations
+// @Produce json
+// @Param id path string true "ID da reserva"
+// @Success 200 {object} item.Reservation
+// @Failure 403 {string} string "O item pertence a outro usuário"
+// @Failure 404 {string} string "Reserva não encontrada"
+// @Failure 409 {string} string "A reserva já foi confirmada, liberada ou expirou, ou o item está arquivado"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /reservations/{id}/confirm [post]
+func (h *ItemHandler) ConfirmReservation(w http.ResponseWriter, r *http.Request) {
+	reservation, err := h.itemService.ConfirmReservation(r.Context(), mux.Vars(r)["id"])
+	writeReservation(w, reservation, err)
+}
+
+// ReleaseReservation libera uma reserva
+// @Summary Libera uma reserva
+// @Description Devolve a quantidade reservada ao estoque disponível do item.
+// @Tags reservations
+// @Produce json
+// @Param id path string true "ID da reserva"
+// @Success 200 {object} item.Reservation
+// @Failure 403 {string} string "O item pertence a outro usuário"
+// @Failure 404 {string} string "Reserva não encontrada"
+// @Failure 409 {string} string "A reserva já foi confirmada, liberada ou expirou"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /reservations/{id}/release [post]
+func (h *ItemHandler) ReleaseReservation(w http.ResponseWriter, r *http.Request) {
+	reservation, err := h.itemService.ReleaseReservation(r.Context(), mux.Vars(r)["id"])
+	writeReservation(w, reservation, err)
+}
+
+func writeReservation(w http.ResponseWriter, reservation *item.Reservation, err error) {
+	if err != nil {
+		writeReservationError(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(reservation); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// writeReservationError maps the errors of reservations to a status.
+func writeReservationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, item.ErrInvalidReservation):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, item.ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, item.ErrItemNotFound), errors.Is(err, item.ErrReservationNotFound),
+		errors.Is(err, warehouse.ErrWarehouseNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, item.ErrInsufficientStock), errors.Is(err, item.ErrReservationClosed),
+		errors.Is(err, item.ErrItemArchived), errors.Is(err, item.ErrItemNotActive):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
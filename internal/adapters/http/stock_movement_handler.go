@@ -0,0 +1,205 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/domain/warehouse"
+)
+
+// stockMovementRequest holds the fields of a stock movement set by the client.
+type stockMovementRequest struct {
+	Type     item.MovementType `json:"type" example:"receipt"`
+	Quantity int               `json:"quantity" example:"10"`
+	Reason   string            `json:"reason" example:"purchase order 1234"`
+	// WarehouseID defaults to the default warehouse.
+	WarehouseID int `json:"warehouse_id,omitempty" example:"1"`
+}
+
+// stockTransferRequest holds the fields of a stock transfer set by the client.
+type stockTransferRequest struct {
+	FromWarehouseID int    `json:"from_warehouse_id" example:"1"`
+	ToWarehouseID   int    `json:"to_warehouse_id" example:"2"`
+	Quantity        int    `json:"quantity" example:"5"`
+	Reason          string `json:"reason" example:"rebalancing"`
+}
+
+// CreateStockMovement registra uma movimentação de estoque
+// @Summary Registra uma movimentação de estoque
+// @Description Registra uma entrada (receipt), venda (sale), devolução (return), ajuste (adjustment) ou baixa (write_off) no estoque do item, no depósito informado ou no depósito padrão. A quantidade é positiva, exceto no ajuste, em que o sinal indica a direção. O estoque e o status do item são atualizados na mesma transação, e o estoque nunca fica negativo.
+// @Tags items
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do item"
+// @Param movement body stockMovementRequest true "Movimentação"
+// @Success 201 {object} item.StockMovement
+// @Failure 400 {string} string "Movimentação inválida"
+// @Failure 403 {string} string "O item pertence a outro usuário"
+// @Failure 404 {string} string "Item ou depósito não encontrado"
+// @Failure 409 {string} string "Estoque insuficiente, ou o item está arquivado"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /items/{id}/stock-movements [post]
+func (h *ItemHandler) CreateStockMovement(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+	var req stockMovementRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	movement, err := h.itemService.RecordStockMovement(r.Context(), id, &item.StockMovement{
+		Type:        req.Type,
+		Quantity:    req.Quantity,
+		Reason:      req.Reason,
+		WarehouseID: req.WarehouseID,
+	})
+	if err != nil {
+		writeStockMovementError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(movement); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// ListStockMovements lista as movimentações de estoque de um item
+// @Summary Lista as movimentações de estoque de um item
+// @Description Lista as movimentações de estoque do item, da mais recente para a mais antiga, opcionalmente filtradas por tipo.
+// @Tags items
+// @Produce json
+// @Param id path int true "ID do item"
+// @Param type query string false "Tipo da movimentação" Enums(receipt, sale, return, adjustment, write_off)
+// @Param limit query int false "Limite de movimentações por página" default(20)
+// @Param page query int false "Página" default(1)
+// @Success 200 {object} item.MovementResponse
+// @Failure 400 {string} string "Parâmetros inválidos"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /items/{id}/stock-movements [get]
+func (h *ItemHandler) ListStockMovements(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+	values := r.URL.Query()
+	query := item.MovementQuery{ItemID: id, Type: item.MovementType(values.Get("type")), Limit: 20, Page: 1}
+	if err := parsePageParams(values, &query.Limit, &query.Page); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	movements, err := h.itemService.ListStockMovements(r.Context(), query)
+	if err != nil {
+		if errors.Is(err, item.ErrInvalidQuery) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(movements); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// TransferStock transfere estoque entre depósitos
+// @Summary Transfere estoque entre depósitos
+// @Description Move estoque do item de um depósito para outro, registrando uma saída (transfer_out) e uma entrada (transfer_in) com o mesmo transfer_id. O estoque total do item não muda.
+// @Tags items
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do item"
+// @Param transfer body stockTransferRequest true "Transferência"
+// @Success 201 {object} item.StockTransfer
+// @Failure 400 {string} string "Transferência inválida"
+// @Failure 403 {string} string "O item pertence a outro usuário"
+// @Failure 404 {string} string "Item ou depósito não encontrado"
+// @Failure 409 {string} string "Estoque insuficiente no depósito de origem, ou o item está arquivado"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /items/{id}/stock-transfers [post]
+func (h *ItemHandler) TransferStock(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+	var req stockTransferRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	transfer, err := h.itemService.TransferStock(r.Context(), id, &item.StockTransfer{
+		FromWarehouseID: req.FromWarehouseID,
+		ToWarehouseID:   req.ToWarehouseID,
+		Quantity:        req.Quantity,
+		Reason:          req.Reason,
+	})
+	if err != nil {
+		writeStockMovementError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(transfer); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// ListStockLevels lista o estoque de um item por depósito
+// @Summary Lista o estoque de um item por depósito
+// @Tags items
+// @Produce json
+// @Param id path int true "ID do item"
+// @Success 200 {array} item.StockLevel
+// @Failure 404 {string} string "Item não encontrado"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /items/{id}/stock-levels [get]
+func (h *ItemHandler) ListStockLevels(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+
+	levels, err := h.itemService.ListStockLevels(r.Context(), id)
+	if err != nil {
+		writeStockMovementError(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(levels); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// writeStockMovementError maps the errors of stock movements to a status.
+func writeStockMovementError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, item.ErrInvalidMovement):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, item.ErrItemNotFound), errors.Is(err, warehouse.ErrWarehouseNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, item.ErrInsufficientStock), errors.Is(err, item.ErrItemArchived):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, item.ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
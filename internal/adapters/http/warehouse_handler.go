@@ -0,0 +1,190 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/teamcubation/go-items-challenge/internal/domain/warehouse"
+	"github.com/teamcubation/go-items-challenge/internal/ports/in"
+)
+
+type WarehouseHandler struct {
+	warehouseService in.WarehouseService
+}
+
+func NewWarehouseHandler(warehouseService in.WarehouseService) *WarehouseHandler {
+	return &WarehouseHandler{warehouseService: warehouseService}
+}
+
+// warehouseRequest holds the fields of a warehouse set by the client.
+type warehouseRequest struct {
+	Code    string `json:"code" example:"NORTH1"`
+	Name    string `json:"name" example:"Depósito Norte"`
+	Address string `json:"address,omitempty" example:"Rua A, 100"`
+}
+
+func decodeWarehouse(r *http.Request) (*warehouse.Warehouse, error) {
+	var req warehouseRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		return nil, err
+	}
+	return &warehouse.Warehouse{Code: req.Code, Name: req.Name, Address: req.Address}, nil
+}
+
+// CreateWarehouse cria um depósito
+// @Summary Cria um depósito
+// @Description Cria um depósito com um código único. O depósito padrão é criado pelas migrações.
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Param warehouse body warehouseRequest true "Depósito"
+// @Success 201 {object} warehouse.Warehouse
+// @Failure 400 {string} string "Depósito inválido"
+// @Failure 409 {string} string "Já existe um depósito com este código"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /warehouses [post]
+func (h *WarehouseHandler) CreateWarehouse(w http.ResponseWriter, r *http.Request) {
+	req, err := decodeWarehouse(r)
+	if err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.warehouseService.CreateWarehouse(r.Context(), req)
+	if err != nil {
+		writeWarehouseError(w, err)
+		return
+	}
+	w.Header().Set("Location", "/api/warehouses/"+strconv.Itoa(created.ID))
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(created); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// ListWarehouses lista os depósitos
+// @Summary Lista os depósitos
+// @Tags warehouses
+// @Produce json
+// @Success 200 {array} warehouse.Warehouse
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /warehouses [get]
+func (h *WarehouseHandler) ListWarehouses(w http.ResponseWriter, r *http.Request) {
+	warehouses, err := h.warehouseService.ListWarehouses(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(warehouses); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetWarehouse consulta um depósito
+// @Summary Consulta um depósito
+// @Tags warehouses
+// @Produce json
+// @Param id path int true "ID do depósito"
+// @Success 200 {object} warehouse.Warehouse
+// @Failure 404 {string} string "Depósito não encontrado"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /warehouses/{id} [get]
+func (h *WarehouseHandler) GetWarehouse(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid warehouse ID", http.StatusBadRequest)
+		return
+	}
+
+	found, err := h.warehouseService.GetWarehouse(r.Context(), id)
+	if err != nil {
+		writeWarehouseError(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(found); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// UpdateWarehouse atualiza um depósito
+// @Summary Atualiza um depósito
+// @Description Atualiza o nome e o endereço do depósito. O código não pode ser alterado.
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Param id path int true "ID do depósito"
+// @Param warehouse body warehouseRequest true "Depósito"
+// @Success 200 {object} warehouse.Warehouse
+// @Failure 400 {string} string "Depósito inválido ou código alterado"
+// @Failure 404 {string} string "Depósito não encontrado"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /warehouses/{id} [put]
+func (h *WarehouseHandler) UpdateWarehouse(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid warehouse ID", http.StatusBadRequest)
+		return
+	}
+	req, err := decodeWarehouse(r)
+	if err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.warehouseService.UpdateWarehouse(r.Context(), id, req)
+	if err != nil {
+		writeWarehouseError(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(updated); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// DeleteWarehouse exclui um depósito
+// @Summary Exclui um depósito
+// @Description Exclui um depósito sem estoque. O depósito padrão não pode ser excluído.
+// @Tags warehouses
+// @Param id path int true "ID do depósito"
+// @Success 204
+// @Failure 404 {string} string "Depósito não encontrado"
+// @Failure 409 {string} string "O depósito ainda tem estoque ou é o padrão"
+// @Failure 500 {string} string "Erro interno do servidor"
+// @Router /warehouses/{id} [delete]
+func (h *WarehouseHandler) DeleteWarehouse(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid warehouse ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.warehouseService.DeleteWarehouse(r.Context(), id); err != nil {
+		writeWarehouseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeWarehouseError maps the errors of warehouses to a status.
+func writeWarehouseError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, warehouse.ErrInvalidWarehouse), errors.Is(err, warehouse.ErrCodeImmutable):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, warehouse.ErrWarehouseNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, warehouse.ErrCodeExists), errors.Is(err, warehouse.ErrWarehouseNotEmpty),
+		errors.Is(err, warehouse.ErrDefaultWarehouse):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,96 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	http2 "github.com/teamcubation/go-items-challenge/internal/adapters/http"
+	"github.com/teamcubation/go-items-challenge/internal/domain/warehouse"
+	"github.com/teamcubation/go-items-challenge/internal/ports/in/mocks"
+)
+
+func setupWarehouseRouter(service *mocks.WarehouseService) *mux.Router {
+	handler := http2.NewWarehouseHandler(service)
+	r := mux.NewRouter()
+	r.HandleFunc("/api/warehouses", handler.CreateWarehouse).Methods("POST")
+	r.HandleFunc("/api/warehouses", handler.ListWarehouses).Methods("GET")
+	r.HandleFunc("/api/warehouses/{id}", handler.GetWarehouse).Methods("GET")
+	r.HandleFunc("/api/warehouses/{id}", handler.UpdateWarehouse).Methods("PUT")
+	r.HandleFunc("/api/warehouses/{id}", handler.DeleteWarehouse).Methods("DELETE")
+	return r
+}
+
+func TestWarehouseHandler_CreateWarehouse(t *testing.T) {
+	service := new(mocks.WarehouseService)
+	router := setupWarehouseRouter(service)
+
+	service.On("CreateWarehouse", mock.Anything, &warehouse.Warehouse{Code: "NORTH", Name: "North"}).
+		Return(&warehouse.Warehouse{ID: 2, Code: "NORTH", Name: "North"}, nil)
+	service.On("CreateWarehouse", mock.Anything, &warehouse.Warehouse{Code: "MAIN", Name: "Main"}).
+		Return(nil, warehouse.ErrCodeExists)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/warehouses", strings.NewReader(`{"code": "NORTH", "name": "North"}`)))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "/api/warehouses/2", rec.Header().Get("Location"))
+	var got warehouse.Warehouse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	assert.Equal(t, 2, got.ID)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/warehouses", strings.NewReader(`{"code": "MAIN", "name": "Main"}`)))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/warehouses", strings.NewReader(`{"code": "X", "default": true}`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestWarehouseHandler_UpdateWarehouse(t *testing.T) {
+	service := new(mocks.WarehouseService)
+	router := setupWarehouseRouter(service)
+
+	service.On("UpdateWarehouse", mock.Anything, 2, &warehouse.Warehouse{Name: "North DC"}).
+		Return(&warehouse.Warehouse{ID: 2, Code: "NORTH", Name: "North DC"}, nil)
+	service.On("UpdateWarehouse", mock.Anything, 2, &warehouse.Warehouse{Code: "SOUTH", Name: "North"}).
+		Return(nil, warehouse.ErrCodeImmutable)
+	service.On("UpdateWarehouse", mock.Anything, 3, mock.Anything).Return(nil, warehouse.ErrWarehouseNotFound)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/api/warehouses/2", strings.NewReader(`{"name": "North DC"}`)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/api/warehouses/2", strings.NewReader(`{"code": "SOUTH", "name": "North"}`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/api/warehouses/3", strings.NewReader(`{"name": "x"}`)))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestWarehouseHandler_DeleteWarehouse(t *testing.T) {
+	service := new(mocks.WarehouseService)
+	router := setupWarehouseRouter(service)
+
+	service.On("DeleteWarehouse", mock.Anything, 1).Return(warehouse.ErrDefaultWarehouse)
+	service.On("DeleteWarehouse", mock.Anything, 2).Return(warehouse.ErrWarehouseNotEmpty)
+	service.On("DeleteWarehouse", mock.Anything, 3).Return(nil)
+
+	for url, status := range map[string]int{
+		"/api/warehouses/1": http.StatusConflict,
+		"/api/warehouses/2": http.StatusConflict,
+		"/api/warehouses/3": http.StatusNoContent,
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, url, nil))
+		assert.Equal(t, status, rec.Code, url)
+	}
+}
@@ -0,0 +1,290 @@
+package jwtauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"github.com/teamcubation/go-items-challenge/pkg/log"
+)
+
+const (
+	// JWKSMaxAge is how long the clients may cache the JWKS.
+	JWKSMaxAge = 5 * time.Minute
+	// activationDelay keeps a new key from signing until every instance has
+	// loaded it and the clients have fetched the JWKS again.
+	activationDelay = 2 * JWKSMaxAge
+
+	rsaKeyBits = 2048
+)
+
+type signingKey struct {
+	id        string
+	alg       string
+	createdAt time.Time
+	private   crypto.Signer
+	public    crypto.PublicKey
+}
+
+// signer is the newest key of the configured algorithm that is active. Before
+// any key is active, as on the first start, the newest key signs.
+func (m *Manager) signer(now time.Time) (signingKey, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var newest *signingKey
+	for i, key := range m.keys {
+		if key.alg != m.cfg.Algorithm {
+			continue
+		}
+		if newest == nil {
+			newest = &m.keys[i]
+		}
+		if !key.createdAt.After(now.Add(-activationDelay)) {
+			return key, true
+		}
+	}
+	if newest == nil {
+		return signingKey{}, false
+	}
+	return *newest, true
+}
+
+// Load reads the keys of the repository, and creates a key when the newest one
+// of the configured algorithm is due for rotation. Keys stored before they were
+// encrypted keep verifying but are replaced. The check and the creation hold
+// the rotation lock, so concurrent instances create a single key. It does
+// nothing for HS256.
+func (m *Manager) Load(ctx context.Context) error {
+	if m.cfg.Algorithm == AlgorithmHS256 {
+		return nil
+	}
+	now := m.now()
+	var stored []user.SigningKey
+	err := m.repo.WithRotationLock(ctx, func(ctx context.Context) error {
+		var err error
+		stored, err = m.repo.ListSigningKeys(ctx, now)
+		if err != nil {
+			return err
+		}
+		for _, key := range stored {
+			if key.Algorithm == m.cfg.Algorithm && key.Encrypted && now.Before(key.CreatedAt.Add(m.cfg.RotationInterval)) {
+				return nil
+			}
+		}
+		key, err := m.newSigningKey(now)
+		if err != nil {
+			return err
+		}
+		if err := m.repo.CreateSigningKey(ctx, key); err != nil {
+			return err
+		}
+		log.GetFromContext(ctx).Infof("Created signing key %s", key.ID)
+		stored = append([]user.SigningKey{*key}, stored...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	keys := make([]signingKey, 0, len(stored))
+	for _, key := range stored {
+		parsed, err := m.parseSigningKey(key)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, parsed)
+	}
+	m.mu.Lock()
+	m.keys = keys
+	m.mu.Unlock()
+	return nil
+}
+
+// newSigningKey generates a key of the configured algorithm. It expires once
+// the tokens it may sign until the next rotation have expired.
+func (m *Manager) newSigningKey(now time.Time) (*user.SigningKey, error) {
+	var private crypto.Signer
+	var err error
+	switch m.cfg.Algorithm {
+	case AlgorithmRS256:
+		private, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case AlgorithmEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	}
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+	id := uuid.New().String()
+	sealed, err := m.sealPrivateKey(id, der)
+	if err != nil {
+		return nil, err
+	}
+	return &user.SigningKey{
+		ID:         id,
+		Algorithm:  m.cfg.Algorithm,
+		PrivateKey: sealed,
+		Encrypted:  true,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(m.cfg.RotationInterval + 2*activationDelay + m.cfg.TTL),
+	}, nil
+}
+
+// sealPrivateKey encrypts der with AES-GCM under the key-encryption key. The
+// nonce is prepended, and the ID of the key is authenticated so that a sealed
+// key cannot be moved to another row.
+func (m *Manager) sealPrivateKey(id string, der []byte) ([]byte, error) {
+	aead, err := m.keyCipher()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, der, []byte(id)), nil
+}
+
+func (m *Manager) openPrivateKey(key user.SigningKey) ([]byte, error) {
+	if !key.Encrypted {
+		return key.PrivateKey, nil
+	}
+	aead, err := m.keyCipher()
+	if err != nil {
+		return nil, err
+	}
+	if len(key.PrivateKey) < aead.NonceSize() {
+		return nil, fmt.Errorf("signing key %s is truncated", key.ID)
+	}
+	nonce, sealed := key.PrivateKey[:aead.NonceSize()], key.PrivateKey[aead.NonceSize():]
+	der, err := aead.Open(nil, nonce, sealed, []byte(key.ID))
+	if err != nil {
+		return nil, fmt.Errorf("decrypting signing key %s: %w", key.ID, err)
+	}
+	return der, nil
+}
+
+func (m *Manager) keyCipher() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(m.cfg.KeyEncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (m *Manager) parseSigningKey(key user.SigningKey) (signingKey, error) {
+	der, err := m.openPrivateKey(key)
+	if err != nil {
+		return signingKey{}, err
+	}
+	private, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return signingKey{}, fmt.Errorf("parsing signing key %s: %w", key.ID, err)
+	}
+	signer, ok := private.(crypto.Signer)
+	if !ok {
+		return signingKey{}, fmt.Errorf("signing key %s cannot sign", key.ID)
+	}
+	switch signer.(type) {
+	case *rsa.PrivateKey:
+		if key.Algorithm != AlgorithmRS256 {
+			return signingKey{}, fmt.Errorf("signing key %s is not an %s key", key.ID, key.Algorithm)
+		}
+	case ed25519.PrivateKey:
+		if key.Algorithm != AlgorithmEdDSA {
+			return signingKey{}, fmt.Errorf("signing key %s is not an %s key", key.ID, key.Algorithm)
+		}
+	default:
+		return signingKey{}, fmt.Errorf("signing key %s has an unsupported type", key.ID)
+	}
+	return signingKey{
+		id:        key.ID,
+		alg:       key.Algorithm,
+		createdAt: key.CreatedAt,
+		private:   signer,
+		public:    signer.Public(),
+	}, nil
+}
+
+// Run loads the keys every interval, rotating them when due, and deletes the
+// expired ones, until ctx is done. interval must be well below the activation
+// delay of new keys.
+func (m *Manager) Run(ctx context.Context, interval time.Duration) {
+	if m.cfg.Algorithm == AlgorithmHS256 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	logger := log.GetFromContext(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := m.Load(ctx); err != nil && ctx.Err() == nil {
+			logger.Errorf("Error loading signing keys: %v", err)
+		}
+		deleted, err := m.repo.DeleteExpiredSigningKeys(ctx, m.now())
+		if err != nil && ctx.Err() == nil {
+			logger.Errorf("Error deleting expired signing keys: %v", err)
+		} else if deleted > 0 {
+			logger.Infof("Deleted %d expired signing keys", deleted)
+		}
+	}
+}
+
+// JWK is a public key in the JSON Web Key format (RFC 7517).
+type JWK struct {
+	KeyType   string `json:"kty" example:"OKP"`
+	ID        string `json:"kid"`
+	Use       string `json:"use" example:"sig"`
+	Algorithm string `json:"alg" example:"EdDSA"`
+	Curve     string `json:"crv,omitempty" example:"Ed25519"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+// JWKS is the set of public keys that verify the access tokens.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys loaded, including those not signing yet. It is
+// empty with HS256, whose secret is never published.
+func (m *Manager) JWKS() JWKS {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	set := JWKS{Keys: []JWK{}}
+	for _, key := range m.keys {
+		jwk := JWK{ID: key.id, Use: "sig", Algorithm: key.alg}
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
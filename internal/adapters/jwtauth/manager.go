@@ -0,0 +1,200 @@
+// Package jwtauth signs and verifies the access tokens. Tokens are signed with
+// a shared secret (HS256) or with asymmetric keys (RS256, EdDSA) that rotate
+// and are published as a JWKS, so other services can verify them.
+package jwtauth
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+
+	DefaultAccessTokenTTL   = time.Hour
+	DefaultRotationInterval = 7 * 24 * time.Hour
+
+	// minSecretLength is the length of the output of SHA-256; shorter HS256
+	// secrets weaken the signature.
+	minSecretLength = 32
+	// keyEncryptionKeyLength selects AES-256 to seal the private keys.
+	keyEncryptionKeyLength = 32
+	// clockSkew is tolerated between the clocks of the instances when
+	// checking exp and nbf.
+	clockSkew = 30 * time.Second
+)
+
+var (
+	ErrInvalidToken  = errors.New("invalid token")
+	ErrInvalidConfig = errors.New("invalid JWT configuration")
+)
+
+// Claims of the access tokens. Tokens without a role are treated as viewers.
+type Claims struct {
+	UserID int       `json:"user_id"`
+	Role   user.Role `json:"role,omitempty"`
+	jwt.StandardClaims
+}
+
+// Config of the tokens. Issuer and Audience, when set, are written to the
+// tokens and required from the tokens verified.
+type Config struct {
+	Algorithm string
+	// Secret signs the HS256 tokens.
+	Secret   []byte
+	Issuer   string
+	Audience string
+	TTL      time.Duration
+	// RotationInterval is how long an asymmetric key signs before a new one
+	// replaces it.
+	RotationInterval time.Duration
+	// KeyEncryptionKey seals the asymmetric private keys before they are
+	// stored. Every instance needs the same one.
+	KeyEncryptionKey []byte
+}
+
+// Manager signs and verifies the access tokens. With an asymmetric algorithm
+// it holds the keys of the repository, which Load refreshes.
+type Manager struct {
+	cfg  Config
+	repo out.SigningKeyRepository
+	now  func() time.Time
+
+	mu   sync.RWMutex
+	keys []signingKey
+}
+
+// New returns a manager for cfg. Asymmetric algorithms need repo, and Load
+// before the first token is signed.
+func New(cfg Config, repo out.SigningKeyRepository) (*Manager, error) {
+	if cfg.TTL <= 0 {
+		cfg.TTL = DefaultAccessTokenTTL
+	}
+	if cfg.RotationInterval <= 0 {
+		cfg.RotationInterval = DefaultRotationInterval
+	}
+	switch cfg.Algorithm {
+	case AlgorithmHS256:
+		if len(cfg.Secret) < minSecretLength {
+			return nil, fmt.Errorf("%w: HS256 needs a secret of at least %d bytes", ErrInvalidConfig, minSecretLength)
+		}
+	case AlgorithmRS256, AlgorithmEdDSA:
+		if repo == nil {
+			return nil, fmt.Errorf("%w: %s needs a key repository", ErrInvalidConfig, cfg.Algorithm)
+		}
+		if len(cfg.KeyEncryptionKey) != keyEncryptionKeyLength {
+			return nil, fmt.Errorf("%w: %s needs a key-encryption key of %d bytes", ErrInvalidConfig, cfg.Algorithm, keyEncryptionKeyLength)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidConfig, cfg.Algorithm)
+	}
+	return &Manager{cfg: cfg, repo: repo, now: time.Now}, nil
+}
+
+// ParseAlgorithm accepts the algorithm names regardless of case.
+func ParseAlgorithm(raw string) (string, error) {
+	for _, alg := range []string{AlgorithmHS256, AlgorithmRS256, AlgorithmEdDSA} {
+		if strings.EqualFold(strings.TrimSpace(raw), alg) {
+			return alg, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidConfig, raw)
+}
+
+// SignAccessToken signs an access token identified by jti, and returns it with
+// its expiry.
+func (m *Manager) SignAccessToken(userID int, role user.Role, jti string) (string, time.Time, error) {
+	now := m.now()
+	expiresAt := now.Add(m.cfg.TTL)
+	claims := &Claims{
+		UserID: userID,
+		Role:   role,
+		StandardClaims: jwt.StandardClaims{
+			Id:        jti,
+			Subject:   strconv.Itoa(userID),
+			Issuer:    m.cfg.Issuer,
+			Audience:  m.cfg.Audience,
+			IssuedAt:  now.Unix(),
+			NotBefore: now.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+	}
+
+	if m.cfg.Algorithm == AlgorithmHS256 {
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.cfg.Secret)
+		return signed, expiresAt, err
+	}
+	key, ok := m.signer(now)
+	if !ok {
+		return "", time.Time{}, errors.New("no signing key loaded")
+	}
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.alg), claims)
+	token.Header["kid"] = key.id
+	signed, err := token.SignedString(key.private)
+	return signed, expiresAt, err
+}
+
+// VerifyAccessToken checks the signature of the token and its exp, nbf, iss
+// and aud claims. exp is required.
+func (m *Manager) VerifyAccessToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	parser := &jwt.Parser{ValidMethods: m.validMethods(), SkipClaimsValidation: true}
+	_, err := parser.ParseWithClaims(tokenString, claims, m.verificationKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	now := m.now()
+	skew := int64(clockSkew.Seconds())
+	switch {
+	case claims.ExpiresAt == 0 || now.Unix() > claims.ExpiresAt+skew:
+		return nil, fmt.Errorf("%w: expired", ErrInvalidToken)
+	case claims.NotBefore != 0 && now.Unix()+skew < claims.NotBefore:
+		return nil, fmt.Errorf("%w: not valid yet", ErrInvalidToken)
+	case m.cfg.Issuer != "" && claims.Issuer != m.cfg.Issuer:
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+	case m.cfg.Audience != "" && claims.Audience != m.cfg.Audience:
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+	return claims, nil
+}
+
+// validMethods are the algorithm of the configuration and those of the keys
+// still loaded, so tokens keep verifying after the algorithm changes.
+func (m *Manager) validMethods() []string {
+	methods := []string{m.cfg.Algorithm}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, key := range m.keys {
+		if key.alg != m.cfg.Algorithm {
+			methods = append(methods, key.alg)
+		}
+	}
+	return methods
+}
+
+func (m *Manager) verificationKey(token *jwt.Token) (interface{}, error) {
+	if token.Method.Alg() == AlgorithmHS256 {
+		return m.cfg.Secret, nil
+	}
+	kid, _ := token.Header["kid"].(string)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, key := range m.keys {
+		// the algorithm of the key, not that of the header, decides
+		if key.id == kid && key.alg == token.Method.Alg() {
+			return key.public, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown key %q", kid)
+}
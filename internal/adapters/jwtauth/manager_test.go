@@ -0,0 +1,246 @@
+package jwtauth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out/mocks"
+)
+
+var (
+	testSecret = []byte("0123456789abcdef0123456789abcdef")
+	testKEK    = []byte("fedcba9876543210fedcba9876543210")
+)
+
+func TestNew_InvalidConfig(t *testing.T) {
+	_, err := New(Config{Algorithm: AlgorithmHS256, Secret: []byte("your_secret_key")}, nil)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	_, err = New(Config{Algorithm: AlgorithmRS256, KeyEncryptionKey: testKEK}, nil)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	repo, _ := keyStore()
+	_, err = New(Config{Algorithm: AlgorithmEdDSA}, repo)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	_, err = ParseAlgorithm("none")
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	alg, err := ParseAlgorithm("eddsa")
+	require.NoError(t, err)
+	assert.Equal(t, AlgorithmEdDSA, alg)
+}
+
+func TestManager_HS256_Claims(t *testing.T) {
+	cfg := Config{Algorithm: AlgorithmHS256, Secret: testSecret, Issuer: "items", Audience: "items-api"}
+	m, err := New(cfg, nil)
+	require.NoError(t, err)
+
+	token, expiresAt, err := m.SignAccessToken(7, user.RoleEditor, "jti-1")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(DefaultAccessTokenTTL), expiresAt, time.Second)
+
+	claims, err := m.VerifyAccessToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, 7, claims.UserID)
+	assert.Equal(t, user.RoleEditor, claims.Role)
+	assert.Equal(t, "jti-1", claims.Id)
+
+	other := cfg
+	other.Issuer = "elsewhere"
+	otherIssuer, err := New(other, nil)
+	require.NoError(t, err)
+	_, err = otherIssuer.VerifyAccessToken(token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	other = cfg
+	other.Audience = "reports-api"
+	otherAudience, err := New(other, nil)
+	require.NoError(t, err)
+	_, err = otherAudience.VerifyAccessToken(token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// a token from the future is not valid yet, beyond the clock skew
+	m.now = func() time.Time { return time.Now().Add(-time.Minute) }
+	_, err = m.VerifyAccessToken(token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	m.now = time.Now
+
+	// exp is required
+	noExpiry, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{UserID: 7, StandardClaims: jwt.StandardClaims{
+		Issuer: "items", Audience: "items-api",
+	}}).SignedString(testSecret)
+	require.NoError(t, err)
+	_, err = m.VerifyAccessToken(noExpiry)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	assert.Empty(t, m.JWKS().Keys)
+}
+
+// keyStore is a signing key repository in memory.
+func keyStore() (*mocks.SigningKeyRepository, *[]user.SigningKey) {
+	repo := new(mocks.SigningKeyRepository)
+	var stored []user.SigningKey
+	repo.On("WithRotationLock", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	repo.On("ListSigningKeys", mock.Anything, mock.Anything).Return(func(_ context.Context, now time.Time) []user.SigningKey {
+		var keys []user.SigningKey
+		for i := len(stored) - 1; i >= 0; i-- {
+			if stored[i].ExpiresAt.After(now) {
+				keys = append(keys, stored[i])
+			}
+		}
+		return keys
+	}, nil)
+	repo.On("CreateSigningKey", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = append(stored, *args.Get(1).(*user.SigningKey))
+	}).Return(nil)
+	return repo, &stored
+}
+
+func TestManager_EdDSA_Rotation(t *testing.T) {
+	repo, stored := keyStore()
+	m, err := New(Config{Algorithm: AlgorithmEdDSA, RotationInterval: 24 * time.Hour, KeyEncryptionKey: testKEK}, repo)
+	require.NoError(t, err)
+
+	start := time.Now()
+	m.now = func() time.Time { return start }
+	require.NoError(t, m.Load(context.Background()))
+	require.Len(t, *stored, 1)
+	first := (*stored)[0].ID
+
+	// the first key signs right away
+	token, _, err := m.SignAccessToken(7, user.RoleViewer, "jti-1")
+	require.NoError(t, err)
+	assert.Equal(t, first, kidOf(t, token))
+
+	// loading again before the rotation is due keeps the key
+	require.NoError(t, m.Load(context.Background()))
+	require.Len(t, *stored, 1)
+
+	// after the rotation interval a new key is published, but does not sign
+	// until it is active
+	rotation := start.Add(24 * time.Hour)
+	m.now = func() time.Time { return rotation }
+	require.NoError(t, m.Load(context.Background()))
+	require.Len(t, *stored, 2)
+	second := (*stored)[1].ID
+	assert.Len(t, m.JWKS().Keys, 2)
+
+	token2, _, err := m.SignAccessToken(7, user.RoleViewer, "jti-2")
+	require.NoError(t, err)
+	assert.Equal(t, first, kidOf(t, token2))
+
+	m.now = func() time.Time { return rotation.Add(activationDelay) }
+	token3, _, err := m.SignAccessToken(7, user.RoleViewer, "jti-3")
+	require.NoError(t, err)
+	assert.Equal(t, second, kidOf(t, token3))
+
+	// tokens of both keys verify
+	for _, signed := range []string{token2, token3} {
+		claims, err := m.VerifyAccessToken(signed)
+		require.NoError(t, err)
+		assert.Equal(t, 7, claims.UserID)
+	}
+
+	// the JWKS holds the public keys
+	var jwk JWK
+	for _, key := range m.JWKS().Keys {
+		if key.ID == second {
+			jwk = key
+		}
+	}
+	assert.Equal(t, "OKP", jwk.KeyType)
+	assert.Equal(t, "Ed25519", jwk.Curve)
+	x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	require.NoError(t, err)
+	assert.Len(t, x, ed25519.PublicKeySize)
+}
+
+func TestManager_RS256(t *testing.T) {
+	repo, _ := keyStore()
+	m, err := New(Config{Algorithm: AlgorithmRS256, KeyEncryptionKey: testKEK}, repo)
+	require.NoError(t, err)
+	require.NoError(t, m.Load(context.Background()))
+
+	token, _, err := m.SignAccessToken(3, user.RoleAdmin, "jti")
+	require.NoError(t, err)
+	claims, err := m.VerifyAccessToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, user.RoleAdmin, claims.Role)
+
+	keys := m.JWKS().Keys
+	require.Len(t, keys, 1)
+	assert.Equal(t, "RSA", keys[0].KeyType)
+	assert.Equal(t, "AQAB", keys[0].E)
+
+	// an HS256 token is rejected, even signed with a published key
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{UserID: 3, StandardClaims: jwt.StandardClaims{
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}}).SignedString([]byte(keys[0].N))
+	require.NoError(t, err)
+	_, err = m.VerifyAccessToken(forged)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// a token signed by an unknown key is rejected
+	otherRepo, _ := keyStore()
+	other, err := New(Config{Algorithm: AlgorithmRS256, KeyEncryptionKey: testKEK}, otherRepo)
+	require.NoError(t, err)
+	require.NoError(t, other.Load(context.Background()))
+	unknown, _, err := other.SignAccessToken(3, user.RoleAdmin, "jti")
+	require.NoError(t, err)
+	_, err = m.VerifyAccessToken(unknown)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestManager_EncryptedKeys(t *testing.T) {
+	repo, stored := keyStore()
+	m, err := New(Config{Algorithm: AlgorithmEdDSA, KeyEncryptionKey: testKEK}, repo)
+	require.NoError(t, err)
+
+	// a key stored in plain before encryption keeps verifying its tokens, but
+	// an encrypted key replaces it
+	_, legacyPrivate, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(legacyPrivate)
+	require.NoError(t, err)
+	*stored = append(*stored, user.SigningKey{
+		ID: "legacy", Algorithm: AlgorithmEdDSA, PrivateKey: der,
+		CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, m.Load(context.Background()))
+	require.Len(t, *stored, 2)
+	sealed := (*stored)[1]
+	assert.True(t, sealed.Encrypted)
+	_, err = x509.ParsePKCS8PrivateKey(sealed.PrivateKey)
+	assert.Error(t, err)
+	assert.Len(t, m.JWKS().Keys, 2)
+
+	// another key-encryption key cannot open the stored keys
+	other, err := New(Config{Algorithm: AlgorithmEdDSA, KeyEncryptionKey: testSecret}, repo)
+	require.NoError(t, err)
+	assert.Error(t, other.Load(context.Background()))
+
+	// nor can a sealed key be moved to another ID
+	moved := sealed
+	moved.ID = "moved"
+	_, err = m.parseSigningKey(moved)
+	assert.Error(t, err)
+}
+
+func kidOf(t *testing.T, token string) string {
+	parsed, _, err := new(jwt.Parser).ParseUnverified(token, &Claims{})
+	require.NoError(t, err)
+	kid, _ := parsed.Header["kid"].(string)
+	return kid
+}
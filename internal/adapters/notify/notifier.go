@@ -0,0 +1,64 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+	"github.com/teamcubation/go-items-challenge/pkg/log"
+)
+
+type logNotifier struct{}
+
+// NewLogNotifier returns a notifier that writes the alerts to the log, for
+// deployments without a webhook.
+func NewLogNotifier() out.StockAlertNotifier {
+	return logNotifier{}
+}
+
+func (logNotifier) NotifyLowStock(ctx context.Context, alert *item.StockAlert) error {
+	log.GetFromContext(ctx).WithFields(map[string]interface{}{
+		"item_id":       alert.ItemID,
+		"code":          alert.Code,
+		"stock":         alert.Stock,
+		"reorder_point": alert.ReorderPoint,
+	}).Warn("Item is low on stock")
+	return nil
+}
+
+type webhookNotifier struct {
+	client *resty.Client
+	url    string
+}
+
+// NewWebhookNotifier returns a notifier that POSTs each alert as JSON to url.
+// Any status other than 2xx is an error, so the alert is retried.
+func NewWebhookNotifier(url string) out.StockAlertNotifier {
+	client := resty.New().
+		SetTimeout(10 * time.Second).
+		SetRetryCount(2).
+		SetRetryWaitTime(1 * time.Second).
+		AddRetryCondition(func(r *resty.Response, _ error) bool {
+			return r.StatusCode() >= 500
+		})
+
+	return &webhookNotifier{client: client, url: url}
+}
+
+func (n *webhookNotifier) NotifyLowStock(ctx context.Context, alert *item.StockAlert) error {
+	resp, err := n.client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetBody(alert).
+		Post(n.url)
+	if err != nil {
+		return fmt.Errorf("error sending stock alert: %w", err)
+	}
+	if resp.IsError() {
+		return fmt.Errorf("stock alert webhook returned %s", resp.Status())
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teamcubation/go-items-challenge/internal/adapters/notify"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+func TestWebhookNotifier_NotifyLowStock(t *testing.T) {
+	var received item.StockAlert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	alert := &item.StockAlert{ID: 1, ItemID: 7, Code: "A1", Stock: 2, ReorderPoint: 5}
+	err := notify.NewWebhookNotifier(server.URL).NotifyLowStock(context.Background(), alert)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, received.ItemID)
+	assert.Equal(t, 5, received.ReorderPoint)
+}
+
+func TestWebhookNotifier_NotifyLowStock_Rejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	err := notify.NewWebhookNotifier(server.URL).NotifyLowStock(context.Background(), &item.StockAlert{ItemID: 7})
+	assert.Error(t, err)
+}
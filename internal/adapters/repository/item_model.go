@@ -2,8 +2,6 @@ package repository
 
 import (
 	"time"
-
-	"gorm.io/gorm"
 )
 
 type ItemModel struct {
@@ -21,12 +19,3 @@ type ItemModel struct {
 func (ItemModel) TableName() string {
 	return "items"
 }
-
-func (item *ItemModel) BeforeSave(_ *gorm.DB) (err error) {
-	if item.Stock > 0 {
-		item.Status = "ACTIVE"
-	} else {
-		item.Status = "INACTIVE"
-	}
-	return
-}
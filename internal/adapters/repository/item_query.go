@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+// filterItems translates the filters of an item.ListQuery into WHERE clauses.
+func filterItems(query item.ListQuery) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if query.IncludeDeleted {
+			db = db.Unscoped()
+		}
+		if query.Status != "" {
+			db = db.Where("UPPER(status) = ?", query.Status)
+		}
+		if query.CategoryID != nil {
+			db = db.Where("category_id = ?", *query.CategoryID)
+		}
+		if query.MinPrice != nil {
+			db = db.Where("price >= ?", *query.MinPrice)
+		}
+		if query.MaxPrice != nil {
+			db = db.Where("price <= ?", *query.MaxPrice)
+		}
+		if query.MinStock != nil {
+			db = db.Where("stock >= ?", *query.MinStock)
+		}
+		if query.MaxStock != nil {
+			db = db.Where("stock <= ?", *query.MaxStock)
+		}
+		if query.CreatedBy != nil {
+			db = db.Where("created_by = ?", *query.CreatedBy)
+		}
+		if query.OwnerID != 0 {
+			db = db.Where("(created_by = ? OR id IN (?))", query.OwnerID,
+				db.Session(&gorm.Session{NewDB: true}).Model(&item.ItemGrant{}).Select("item_id").Where("user_id = ?", query.OwnerID))
+		}
+		if query.CreatedFrom != nil {
+			db = db.Where("created_at >= ?", *query.CreatedFrom)
+		}
+		if query.CreatedTo != nil {
+			db = db.Where("created_at <= ?", *query.CreatedTo)
+		}
+		if query.UpdatedFrom != nil {
+			db = db.Where("updated_at >= ?", *query.UpdatedFrom)
+		}
+		if query.UpdatedTo != nil {
+			db = db.Where("updated_at <= ?", *query.UpdatedTo)
+		}
+		if query.LowStock != nil {
+			db = db.Where("low_stock = ?", *query.LowStock)
+		}
+		if query.CodePrefix != "" {
+			db = db.Where("code LIKE ?", escapeLike(query.CodePrefix)+"%")
+		}
+		return db
+	}
+}
+
+// sortItems orders by the requested fields, always ending with the id so that
+// pagination is stable. Field names are validated by item.ParseSort and match
+// the column names.
+func sortItems(fields []item.SortField) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		sortedByID := false
+		for _, f := range fields {
+			db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: f.Field}, Desc: f.Desc})
+			if f.Field == "id" {
+				sortedByID = true
+			}
+		}
+		if !sortedByID {
+			db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}})
+		}
+		return db
+	}
+}
+
+func escapeLike(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return r.Replace(s)
+}
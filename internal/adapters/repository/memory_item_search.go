@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"html"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+)
+
+// Field weights mirror the setweight calls of the search_vector column.
+const (
+	weightA = 1.0
+	weightB = 0.4
+)
+
+// MemoryItemSearch is an in-memory out.ItemSearcher with the same matching
+// rules as the PostgreSQL implementation. It is meant for tests and for
+// running without a database.
+type MemoryItemSearch struct {
+	mu    sync.RWMutex
+	items map[int]item.Item
+}
+
+var _ out.ItemSearcher = (*MemoryItemSearch)(nil)
+
+func NewMemoryItemSearch(items ...item.Item) *MemoryItemSearch {
+	s := &MemoryItemSearch{items: make(map[int]item.Item, len(items))}
+	s.Index(items...)
+	return s
+}
+
+// Index adds or replaces items in the index.
+func (s *MemoryItemSearch) Index(items ...item.Item) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, itm := range items {
+		s.items[itm.ID] = itm
+	}
+}
+
+func (s *MemoryItemSearch) Remove(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.items, id)
+}
+
+func (s *MemoryItemSearch) SearchItems(_ context.Context, query item.SearchQuery) (*item.SearchResponse, error) {
+	status, err := item.ParseStatus(query.Status)
+	if err != nil {
+		return nil, err
+	}
+	terms := query.Terms()
+
+	s.mu.RLock()
+	var matches []item.SearchResult
+	for _, itm := range s.items {
+		if itm.Status != status {
+			continue
+		}
+		rank, ok := rankItem(itm, terms)
+		if !ok {
+			continue
+		}
+		matches = append(matches, item.SearchResult{
+			Item:    itm,
+			Rank:    rank,
+			Snippet: highlight(strings.TrimSpace(itm.Title+" "+itm.Description), terms),
+		})
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Rank != matches[j].Rank {
+			return matches[i].Rank > matches[j].Rank
+		}
+		return matches[i].Item.ID < matches[j].Item.ID
+	})
+
+	response := &item.SearchResponse{
+		TotalPages: (len(matches) + query.Limit - 1) / query.Limit,
+		Data:       []item.SearchResult{},
+	}
+	start := (query.Page - 1) * query.Limit
+	if start < len(matches) {
+		end := min(start+query.Limit, len(matches))
+		response.Data = matches[start:end]
+	}
+	return response, nil
+}
+
+// rankItem reports whether every term prefixes a word of the item and sums the
+// weights of the matching words.
+func rankItem(itm item.Item, terms []string) (float64, bool) {
+	fields := []struct {
+		words  []string
+		weight float64
+	}{
+		{words: words(itm.Code), weight: weightA},
+		{words: words(itm.Title), weight: weightA},
+		{words: words(itm.Description), weight: weightB},
+	}
+
+	var rank float64
+	for _, term := range terms {
+		matched := false
+		for _, f := range fields {
+			for _, w := range f.words {
+				if strings.HasPrefix(w, term) {
+					rank += f.weight
+					matched = true
+				}
+			}
+		}
+		if !matched {
+			return 0, false
+		}
+	}
+	return rank, true
+}
+
+func words(s string) []string {
+	return item.SearchQuery{Text: s}.Terms()
+}
+
+// highlight wraps the words of text starting with any of the terms in <mark>
+// tags, like ts_headline does, and escapes the rest of text as HTML.
+func highlight(text string, terms []string) string {
+	var b strings.Builder
+	start := -1
+	flush := func(end int) {
+		word := text[start:end]
+		lower := strings.ToLower(word)
+		for _, term := range terms {
+			if strings.HasPrefix(lower, term) {
+				b.WriteString("<mark>" + word + "</mark>")
+				return
+			}
+		}
+		b.WriteString(word)
+	}
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsNumber(r)
+		switch {
+		case isWord && start < 0:
+			start = i
+		case !isWord && start >= 0:
+			flush(i)
+			start = -1
+			b.WriteString(html.EscapeString(string(r)))
+		case !isWord:
+			b.WriteString(html.EscapeString(string(r)))
+		}
+	}
+	if start >= 0 {
+		flush(len(text))
+	}
+	return b.String()
+}
@@ -0,0 +1,85 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/adapters/repository"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+func newSearchFixture() *repository.MemoryItemSearch {
+	return repository.NewMemoryItemSearch(
+		item.Item{ID: 1, Code: "SHIRT01", Title: "Blue cotton shirt", Description: "A comfortable shirt for summer", Status: item.StatusActive},
+		item.Item{ID: 2, Code: "SHOE02", Title: "Running shoes", Description: "Lightweight shoes, blue laces", Status: item.StatusActive},
+		item.Item{ID: 3, Code: "HAT03", Title: "Straw hat", Description: "Protects from the sun", Status: item.StatusActive},
+	)
+}
+
+func TestMemoryItemSearch_RanksTitleMatchesFirst(t *testing.T) {
+	search := newSearchFixture()
+
+	resp, err := search.SearchItems(context.Background(), item.SearchQuery{Status: "ACTIVE", Text: "blue", Limit: 10, Page: 1})
+	require.NoError(t, err)
+
+	require.Len(t, resp.Data, 2)
+	assert.Equal(t, 1, resp.Data[0].Item.ID)
+	assert.Equal(t, 2, resp.Data[1].Item.ID)
+	assert.Greater(t, resp.Data[0].Rank, resp.Data[1].Rank)
+	assert.Equal(t, "<mark>Blue</mark> cotton shirt A comfortable shirt for summer", resp.Data[0].Snippet)
+}
+
+func TestMemoryItemSearch_PrefixAndAllTerms(t *testing.T) {
+	search := newSearchFixture()
+
+	resp, err := search.SearchItems(context.Background(), item.SearchQuery{Status: "ACTIVE", Text: "run sho", Limit: 10, Page: 1})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, 2, resp.Data[0].Item.ID)
+
+	resp, err = search.SearchItems(context.Background(), item.SearchQuery{Status: "ACTIVE", Text: "hat03", Limit: 10, Page: 1})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, 3, resp.Data[0].Item.ID)
+
+	resp, err = search.SearchItems(context.Background(), item.SearchQuery{Status: "ACTIVE", Text: "straw shirt", Limit: 10, Page: 1})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Data)
+}
+
+func TestMemoryItemSearch_Pagination(t *testing.T) {
+	search := newSearchFixture()
+
+	resp, err := search.SearchItems(context.Background(), item.SearchQuery{Status: "ACTIVE", Text: "s", Limit: 2, Page: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 2, resp.TotalPages)
+	require.Len(t, resp.Data, 1)
+}
+
+func TestMemoryItemSearch_SnippetIsEscaped(t *testing.T) {
+	search := repository.NewMemoryItemSearch(
+		item.Item{ID: 1, Code: "MUG01", Title: "Mug", Description: `<script>alert("x")</script>`, Status: item.StatusActive},
+	)
+
+	resp, err := search.SearchItems(context.Background(), item.SearchQuery{Status: "ACTIVE", Text: "mug", Limit: 10, Page: 1})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, "<mark>Mug</mark> &lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;", resp.Data[0].Snippet)
+}
+
+func TestMemoryItemSearch_Status(t *testing.T) {
+	search := newSearchFixture()
+	search.Index(item.Item{ID: 4, Code: "SHIRT04", Title: "Blue linen shirt", Status: item.StatusDraft})
+
+	resp, err := search.SearchItems(context.Background(), item.SearchQuery{Text: "shirt", Status: "ACTIVE", Limit: 10, Page: 1})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, 1, resp.Data[0].Item.ID, "drafts are not searched by default")
+
+	resp, err = search.SearchItems(context.Background(), item.SearchQuery{Text: "shirt", Status: "draft", Limit: 10, Page: 1})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, 4, resp.Data[0].Item.ID)
+}
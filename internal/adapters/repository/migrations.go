@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// itemStatements holds the schema changes for the items table that
+// AutoMigrate cannot express. Every statement must be idempotent.
+var itemStatements = []string{
+	// supports keyset pagination ordered by (updated_at, id)
+	`CREATE INDEX IF NOT EXISTS idx_items_updated_at_id ON items (updated_at, id)`,
+	// full-text search over code, title and description
+	`ALTER TABLE items ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+		setweight(to_tsvector('english', coalesce(code, '')), 'A') ||
+		setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+		setweight(to_tsvector('english', coalesce(description, '')), 'B')
+	) STORED`,
+	`CREATE INDEX IF NOT EXISTS idx_items_search_vector ON items USING GIN (search_vector)`,
+	// reservations can never hold more stock than is on hand
+	`DO $$ BEGIN
+		ALTER TABLE items ADD CONSTRAINT items_reserved_check CHECK (reserved >= 0 AND reserved <= stock);
+	EXCEPTION WHEN duplicate_object THEN NULL;
+	END $$`,
+	// items without stock were INACTIVE before the lifecycle statuses
+	`UPDATE items SET status = 'OUT_OF_STOCK' WHERE status = 'INACTIVE'`,
+	`DO $$ BEGIN
+		ALTER TABLE items ADD CONSTRAINT items_status_check
+			CHECK (status IN ('DRAFT', 'ACTIVE', 'OUT_OF_STOCK', 'DISCONTINUED', 'ARCHIVED'));
+	EXCEPTION WHEN duplicate_object THEN NULL;
+	END $$`,
+	// items created before public IDs get a UUID
+	`UPDATE items SET public_id = gen_random_uuid()::text WHERE public_id IS NULL OR public_id = ''`,
+	`ALTER TABLE items ALTER COLUMN public_id SET NOT NULL`,
+}
+
+// MigrateItems applies the raw SQL statements needed by the item repository.
+// It must run after the items table has been created by AutoMigrate.
+func MigrateItems(db *gorm.DB) error {
+	return execStatements(db, itemStatements)
+}
+
+// MigrateAudit applies the raw SQL statements of the audit repository. It must
+// run after the item_audit_entries table has been created by AutoMigrate.
+func MigrateAudit(db *gorm.DB) error {
+	return execStatements(db, appendOnlyStatements("item_audit_entries"))
+}
+
+// stockMovementStatements record the stock of the items that predate the
+// stock ledger as an opening receipt, so the ledger of every item adds up to
+// its stock.
+var stockMovementStatements = []string{
+	`INSERT INTO stock_movements (item_id, type, quantity, delta, reason, stock_after, actor_id, request_id, created_at, warehouse_id, transfer_id)
+		SELECT items.id, 'receipt', items.stock, items.stock, 'opening balance', items.stock, 0, '', now(), 0, ''
+		FROM items
+		WHERE items.stock > 0
+			AND NOT EXISTS (SELECT 1 FROM stock_movements WHERE stock_movements.item_id = items.id)`,
+}
+
+// MigrateStockMovements applies the raw SQL statements of the stock movement
+// repository. It must run after the items and stock_movements tables have been
+// created by AutoMigrate.
+func MigrateStockMovements(db *gorm.DB) error {
+	return execStatements(db, append(stockMovementStatements, appendOnlyStatements("stock_movements")...))
+}
+
+// warehouseStatements create the default warehouse and move the stock of the
+// items that have no stock levels yet into it.
+var warehouseStatements = []string{
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_warehouses_default ON warehouses (is_default) WHERE is_default`,
+	`INSERT INTO warehouses (code, name, is_default, created_at, updated_at)
+		SELECT 'MAIN', 'Main warehouse', true, now(), now()
+		WHERE NOT EXISTS (SELECT 1 FROM warehouses WHERE is_default)`,
+	`INSERT INTO item_stock_levels (item_id, warehouse_id, stock, updated_at)
+		SELECT items.id, warehouses.id, items.stock, now()
+		FROM items, warehouses
+		WHERE warehouses.is_default AND items.stock > 0
+			AND NOT EXISTS (SELECT 1 FROM item_stock_levels WHERE item_stock_levels.item_id = items.id)`,
+	`DO $$ BEGIN
+		ALTER TABLE item_stock_levels ADD CONSTRAINT item_stock_levels_stock_check CHECK (stock >= 0);
+	EXCEPTION WHEN duplicate_object THEN NULL;
+	END $$`,
+	`DO $$ BEGIN
+		ALTER TABLE item_stock_levels ADD CONSTRAINT item_stock_levels_reserved_check CHECK (reserved >= 0 AND reserved <= stock);
+	EXCEPTION WHEN duplicate_object THEN NULL;
+	END $$`,
+}
+
+// MigrateWarehouses applies the raw SQL statements of the warehouse repository.
+// It must run after the items, warehouses and item_stock_levels tables have
+// been created by AutoMigrate.
+func MigrateWarehouses(db *gorm.DB) error {
+	return execStatements(db, warehouseStatements)
+}
+
+// appendOnlyStatements make table append-only at the database level.
+func appendOnlyStatements(table string) []string {
+	return []string{
+		fmt.Sprintf(`CREATE OR REPLACE FUNCTION %[1]s_immutable() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION '%[1]s rows cannot be changed';
+		END
+		$$ LANGUAGE plpgsql`, table),
+		fmt.Sprintf(`DROP TRIGGER IF EXISTS %[1]s_immutable ON %[1]s`, table),
+		fmt.Sprintf(`CREATE TRIGGER %[1]s_immutable BEFORE UPDATE OR DELETE ON %[1]s
+		FOR EACH ROW EXECUTE FUNCTION %[1]s_immutable()`, table),
+	}
+}
+
+func execStatements(db *gorm.DB, statements []string) error {
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("error running migration %q: %w", stmt, err)
+		}
+	}
+	return nil
+}
+
+// userRoleStatements turn the admin flag of the users into a role. Users that
+// were not admins could write items, so they become editors.
+var userRoleStatements = []string{
+	`DO $$ BEGIN
+		IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'users' AND column_name = 'admin') THEN
+			ALTER TABLE users ADD COLUMN IF NOT EXISTS role text;
+			UPDATE users SET role = CASE WHEN admin THEN 'admin' ELSE 'editor' END WHERE role IS NULL;
+			ALTER TABLE users DROP COLUMN admin;
+		END IF;
+	END $$`,
+}
+
+// MigrateUserRoles must run before AutoMigrate, which would otherwise give
+// every existing user the default role.
+func MigrateUserRoles(db *gorm.DB) error {
+	return execStatements(db, userRoleStatements)
+}
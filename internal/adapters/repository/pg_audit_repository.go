@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+	"gorm.io/gorm"
+)
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) out.AuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) CreateAuditEntry(ctx context.Context, entry *item.AuditEntry) error {
+	return conn(ctx, r.db).Create(entry).Error
+}
+
+func (r *auditRepository) ListAuditEntries(ctx context.Context, query item.HistoryQuery) (*item.HistoryResponse, error) {
+	entries := []item.AuditEntry{}
+	offset := (query.Page - 1) * query.Limit
+	result := conn(ctx, r.db).
+		Where("item_id = ?", query.ItemID).
+		Order("id DESC").
+		Limit(query.Limit).
+		Offset(offset).
+		Find(&entries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var total int64
+	if err := conn(ctx, r.db).Model(&item.AuditEntry{}).Where("item_id = ?", query.ItemID).Count(&total).Error; err != nil {
+		return nil, err
+	}
+	return &item.HistoryResponse{
+		TotalPages: int((total + int64(query.Limit) - 1) / int64(query.Limit)),
+		Data:       entries,
+	}, nil
+}
+
+func (r *auditRepository) FindRevision(ctx context.Context, itemID int, version int) (*item.AuditEntry, error) {
+	return r.findEntry(conn(ctx, r.db).Where("item_id = ? AND version = ?", itemID, version))
+}
+
+func (r *auditRepository) FindRevisionAt(ctx context.Context, itemID int, at time.Time) (*item.AuditEntry, error) {
+	return r.findEntry(conn(ctx, r.db).Where("item_id = ? AND created_at <= ?", itemID, at))
+}
+
+// findEntry returns the newest entry matched by db, or nil.
+func (r *auditRepository) findEntry(db *gorm.DB) (*item.AuditEntry, error) {
+	var entry item.AuditEntry
+	if err := db.Order("id DESC").Take(&entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
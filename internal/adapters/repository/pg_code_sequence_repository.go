@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+	"gorm.io/gorm"
+)
+
+type codeSequenceRepository struct {
+	db *gorm.DB
+}
+
+func NewCodeSequenceRepository(db *gorm.DB) out.CodeSequenceRepository {
+	return &codeSequenceRepository{db: db}
+}
+
+// NextCodeSequence runs outside the transaction of ctx, so that concurrent
+// creations do not wait on each other for the sequence row.
+func (r *codeSequenceRepository) NextCodeSequence(ctx context.Context, key string) (int64, error) {
+	var value int64
+	err := r.db.WithContext(ctx).Raw(
+		`INSERT INTO item_code_sequences (key, value) VALUES (?, 1)
+		ON CONFLICT (key) DO UPDATE SET value = item_code_sequences.value + 1
+		RETURNING value`, key,
+	).Scan(&value).Error
+	return value, err
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type exportJobRepository struct {
+	db *gorm.DB
+}
+
+func NewExportJobRepository(db *gorm.DB) out.ExportJobRepository {
+	return &exportJobRepository{db: db}
+}
+
+func (r *exportJobRepository) CreateExportJob(ctx context.Context, job *item.ExportJob) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+func (r *exportJobRepository) GetExportJob(ctx context.Context, id string) (*item.ExportJob, error) {
+	var job item.ExportJob
+	if err := r.db.WithContext(ctx).First(&job, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// UpdateExportJob saves the job, except for its lease, which only the claim and
+// the heartbeats change.
+func (r *exportJobRepository) UpdateExportJob(ctx context.Context, job *item.ExportJob) error {
+	res := r.db.WithContext(ctx).Select("*").Omit("ClaimedBy", "HeartbeatAt").
+		Where("claimed_by = ?", job.ClaimedBy).Save(job)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return item.ErrExportJobLeaseLost
+	}
+	return nil
+}
+
+// ClaimExportJob locks the oldest pending job with SKIP LOCKED, so concurrent
+// workers, even in other processes, never claim the same job.
+func (r *exportJobRepository) ClaimExportJob(ctx context.Context, claim string) (*item.ExportJob, error) {
+	var job item.ExportJob
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ?", item.ExportJobPending).
+			Order("created_at").
+			First(&job).Error
+		if err != nil {
+			return err
+		}
+		startedAt := time.Now()
+		job.Status = item.ExportJobRunning
+		job.StartedAt = &startedAt
+		job.ClaimedBy = claim
+		job.HeartbeatAt = &startedAt
+		return tx.Save(&job).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *exportJobRepository) HeartbeatExportJob(ctx context.Context, id, claim string, at time.Time) error {
+	res := r.db.WithContext(ctx).Model(&item.ExportJob{}).
+		Where("id = ? AND claimed_by = ? AND status = ?", id, claim, item.ExportJobRunning).
+		Update("heartbeat_at", at)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return item.ErrExportJobLeaseLost
+	}
+	return nil
+}
+
+func (r *exportJobRepository) RequeueStaleExportJobs(ctx context.Context, staleBefore time.Time) (int, error) {
+	result := r.db.WithContext(ctx).Model(&item.ExportJob{}).
+		Where("status = ? AND (heartbeat_at IS NULL OR heartbeat_at < ?)", item.ExportJobRunning, staleBefore).
+		Updates(map[string]interface{}{
+			"status": item.ExportJobPending, "processed": 0, "started_at": nil, "claimed_by": "", "heartbeat_at": nil,
+		})
+	return int(result.RowsAffected), result.Error
+}
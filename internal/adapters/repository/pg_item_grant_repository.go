@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type itemGrantRepository struct {
+	db *gorm.DB
+}
+
+func NewItemGrantRepository(db *gorm.DB) out.ItemGrantRepository {
+	return &itemGrantRepository{db: db}
+}
+
+func (r *itemGrantRepository) CreateItemGrant(ctx context.Context, grant *item.ItemGrant) error {
+	return conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(grant).Error
+}
+
+func (r *itemGrantRepository) DeleteItemGrant(ctx context.Context, itemID, userID int) error {
+	result := conn(ctx, r.db).Delete(&item.ItemGrant{}, "item_id = ? AND user_id = ?", itemID, userID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return item.ErrGrantNotFound
+	}
+	return nil
+}
+
+func (r *itemGrantRepository) ListItemGrants(ctx context.Context, itemID int) ([]item.ItemGrant, error) {
+	grants := []item.ItemGrant{}
+	err := conn(ctx, r.db).Where("item_id = ?", itemID).Order("created_at, user_id").Find(&grants).Error
+	return grants, err
+}
+
+func (r *itemGrantRepository) HasItemGrant(ctx context.Context, itemID, userID int) (bool, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&item.ItemGrant{}).
+		Where("item_id = ? AND user_id = ?", itemID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
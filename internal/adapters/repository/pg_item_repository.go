@@ -4,12 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
-	"github.com/teamcubation/go-items-challenge/internal/adapters/http/middleware"
 	"github.com/teamcubation/go-items-challenge/internal/domain/item"
 	"github.com/teamcubation/go-items-challenge/internal/domain/user"
 	"github.com/teamcubation/go-items-challenge/pkg/log"
@@ -23,9 +22,17 @@ func NewItemRepository(db *gorm.DB) *ItemRepository {
 	return &ItemRepository{db: db}
 }
 
+func (r *ItemRepository) conn(ctx context.Context) *gorm.DB {
+	return conn(ctx, r.db)
+}
+
+func (r *ItemRepository) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTransaction(ctx, r.db, fn)
+}
+
 func (r *ItemRepository) CreateItem(ctx context.Context, itm *item.Item) (*item.Item, error) {
-	userID, ok := ctx.Value(middleware.UserContextKey).(int)
-	if !ok || userID == 0 {
+	userID := user.UserIDFromContext(ctx)
+	if userID == 0 {
 		return nil, fmt.Errorf("invalid user ID in context")
 	}
 	itm.CreatedBy = userID
@@ -33,65 +40,78 @@ func (r *ItemRepository) CreateItem(ctx context.Context, itm *item.Item) (*item.
 
 	// checking if the item code already exists
 	if r.ItemExistsByCode(ctx, itm.Code) {
-		return nil, fmt.Errorf("item with code %s already exists", itm.Code)
-	}
-
-	// setting the status of the item
-	if itm.Stock > 0 {
-		itm.Status = "ACTIVE"
-	} else {
-		itm.Status = "INACTIVE"
+		return nil, fmt.Errorf("%w: %s", item.ErrDuplicateCode, itm.Code)
 	}
 
 	// checking if the user exists
 	var use user.User
-	if err := r.db.WithContext(ctx).First(&use, userID).Error; err != nil {
+	if err := r.conn(ctx).First(&use, userID).Error; err != nil {
 		return nil, fmt.Errorf("user with ID %d not found", userID)
 	}
 
-	if err := r.db.WithContext(ctx).Create(itm).Error; err != nil {
+	itm.Version = 1
+	if err := r.conn(ctx).Create(itm).Error; err != nil {
 		return nil, err
 	}
 
 	return itm, nil
 }
 
+// GetItemByID reads the item without writing: its status is kept up to date
+// by the writes that change it.
 func (r *ItemRepository) GetItemByID(ctx context.Context, id int) (*item.Item, error) {
 	logger := log.GetFromContext(ctx)
 	logger.Info("Entering itemRepository: GetItemById()")
 	logger.Printf("Fetching item with ID: %d", id)
 
 	var itm item.Item
-	if err := r.db.WithContext(ctx).First(&itm, id).Error; err != nil {
+	if err := r.conn(ctx).First(&itm, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("item with ID %d not found: %v", id, err)
+			return nil, fmt.Errorf("%w: id %d", item.ErrItemNotFound, id)
 		}
 		return nil, err
 	}
 
-	if itm.Stock == 0 {
-		itm.Status = "INACTIVE"
-	} else {
-		itm.Status = "ACTIVE"
-	}
+	return &itm, nil
+}
 
-	if err := r.db.WithContext(ctx).Save(&itm).Error; err != nil {
+func (r *ItemRepository) FindItem(ctx context.Context, id int, includeDeleted bool) (*item.Item, error) {
+	db := r.conn(ctx)
+	if includeDeleted {
+		db = db.Unscoped()
+	}
+	var itm item.Item
+	if err := db.First(&itm, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
 		return nil, err
 	}
+	return &itm, nil
+}
 
+// GetItemByCode returns the item with the given code, or nil when there is none.
+func (r *ItemRepository) GetItemByCode(ctx context.Context, code string) (*item.Item, error) {
+	var itm item.Item
+	if err := r.conn(ctx).Where("code = ?", code).First(&itm).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
 	return &itm, nil
 }
 
 func (r *ItemRepository) UpdateItem(ctx context.Context, itm *item.Item) (*item.Item, error) {
-	userID, ok := ctx.Value(middleware.UserContextKey).(int)
-	if !ok || userID == 0 {
+	userID := user.UserIDFromContext(ctx)
+	if userID == 0 {
 		return nil, fmt.Errorf("user ID not found in context")
 	}
 
 	var existingItem item.Item
-	if err := r.db.WithContext(ctx).First(&existingItem, itm.ID).Error; err != nil {
+	if err := r.conn(ctx).First(&existingItem, itm.ID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("item with ID %d not found", itm.ID)
+			return nil, fmt.Errorf("%w: id %d", item.ErrItemNotFound, itm.ID)
 		}
 		return nil, err
 	}
@@ -111,27 +131,38 @@ func (r *ItemRepository) UpdateItem(ctx context.Context, itm *item.Item) (*item.
 
 	// Only allow update if the item code is the same
 	if existingItem.Code != itm.Code {
-		return nil, fmt.Errorf("cannot update item with different code")
+		return nil, item.ErrCodeImmutable
+	}
+
+	// A zero version skips the check, for callers without a precondition
+	if itm.Version != 0 && itm.Version != existingItem.Version {
+		return nil, item.ErrVersionConflict
 	}
 
 	existingItem.Title = itm.Title
 	existingItem.Description = itm.Description
+	existingItem.CategoryID = itm.CategoryID
 	existingItem.Price = itm.Price
-	existingItem.Stock = itm.Stock
+	existingItem.ReorderPoint = itm.ReorderPoint
+	// the stock is only changed through AdjustStock, by the stock ledger
 	existingItem.UpdatedAt = time.Now()
 	existingItem.UpdatedBy = userID
 
-	if existingItem.Stock == 0 {
-		existingItem.Status = "INACTIVE"
-	} else {
-		existingItem.Status = "ACTIVE"
+	// the version condition catches writes made since existingItem was read
+	current := existingItem.Version
+	existingItem.Version = current + 1
+	result := r.conn(ctx).Model(&existingItem).Select("*").Where("version = ?", current).Updates(&existingItem)
+	if result.Error != nil {
+		return nil, result.Error
 	}
-
-	if err := r.db.WithContext(ctx).Save(&existingItem).Error; err != nil {
-		return nil, err
+	if result.RowsAffected == 0 {
+		return nil, item.ErrVersionConflict
 	}
 
+	itm.Version = existingItem.Version
+	itm.Stock = existingItem.Stock
 	itm.Status = existingItem.Status
+	itm.LowStock = existingItem.LowStock
 	itm.CreatedBy = existingItem.CreatedBy
 	itm.CreatedAt = existingItem.CreatedAt
 	itm.UpdatedBy = existingItem.UpdatedBy
@@ -140,47 +171,306 @@ func (r *ItemRepository) UpdateItem(ctx context.Context, itm *item.Item) (*item.
 	return itm, nil
 }
 
-func (r *ItemRepository) DeleteItem(ctx context.Context, id int) (*item.Item, error) {
+// AdjustStock adds the deltas to the on-hand and reserved stock in a single
+// statement, so concurrent movements and reservations cannot lose updates or
+// hold more stock than there is. Deleted items can only release reservations.
+func (r *ItemRepository) AdjustStock(ctx context.Context, id int, stockDelta, reservedDelta int) (*item.Item, error) {
+	release := stockDelta == 0 && reservedDelta < 0
+	updates := map[string]interface{}{
+		"stock":      gorm.Expr("stock + ?", stockDelta),
+		"reserved":   gorm.Expr("reserved + ?", reservedDelta),
+		"status":     stockStatusExpr(stockDelta),
+		"version":    gorm.Expr("version + 1"),
+		"updated_at": time.Now(),
+	}
+	// expired reservations are released by the reaper, without a user
+	if userID := user.UserIDFromContext(ctx); userID != 0 {
+		updates["updated_by"] = userID
+	} else if !release {
+		return nil, fmt.Errorf("user ID not found in context")
+	}
+
+	result := r.conn(ctx).Unscoped().Model(&item.Item{}).
+		Where("id = ? AND stock + ? >= reserved + ? AND reserved + ? >= 0", id, stockDelta, reservedDelta, reservedDelta).
+		Where("deleted_at IS NULL OR ?", release).
+		Updates(updates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	itm, err := r.FindItem(ctx, id, release)
+	if err != nil {
+		return nil, err
+	}
+	if itm == nil {
+		return nil, item.ErrItemNotFound
+	}
+	if result.RowsAffected == 0 {
+		return nil, item.ErrInsufficientStock
+	}
+	return itm, nil
+}
+
+func (r *ItemRepository) UpdateStatus(ctx context.Context, id int, status item.Status, version int) (*item.Item, error) {
+	userID := user.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, fmt.Errorf("user ID not found in context")
+	}
+
+	result := r.conn(ctx).Model(&item.Item{}).Where("id = ? AND version = ?", id, version).Updates(map[string]interface{}{
+		"status":     status,
+		"version":    version + 1,
+		"updated_by": userID,
+		"updated_at": time.Now(),
+	})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	itm, err := r.FindItem(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	if itm == nil {
+		return nil, item.ErrItemNotFound
+	}
+	if result.RowsAffected == 0 {
+		return nil, item.ErrVersionConflict
+	}
+	return itm, nil
+}
+
+func (r *ItemRepository) SetLowStock(ctx context.Context, id int, lowStock bool) (bool, error) {
+	// the flag is part of the item, so its ETag changes with it
+	result := r.conn(ctx).Model(&item.Item{}).
+		Where("id = ? AND low_stock <> ?", id, lowStock).
+		UpdateColumns(map[string]interface{}{"low_stock": lowStock, "version": gorm.Expr("version + 1")})
+	return result.RowsAffected == 1, result.Error
+}
+
+// DeleteItem soft-deletes the item if it is still at version, recording the
+// user in ctx. A zero version deletes it unconditionally.
+func (r *ItemRepository) DeleteItem(ctx context.Context, id int, version int) (*item.Item, error) {
+	userID := user.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, fmt.Errorf("user ID not found in context")
+	}
+
 	var itm item.Item
-	if err := r.db.WithContext(ctx).First(&itm, id).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("item with ID %d not found", id)
+	if err := r.conn(ctx).First(&itm, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: id %d", item.ErrItemNotFound, id)
 		}
 		return nil, err
 	}
-	if err := r.db.WithContext(ctx).Delete(&itm).Error; err != nil {
+	if version != 0 && itm.Version != version {
+		return nil, item.ErrVersionConflict
+	}
+
+	now := time.Now()
+	result := r.conn(ctx).Model(&itm).Where("version = ?", itm.Version).Updates(map[string]interface{}{
+		"deleted_at": now,
+		"deleted_by": userID,
+		"version":    gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, item.ErrVersionConflict
+	}
+
+	itm.DeletedAt = gorm.DeletedAt{Time: now, Valid: true}
+	itm.DeletedBy = userID
+	itm.Version++
+	return &itm, nil
+}
+
+// RestoreItem undoes the soft delete of an item. A zero version skips the
+// version check.
+func (r *ItemRepository) RestoreItem(ctx context.Context, id int, version int) (*item.Item, error) {
+	userID := user.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, fmt.Errorf("user ID not found in context")
+	}
+
+	var itm item.Item
+	if err := r.conn(ctx).Unscoped().First(&itm, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, item.ErrItemNotFound
+		}
 		return nil, err
 	}
+	if !itm.DeletedAt.Valid {
+		return nil, item.ErrItemNotDeleted
+	}
+	if version != 0 && itm.Version != version {
+		return nil, item.ErrVersionConflict
+	}
+
+	itm.DeletedAt = gorm.DeletedAt{}
+	itm.DeletedBy = 0
+	itm.UpdatedBy = userID
+	itm.UpdatedAt = time.Now()
+	current := itm.Version
+	itm.Version++
+	result := r.conn(ctx).Unscoped().Model(&itm).Where("version = ?", current).
+		Select("deleted_at", "deleted_by", "updated_by", "updated_at", "version").Updates(&itm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, item.ErrVersionConflict
+	}
 	return &itm, nil
 }
 
-func (r *ItemRepository) ListItems(ctx context.Context, status string, limit int, page int) (*item.Response, error) {
-	status = strings.ToUpper(status)
-	if status != "ACTIVE" && status != "INACTIVE" {
-		return nil, fmt.Errorf("invalid status: %s", status)
+// PurgeDeletedItems permanently removes the items soft-deleted before the given
+// time, with their stock levels, and returns how many were removed.
+func (r *ItemRepository) PurgeDeletedItems(ctx context.Context, before time.Time) (int, error) {
+	purged := 0
+	err := r.WithinTransaction(ctx, func(ctx context.Context) error {
+		purgedIDs := r.conn(ctx).Unscoped().Model(&item.Item{}).Select("id").Where("deleted_at < ?", before)
+		for _, dependent := range []interface{}{&item.StockLevel{}, &item.ItemGrant{}} {
+			if err := r.conn(ctx).Where("item_id IN (?)", purgedIDs).Delete(dependent).Error; err != nil {
+				return err
+			}
+		}
+		result := r.conn(ctx).Unscoped().Where("deleted_at < ?", before).Delete(&item.Item{})
+		purged = int(result.RowsAffected)
+		return result.Error
+	})
+	return purged, err
+}
+
+func (r *ItemRepository) ListItems(ctx context.Context, query item.ListQuery) (*item.Response, error) {
+	status, err := item.ParseStatus(query.Status)
+	if err != nil {
+		return nil, err
+	}
+	query.Status = string(status)
+
+	if query.Cursor != nil {
+		return r.listItemsAfter(ctx, query)
 	}
 
 	var items []item.Item
-	offset := (page - 1) * limit
-	result := r.db.WithContext(ctx).Where("UPPER(status) = ?", status).Limit(limit).Offset(offset).Find(&items)
+	offset := (query.Page - 1) * query.Limit
+	result := r.conn(ctx).
+		Scopes(filterItems(query), sortItems(query.Sort)).
+		Limit(query.Limit).
+		Offset(offset).
+		Find(&items)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
 	var totalItems int64
-	r.db.WithContext(ctx).Model(&item.Item{}).Where("UPPER(status) = ?", status).Count(&totalItems)
-	totalPages := int((totalItems + int64(limit) - 1) / int64(limit))
+	if err := r.conn(ctx).Model(&item.Item{}).Scopes(filterItems(query)).Count(&totalItems).Error; err != nil {
+		return nil, err
+	}
+	totalPages := int((totalItems + int64(query.Limit) - 1) / int64(query.Limit))
 
 	response := &item.Response{
 		TotalPages: totalPages,
 		Data:       items,
+		HasMore:    query.Page < totalPages,
+	}
+
+	return response, nil
+}
+
+// listItemsAfter implements keyset pagination ordered by (updated_at, id).
+// One extra row is fetched to know whether another page exists.
+func (r *ItemRepository) listItemsAfter(ctx context.Context, query item.ListQuery) (*item.Response, error) {
+	db := r.conn(ctx).Scopes(filterItems(query))
+	if !query.Cursor.IsZero() {
+		db = db.Where("(updated_at, id) > (?, ?)", query.Cursor.UpdatedAt, query.Cursor.ID)
+	}
+
+	var items []item.Item
+	if err := db.Order("updated_at").Order("id").Limit(query.Limit + 1).Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	response := &item.Response{Data: items}
+	if len(items) > query.Limit {
+		response.Data = items[:query.Limit]
+		last := response.Data[len(response.Data)-1]
+		response.HasMore = true
+		response.NextCursor = item.Cursor{UpdatedAt: last.UpdatedAt, ID: last.ID}.Encode()
 	}
 
 	return response, nil
 }
 
+// IterateItems streams every item matching the filters and sorting of query,
+// ignoring pagination, calling fn once per row. An empty status matches every
+// item. Iteration stops at the first error returned by fn.
+func (r *ItemRepository) IterateItems(ctx context.Context, query item.ListQuery, fn func(itm *item.Item) error) error {
+	if err := normalizeStatus(&query); err != nil {
+		return err
+	}
+
+	db := r.conn(ctx)
+	rows, err := db.Model(&item.Item{}).Scopes(filterItems(query), sortItems(query.Sort)).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var itm item.Item
+		if err := db.ScanRows(rows, &itm); err != nil {
+			return err
+		}
+		itm.DeriveAvailable()
+		if err := fn(&itm); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// CountItems counts the items matching the filters of query, with the same
+// status rules as IterateItems.
+func (r *ItemRepository) CountItems(ctx context.Context, query item.ListQuery) (int, error) {
+	if err := normalizeStatus(&query); err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if err := r.conn(ctx).Model(&item.Item{}).Scopes(filterItems(query)).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// ItemExistsByCode also counts soft-deleted items, so their code stays
+// reserved until they are purged and a restore never clashes.
 func (r *ItemRepository) ItemExistsByCode(ctx context.Context, code string) bool {
 	var count int64
-	r.db.WithContext(ctx).Model(&item.Item{}).Where("code = ?", code).Count(&count)
+	r.conn(ctx).Unscoped().Model(&item.Item{}).Where("code = ?", code).Count(&count)
 	return count > 0
 }
+
+// normalizeStatus replaces the status filter of query, if any, with its
+// canonical form.
+func normalizeStatus(query *item.ListQuery) error {
+	if query.Status == "" {
+		return nil
+	}
+	status, err := item.ParseStatus(query.Status)
+	if err != nil {
+		return err
+	}
+	query.Status = string(status)
+	return nil
+}
+
+// stockStatusExpr computes the status of an item whose stock changes by
+// stockDelta, as item.Status.WithStock does.
+func stockStatusExpr(stockDelta int) clause.Expr {
+	return gorm.Expr("CASE WHEN status IN (?, ?) THEN (CASE WHEN stock + ? > 0 THEN ? ELSE ? END) ELSE status END",
+		item.StatusActive, item.StatusOutOfStock, stockDelta, item.StatusActive, item.StatusOutOfStock)
+}
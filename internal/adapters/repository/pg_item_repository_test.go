@@ -1,5 +1,87 @@
 package repository
 
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// recordingDB returns a dry run connection, which builds the SQL of every
+// statement without a database, and the statements built through it.
+func recordingDB(t *testing.T) (*gorm.DB, *[]string) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost user=test dbname=test"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+
+	var statements []string
+	record := func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	}
+	callbacks := db.Callback()
+	require.NoError(t, callbacks.Query().After("gorm:query").Register("test:record", record))
+	require.NoError(t, callbacks.Create().After("gorm:create").Register("test:record", record))
+	require.NoError(t, callbacks.Update().After("gorm:update").Register("test:record", record))
+	require.NoError(t, callbacks.Delete().After("gorm:delete").Register("test:record", record))
+	require.NoError(t, callbacks.Raw().After("gorm:raw").Register("test:record", record))
+	return db, &statements
+}
+
+func TestItemRepository_GetItemByID_DoesNotWrite(t *testing.T) {
+	db, statements := recordingDB(t)
+	repo := NewItemRepository(db)
+
+	_, err := repo.GetItemByID(context.Background(), 1)
+	require.NoError(t, err)
+
+	require.Len(t, *statements, 1)
+	assert.True(t, strings.HasPrefix((*statements)[0], "SELECT"), (*statements)[0])
+}
+
+func TestItemRepository_NotFound(t *testing.T) {
+	db, _ := recordingDB(t)
+	// every query finds no row, as for a missing or soft-deleted item
+	require.NoError(t, db.Callback().Query().After("test:record").Register("test:not_found", func(tx *gorm.DB) {
+		_ = tx.AddError(gorm.ErrRecordNotFound)
+	}))
+	ctx := user.WithPrincipal(context.Background(), &user.Principal{UserID: 7})
+	repo := NewItemRepository(db)
+
+	_, err := repo.GetItemByID(ctx, 1)
+	assert.ErrorIs(t, err, item.ErrItemNotFound)
+	_, err = repo.UpdateItem(ctx, &item.Item{ID: 1})
+	assert.ErrorIs(t, err, item.ErrItemNotFound)
+	_, err = repo.DeleteItem(ctx, 1, 0)
+	assert.ErrorIs(t, err, item.ErrItemNotFound)
+}
+
+func TestItemRepository_ListItems_Owner(t *testing.T) {
+	db, statements := recordingDB(t)
+	repo := NewItemRepository(db)
+
+	_, err := repo.ListItems(context.Background(), item.ListQuery{Status: "ACTIVE", OwnerID: 7, Limit: 10, Page: 1})
+	require.NoError(t, err)
+
+	// the page and the count are filtered the same way
+	filtered := 0
+	for _, stmt := range *statements {
+		if strings.Contains(stmt, `FROM "items"`) {
+			assert.Contains(t, stmt, `(created_by = $2 OR id IN (SELECT "item_id" FROM "item_grants" WHERE user_id = $3))`)
+			filtered++
+		}
+	}
+	assert.Equal(t, 2, filtered)
+}
+
 // func setupTestDB() (*gorm.DB, error) {
 // 	dsn := os.Getenv("DSN")
 
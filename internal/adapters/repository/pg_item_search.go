@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, HighlightAll=false"
+
+// headlineText is the text the snippet is taken from, HTML-escaped so that
+// only the <mark> tags added by ts_headline are markup.
+const headlineText = `replace(replace(replace(replace(replace(
+				coalesce(items.title, '') || ' ' || coalesce(items.description, ''),
+				'&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '''', '&#39;')`
+
+type searchRow struct {
+	item.Item
+	Rank    float64
+	Snippet string
+}
+
+// SearchItems runs a full-text search using the search_vector column created
+// by MigrateItems over the items in the status of query. Every term is matched
+// as a prefix and results are ranked with ts_rank_cd.
+func (r *ItemRepository) SearchItems(ctx context.Context, query item.SearchQuery) (*item.SearchResponse, error) {
+	status, err := item.ParseStatus(query.Status)
+	if err != nil {
+		return nil, err
+	}
+	tsQuery := toTSQuery(query.Terms())
+
+	var totalItems int64
+	if err := r.conn(ctx).Model(&item.Item{}).
+		Where("search_vector @@ to_tsquery('english', ?) AND status = ?", tsQuery, status).
+		Count(&totalItems).Error; err != nil {
+		return nil, err
+	}
+
+	var rows []searchRow
+	offset := (query.Page - 1) * query.Limit
+	err = r.conn(ctx).Raw(`
+		SELECT items.*,
+			ts_rank_cd(items.search_vector, q) AS rank,
+			ts_headline('english', `+headlineText+`, q, ?) AS snippet
+		FROM items, to_tsquery('english', ?) AS q
+		WHERE items.search_vector @@ q AND items.status = ? AND items.deleted_at IS NULL
+		ORDER BY rank DESC, items.id
+		LIMIT ? OFFSET ?`, headlineOptions, tsQuery, status, query.Limit, offset).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	response := &item.SearchResponse{
+		TotalPages: int((totalItems + int64(query.Limit) - 1) / int64(query.Limit)),
+		Data:       make([]item.SearchResult, 0, len(rows)),
+	}
+	for _, row := range rows {
+		row.Item.DeriveAvailable()
+		response.Data = append(response.Data, item.SearchResult{Item: row.Item, Rank: row.Rank, Snippet: row.Snippet})
+	}
+	return response, nil
+}
+
+// toTSQuery turns search terms into a tsquery where every term is required
+// and matched as a prefix, e.g. "blue:* & shirt:*". Terms only contain
+// letters and digits, so they need no further escaping.
+func toTSQuery(terms []string) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		parts[i] = term + ":*"
+	}
+	return strings.Join(parts, " & ")
+}
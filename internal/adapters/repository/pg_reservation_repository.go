@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type reservationRepository struct {
+	db *gorm.DB
+}
+
+func NewReservationRepository(db *gorm.DB) out.ReservationRepository {
+	return &reservationRepository{db: db}
+}
+
+func (r *reservationRepository) CreateReservation(ctx context.Context, reservation *item.Reservation) error {
+	return conn(ctx, r.db).Create(reservation).Error
+}
+
+func (r *reservationRepository) GetReservation(ctx context.Context, id string, forUpdate bool) (*item.Reservation, error) {
+	db := conn(ctx, r.db)
+	if forUpdate {
+		db = db.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	var reservation item.Reservation
+	if err := db.Take(&reservation, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+func (r *reservationRepository) UpdateReservation(ctx context.Context, reservation *item.Reservation) error {
+	return conn(ctx, r.db).Save(reservation).Error
+}
+
+func (r *reservationRepository) ClaimExpiredReservations(ctx context.Context, now time.Time, limit int) ([]item.Reservation, error) {
+	var reservations []item.Reservation
+	err := conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND expires_at <= ?", item.ReservationHeld, now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&reservations).Error
+	return reservations, err
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+	"gorm.io/gorm"
+)
+
+// signingKeyRotationLock is the key of the advisory lock taken while the keys
+// are rotated.
+const signingKeyRotationLock = 7_126_513_010
+
+type signingKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewSigningKeyRepository(db *gorm.DB) out.SigningKeyRepository {
+	return &signingKeyRepository{db: db}
+}
+
+// WithRotationLock holds a transaction-level advisory lock, released when fn
+// returns, so an instance waits for the rotation of another before reading the
+// keys.
+func (r *signingKeyRepository) WithRotationLock(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTransaction(ctx, r.db, func(ctx context.Context) error {
+		if err := conn(ctx, r.db).Exec("SELECT pg_advisory_xact_lock(?)", signingKeyRotationLock).Error; err != nil {
+			return err
+		}
+		return fn(ctx)
+	})
+}
+
+func (r *signingKeyRepository) CreateSigningKey(ctx context.Context, key *user.SigningKey) error {
+	return conn(ctx, r.db).Create(key).Error
+}
+
+func (r *signingKeyRepository) ListSigningKeys(ctx context.Context, now time.Time) ([]user.SigningKey, error) {
+	var keys []user.SigningKey
+	err := conn(ctx, r.db).Where("expires_at > ?", now).Order("created_at DESC").Find(&keys).Error
+	return keys, err
+}
+
+func (r *signingKeyRepository) DeleteExpiredSigningKeys(ctx context.Context, now time.Time) (int64, error) {
+	res := conn(ctx, r.db).Where("expires_at <= ?", now).Delete(&user.SigningKey{})
+	return res.RowsAffected, res.Error
+}
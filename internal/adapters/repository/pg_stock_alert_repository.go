@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type stockAlertRepository struct {
+	db *gorm.DB
+}
+
+func NewStockAlertRepository(db *gorm.DB) out.StockAlertRepository {
+	return &stockAlertRepository{db: db}
+}
+
+func (r *stockAlertRepository) GetCategoryReorderPoint(ctx context.Context, categoryID int) (*item.CategoryReorderPoint, error) {
+	var point item.CategoryReorderPoint
+	if err := conn(ctx, r.db).Take(&point, "category_id = ?", categoryID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &point, nil
+}
+
+func (r *stockAlertRepository) SetCategoryReorderPoint(ctx context.Context, point *item.CategoryReorderPoint) error {
+	return conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "category_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"reorder_point", "updated_at"}),
+	}).Create(point).Error
+}
+
+func (r *stockAlertRepository) DeleteCategoryReorderPoint(ctx context.Context, categoryID int) error {
+	return conn(ctx, r.db).Delete(&item.CategoryReorderPoint{}, "category_id = ?", categoryID).Error
+}
+
+func (r *stockAlertRepository) CreateStockAlert(ctx context.Context, alert *item.StockAlert) error {
+	return conn(ctx, r.db).Create(alert).Error
+}
+
+// ClaimPendingStockAlerts takes the lease in a single statement, so the rows
+// are only locked while it runs, and not while the alerts are sent.
+func (r *stockAlertRepository) ClaimPendingStockAlerts(ctx context.Context, limit int, until time.Time) ([]item.StockAlert, error) {
+	var alerts []item.StockAlert
+	err := conn(ctx, r.db).Raw(`
+		UPDATE stock_alerts SET claimed_until = ?
+		WHERE id IN (
+			SELECT id FROM stock_alerts
+			WHERE sent_at IS NULL AND (claimed_until IS NULL OR claimed_until < now())
+			ORDER BY id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED)
+		RETURNING *`, until, limit).
+		Scan(&alerts).Error
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].ID < alerts[j].ID })
+	return alerts, nil
+}
+
+func (r *stockAlertRepository) MarkStockAlertSent(ctx context.Context, id int64, at time.Time) error {
+	return conn(ctx, r.db).Model(&item.StockAlert{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"sent_at": at, "claimed_until": nil}).Error
+}
+
+func (r *stockAlertRepository) ReleaseStockAlerts(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return conn(ctx, r.db).Model(&item.StockAlert{}).Where("id IN ? AND sent_at IS NULL", ids).
+		Update("claimed_until", nil).Error
+}
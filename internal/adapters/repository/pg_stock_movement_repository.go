@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+	"gorm.io/gorm"
+)
+
+type stockMovementRepository struct {
+	db *gorm.DB
+}
+
+func NewStockMovementRepository(db *gorm.DB) out.StockMovementRepository {
+	return &stockMovementRepository{db: db}
+}
+
+func (r *stockMovementRepository) CreateStockMovement(ctx context.Context, movement *item.StockMovement) error {
+	return conn(ctx, r.db).Create(movement).Error
+}
+
+func (r *stockMovementRepository) ListStockMovements(ctx context.Context, query item.MovementQuery) (*item.MovementResponse, error) {
+	filter := func(db *gorm.DB) *gorm.DB {
+		db = db.Where("item_id = ?", query.ItemID)
+		if query.Type != "" {
+			db = db.Where("type = ?", query.Type)
+		}
+		return db
+	}
+
+	movements := []item.StockMovement{}
+	offset := (query.Page - 1) * query.Limit
+	result := conn(ctx, r.db).
+		Scopes(filter).
+		Order("id DESC").
+		Limit(query.Limit).
+		Offset(offset).
+		Find(&movements)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var total int64
+	if err := conn(ctx, r.db).Model(&item.StockMovement{}).Scopes(filter).Count(&total).Error; err != nil {
+		return nil, err
+	}
+	return &item.MovementResponse{
+		TotalPages: int((total + int64(query.Limit) - 1) / int64(query.Limit)),
+		Data:       movements,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type tokenRepository struct {
+	db *gorm.DB
+}
+
+func NewTokenRepository(db *gorm.DB) out.TokenRepository {
+	return &tokenRepository{db: db}
+}
+
+func (r *tokenRepository) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTransaction(ctx, r.db, fn)
+}
+
+func (r *tokenRepository) CreateRefreshToken(ctx context.Context, token *user.RefreshToken) error {
+	return conn(ctx, r.db).Create(token).Error
+}
+
+func (r *tokenRepository) GetRefreshTokenByHash(ctx context.Context, hash string) (*user.RefreshToken, error) {
+	var token user.RefreshToken
+	err := conn(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Take(&token, "token_hash = ?", hash).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *tokenRepository) MarkRefreshTokenRotated(ctx context.Context, id string, at time.Time) error {
+	return conn(ctx, r.db).Model(&user.RefreshToken{}).Where("id = ?", id).Update("rotated_at", at).Error
+}
+
+func (r *tokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string, at time.Time) ([]user.RefreshToken, error) {
+	var revoked []user.RefreshToken
+	err := conn(ctx, r.db).Model(&revoked).Clauses(clause.Returning{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", at).Error
+	return revoked, err
+}
+
+func (r *tokenRepository) DenyAccessTokens(ctx context.Context, tokens []user.RevokedToken) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	return conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(&tokens).Error
+}
+
+func (r *tokenRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&user.RevokedToken{}).Where("id = ?", jti).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *tokenRepository) DeleteExpiredTokens(ctx context.Context, now time.Time) (int64, error) {
+	var deleted int64
+	err := withinTransaction(ctx, r.db, func(ctx context.Context) error {
+		res := conn(ctx, r.db).Where("expires_at < ?", now).Delete(&user.RefreshToken{})
+		if res.Error != nil {
+			return res.Error
+		}
+		deleted = res.RowsAffected
+		res = conn(ctx, r.db).Where("expires_at < ?", now).Delete(&user.RevokedToken{})
+		deleted += res.RowsAffected
+		return res.Error
+	})
+	return deleted, err
+}
@@ -32,3 +32,25 @@ func (r *userRepository) GetUserByUsername(ctx context.Context, username string)
 	}
 	return &u, nil
 }
+
+func (r *userRepository) GetUserByID(ctx context.Context, id int) (*user.User, error) {
+	var u user.User
+	if err := r.db.WithContext(ctx).First(&u, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (r *userRepository) UpdateUserRole(ctx context.Context, id int, role user.Role) error {
+	res := r.db.WithContext(ctx).Model(&user.User{}).Where("id = ?", id).Update("role", role)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return user.ErrUserNotFound
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/domain/warehouse"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type warehouseRepository struct {
+	db *gorm.DB
+}
+
+func NewWarehouseRepository(db *gorm.DB) out.WarehouseRepository {
+	return &warehouseRepository{db: db}
+}
+
+func (r *warehouseRepository) CreateWarehouse(ctx context.Context, w *warehouse.Warehouse) error {
+	return conn(ctx, r.db).Create(w).Error
+}
+
+func (r *warehouseRepository) GetWarehouse(ctx context.Context, id int) (*warehouse.Warehouse, error) {
+	return r.findWarehouse(conn(ctx, r.db).Where("id = ?", id))
+}
+
+func (r *warehouseRepository) GetDefaultWarehouse(ctx context.Context) (*warehouse.Warehouse, error) {
+	w, err := r.findWarehouse(conn(ctx, r.db).Where("is_default"))
+	if err == nil && w == nil {
+		return nil, errors.New("default warehouse not found, run the migrations")
+	}
+	return w, err
+}
+
+func (r *warehouseRepository) findWarehouse(db *gorm.DB) (*warehouse.Warehouse, error) {
+	var w warehouse.Warehouse
+	if err := db.Take(&w).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &w, nil
+}
+
+func (r *warehouseRepository) ListWarehouses(ctx context.Context) ([]warehouse.Warehouse, error) {
+	warehouses := []warehouse.Warehouse{}
+	err := conn(ctx, r.db).Order("id").Find(&warehouses).Error
+	return warehouses, err
+}
+
+func (r *warehouseRepository) UpdateWarehouse(ctx context.Context, w *warehouse.Warehouse) error {
+	return conn(ctx, r.db).Model(w).Select("name", "address", "updated_at").Updates(w).Error
+}
+
+func (r *warehouseRepository) DeleteWarehouse(ctx context.Context, id int) error {
+	return withinTransaction(ctx, r.db, func(ctx context.Context) error {
+		var stocked int64
+		err := conn(ctx, r.db).Model(&item.StockLevel{}).
+			Where("warehouse_id = ? AND stock > 0", id).
+			Count(&stocked).Error
+		if err != nil {
+			return err
+		}
+		if stocked > 0 {
+			return warehouse.ErrWarehouseNotEmpty
+		}
+		if err := conn(ctx, r.db).Where("warehouse_id = ?", id).Delete(&item.StockLevel{}).Error; err != nil {
+			return err
+		}
+		return conn(ctx, r.db).Delete(&warehouse.Warehouse{}, id).Error
+	})
+}
+
+func (r *warehouseRepository) WarehouseExistsByCode(ctx context.Context, code string) (bool, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&warehouse.Warehouse{}).Where("code = ?", code).Count(&count).Error
+	return count > 0, err
+}
+
+// AdjustStockLevel increments the stock with an upsert, and makes every other
+// change with a guarded update, so concurrent movements and reservations
+// cannot take the level below its reserved stock.
+func (r *warehouseRepository) AdjustStockLevel(
+	ctx context.Context, itemID, warehouseID, stockDelta, reservedDelta int,
+) (*item.StockLevel, error) {
+	now := time.Now()
+	if stockDelta >= 0 && reservedDelta == 0 {
+		level := item.StockLevel{ItemID: itemID, WarehouseID: warehouseID, Stock: stockDelta, UpdatedAt: now}
+		err := conn(ctx, r.db).Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "item_id"}, {Name: "warehouse_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"stock":      gorm.Expr("item_stock_levels.stock + excluded.stock"),
+				"updated_at": now,
+			}),
+		}).Create(&level).Error
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		result := conn(ctx, r.db).Model(&item.StockLevel{}).
+			Where("item_id = ? AND warehouse_id = ?", itemID, warehouseID).
+			Where("stock + ? >= reserved + ? AND reserved + ? >= 0", stockDelta, reservedDelta, reservedDelta).
+			Updates(map[string]interface{}{
+				"stock":      gorm.Expr("stock + ?", stockDelta),
+				"reserved":   gorm.Expr("reserved + ?", reservedDelta),
+				"updated_at": now,
+			})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil, item.ErrInsufficientStock
+		}
+	}
+
+	var level item.StockLevel
+	err := conn(ctx, r.db).Take(&level, "item_id = ? AND warehouse_id = ?", itemID, warehouseID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &level, nil
+}
+
+func (r *warehouseRepository) ListStockLevels(ctx context.Context, itemID int) ([]item.StockLevel, error) {
+	levels := []item.StockLevel{}
+	err := conn(ctx, r.db).Where("item_id = ?", itemID).Order("warehouse_id").Find(&levels).Error
+	return levels, err
+}
@@ -0,0 +1,52 @@
+package storage_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/adapters/storage"
+)
+
+func TestLocalStorage(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "exports")
+	s, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+
+	w, err := s.Create("job.csv")
+	require.NoError(t, err)
+	_, err = io.WriteString(w, "ID,Code\n")
+	require.NoError(t, err)
+
+	_, err = s.Open("job.csv")
+	assert.True(t, os.IsNotExist(err), "the file must not be visible before Close")
+
+	require.NoError(t, w.Close())
+	r, err := s.Open("job.csv")
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, "ID,Code\n", string(content))
+
+	require.NoError(t, s.Remove("job.csv"))
+	require.NoError(t, s.Remove("job.csv"))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestLocalStorage_RejectsPaths(t *testing.T) {
+	s, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	for _, name := range []string{"", "..", "../job.csv", "a/job.csv"} {
+		_, err := s.Create(name)
+		assert.Error(t, err, name)
+		_, err = s.Open(name)
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,129 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+	"github.com/teamcubation/go-items-challenge/pkg/log"
+)
+
+const (
+	apiKeyPrefix = "gic_"
+	// apiKeyShownChars of a key are kept in clear to tell the keys apart.
+	apiKeyShownChars = len(apiKeyPrefix) + 8
+	// apiKeyTouchInterval limits how often the last use of a key is written.
+	apiKeyTouchInterval = time.Minute
+)
+
+var errAPIKeysDisabled = errors.New("API keys are not enabled")
+
+// WithAPIKeys lets the users create API keys, stored in keys.
+func WithAPIKeys(keys out.APIKeyRepository) AuthServiceOption {
+	return func(srv *authService) {
+		srv.apiKeys = keys
+	}
+}
+
+// CreateAPIKey creates a key for the authenticated user. The scopes must be
+// granted by the role of the user. Requests made with an API key cannot create
+// keys, since the new key would survive the revocation of its parent.
+func (srv *authService) CreateAPIKey(ctx context.Context, name string, scopes []user.Permission, expiresAt *time.Time) (*user.CreatedAPIKey, error) {
+	if srv.apiKeys == nil {
+		return nil, errAPIKeysDisabled
+	}
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", user.ErrInvalidScope)
+	}
+	if user.ScopesFromContext(ctx) != nil {
+		return nil, user.ErrAPIKeyCaller
+	}
+	role := user.RoleFromContext(ctx)
+	for _, scope := range scopes {
+		if !role.Can(scope) {
+			return nil, fmt.Errorf("%w: %s is not granted to the caller", user.ErrInvalidScope, scope)
+		}
+	}
+	now := time.Now()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, user.ErrInvalidExpiry
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(raw)
+	userID := user.UserIDFromContext(ctx)
+	created := &user.CreatedAPIKey{
+		APIKey: user.APIKey{
+			ID:        uuid.New().String(),
+			UserID:    userID,
+			Name:      name,
+			Prefix:    key[:apiKeyShownChars],
+			KeyHash:   hashToken(key),
+			Scopes:    scopes,
+			ExpiresAt: expiresAt,
+			CreatedAt: now,
+		},
+		Key: key,
+	}
+	if err := srv.apiKeys.CreateAPIKey(ctx, &created.APIKey); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// ListAPIKeys returns the keys of the authenticated user that are not revoked.
+func (srv *authService) ListAPIKeys(ctx context.Context) ([]user.APIKey, error) {
+	if srv.apiKeys == nil {
+		return nil, errAPIKeysDisabled
+	}
+	userID := user.UserIDFromContext(ctx)
+	return srv.apiKeys.ListAPIKeys(ctx, userID)
+}
+
+// RevokeAPIKey revokes a key of the authenticated user.
+func (srv *authService) RevokeAPIKey(ctx context.Context, id string) error {
+	if srv.apiKeys == nil {
+		return errAPIKeysDisabled
+	}
+	userID := user.UserIDFromContext(ctx)
+	return srv.apiKeys.RevokeAPIKey(ctx, userID, id, time.Now())
+}
+
+// AuthenticateAPIKey returns who the key acts as. The role is the current role
+// of the user, so demoting a user also narrows their keys.
+func (srv *authService) AuthenticateAPIKey(ctx context.Context, key string) (*user.Principal, error) {
+	if srv.apiKeys == nil {
+		return nil, user.ErrInvalidAPIKey
+	}
+	apiKey, err := srv.apiKeys.GetAPIKeyByHash(ctx, hashToken(key))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if apiKey == nil || !apiKey.Active(now) {
+		return nil, user.ErrInvalidAPIKey
+	}
+	u, err := srv.repo.GetUserByID(ctx, apiKey.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, user.ErrInvalidAPIKey
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyTouchInterval {
+		if err := srv.apiKeys.TouchAPIKey(ctx, apiKey.ID, now); err != nil {
+			log.GetFromContext(ctx).Warnf("Error recording the use of API key %s: %v", apiKey.ID, err)
+		}
+	}
+	return &user.Principal{UserID: u.ID, Role: u.Role, Scopes: apiKey.Scopes}, nil
+}
@@ -0,0 +1,92 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out/mocks"
+)
+
+func TestAuthService_CreateAPIKey(t *testing.T) {
+	mockKeys := new(mocks.APIKeyRepository)
+	srv := NewAuthService(new(mocks.UserRepository), accessTokenSigner(), WithAPIKeys(mockKeys))
+
+	var stored *user.APIKey
+	mockKeys.On("CreateAPIKey", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*user.APIKey)
+	}).Return(nil)
+
+	ctx := roleContext(4, user.RoleEditor)
+	created, err := srv.CreateAPIKey(ctx, "nightly", []user.Permission{user.PermItemsRead, user.PermItemsWrite}, nil)
+	require.NoError(t, err)
+
+	require.NotNil(t, stored)
+	assert.Equal(t, 4, stored.UserID)
+	assert.True(t, strings.HasPrefix(created.Key, stored.Prefix))
+	assert.Equal(t, hashToken(created.Key), stored.KeyHash)
+	assert.NotContains(t, stored.KeyHash, created.Key)
+
+	_, err = srv.CreateAPIKey(ctx, "too-much", []user.Permission{user.PermWarehousesWrite}, nil)
+	assert.ErrorIs(t, err, user.ErrInvalidScope)
+
+	past := time.Now().Add(-time.Hour)
+	_, err = srv.CreateAPIKey(ctx, "expired", []user.Permission{user.PermItemsRead}, &past)
+	assert.ErrorIs(t, err, user.ErrInvalidExpiry)
+
+	// a key cannot create keys, even with fewer scopes than its own
+	keyCtx := user.WithPrincipal(ctx, &user.Principal{
+		UserID: 4, Role: user.RoleEditor, Scopes: []user.Permission{user.PermItemsRead, user.PermAPIKeysManage},
+	})
+	_, err = srv.CreateAPIKey(keyCtx, "child", []user.Permission{user.PermItemsRead}, nil)
+	assert.ErrorIs(t, err, user.ErrAPIKeyCaller)
+	mockKeys.AssertNumberOfCalls(t, "CreateAPIKey", 1)
+}
+
+func TestAuthService_AuthenticateAPIKey(t *testing.T) {
+	mockUsers := new(mocks.UserRepository)
+	mockKeys := new(mocks.APIKeyRepository)
+	srv := NewAuthService(mockUsers, accessTokenSigner(), WithAPIKeys(mockKeys))
+
+	expired := time.Now().Add(-time.Minute)
+	recent := time.Now().Add(-time.Second)
+	scopes := []user.Permission{user.PermItemsRead}
+	mockKeys.On("GetAPIKeyByHash", mock.Anything, hashToken("live")).Return(&user.APIKey{ID: "k1", UserID: 4, Scopes: scopes}, nil)
+	mockKeys.On("GetAPIKeyByHash", mock.Anything, hashToken("recent")).Return(&user.APIKey{ID: "k2", UserID: 4, Scopes: scopes, LastUsedAt: &recent}, nil)
+	mockKeys.On("GetAPIKeyByHash", mock.Anything, hashToken("expired")).Return(&user.APIKey{ID: "k3", UserID: 4, ExpiresAt: &expired}, nil)
+	mockKeys.On("GetAPIKeyByHash", mock.Anything, hashToken("unknown")).Return(nil, nil)
+	mockKeys.On("TouchAPIKey", mock.Anything, "k1", mock.Anything).Return(nil)
+	mockUsers.On("GetUserByID", mock.Anything, 4).Return(&user.User{ID: 4, Role: user.RoleViewer}, nil)
+
+	principal, err := srv.AuthenticateAPIKey(context.Background(), "live")
+	require.NoError(t, err)
+	assert.Equal(t, &user.Principal{UserID: 4, Role: user.RoleViewer, Scopes: scopes}, principal)
+
+	// the last use is not written again within a minute
+	_, err = srv.AuthenticateAPIKey(context.Background(), "recent")
+	require.NoError(t, err)
+	mockKeys.AssertNumberOfCalls(t, "TouchAPIKey", 1)
+
+	for _, key := range []string{"expired", "unknown"} {
+		_, err := srv.AuthenticateAPIKey(context.Background(), key)
+		assert.ErrorIs(t, err, user.ErrInvalidAPIKey, key)
+	}
+}
+
+func TestAuthService_RevokeAPIKey(t *testing.T) {
+	mockKeys := new(mocks.APIKeyRepository)
+	srv := NewAuthService(new(mocks.UserRepository), accessTokenSigner(), WithAPIKeys(mockKeys))
+
+	mockKeys.On("RevokeAPIKey", mock.Anything, 4, "k1", mock.Anything).Return(nil)
+	mockKeys.On("RevokeAPIKey", mock.Anything, 4, "other", mock.Anything).Return(user.ErrAPIKeyNotFound)
+
+	ctx := roleContext(4, user.RoleViewer)
+	require.NoError(t, srv.RevokeAPIKey(ctx, "k1"))
+	assert.ErrorIs(t, srv.RevokeAPIKey(ctx, "other"), user.ErrAPIKeyNotFound)
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/google/uuid"
 
 	"golang.org/x/crypto/bcrypt"
 
@@ -15,14 +18,29 @@ import (
 var (
 	ErrUsernameExists   = fmt.Errorf("username already exists")
 	ErrUsernameNotFound = fmt.Errorf("username not found")
+	// ErrOwnRole keeps admins from demoting themselves, which could leave no
+	// admin at all.
+	ErrOwnRole = fmt.Errorf("cannot change your own role")
 )
 
 type authService struct {
-	repo out.UserRepository
+	repo   out.UserRepository
+	signer out.AccessTokenSigner
+
+	tokens     out.TokenRepository
+	refreshTTL time.Duration
+
+	apiKeys out.APIKeyRepository
 }
 
-func NewAuthService(repo out.UserRepository) *authService {
-	return &authService{repo: repo}
+type AuthServiceOption func(*authService)
+
+func NewAuthService(repo out.UserRepository, signer out.AccessTokenSigner, opts ...AuthServiceOption) *authService {
+	srv := &authService{repo: repo, signer: signer}
+	for _, opt := range opts {
+		opt(srv)
+	}
+	return srv
 }
 
 func (srv *authService) RegisterUser(ctx context.Context, newUser *user.User) (*user.User, error) {
@@ -42,6 +60,8 @@ func (srv *authService) RegisterUser(ctx context.Context, newUser *user.User) (*
 
 	newUser.Username = strings.ToUpper(string(newUser.Username[0])) + strings.ToLower(newUser.Username[1:])
 	newUser.Password = string(hashedPassword)
+	// roles are granted by admins, never on registration
+	newUser.Role = user.RoleViewer
 
 	if err := srv.repo.CreateUser(ctx, newUser); err != nil {
 		return nil, fmt.Errorf("error creating user: %w", err)
@@ -50,30 +70,54 @@ func (srv *authService) RegisterUser(ctx context.Context, newUser *user.User) (*
 	return newUser, nil
 }
 
-func (srv *authService) Login(ctx context.Context, creds user.Credentials) (string, error) {
+func (srv *authService) Login(ctx context.Context, creds user.Credentials) (*user.TokenPair, error) {
 	userFound, err := srv.repo.GetUserByUsername(ctx, creds.Username)
-
-	if userFound.Username != creds.Username {
-		return "", fmt.Errorf("invalid user: %s", creds.Username)
-	}
-
 	if err != nil {
-		return "", fmt.Errorf("error fetching user: %w", err)
+		return nil, fmt.Errorf("error fetching user: %w", err)
 	}
 
 	if userFound == nil {
-		return "", ErrUsernameNotFound
+		return nil, ErrUsernameNotFound
+	}
+
+	if userFound.Username != creds.Username {
+		return nil, fmt.Errorf("invalid user: %s", creds.Username)
 	}
 
 	if !utils.CheckPasswordHash(creds.Password, userFound.Password) {
-		return "", fmt.Errorf("invalid password: %s", creds.Username)
+		return nil, fmt.Errorf("invalid password: %s", creds.Username)
 	}
 
-	// Generate token (assuming you have a function to generate JWT tokens)
-	token, err := utils.GenerateToken(userFound.ID)
+	// every login starts a new family of refresh tokens
+	pair, err := srv.issueTokens(ctx, userFound, uuid.New().String())
 	if err != nil {
-		return "", fmt.Errorf("failed to generate token: %s", creds.Username)
+		return nil, fmt.Errorf("failed to generate token: %s: %w", creds.Username, err)
 	}
+	return pair, nil
+}
 
-	return token, nil
+// BootstrapAdmin makes the user named username an admin, so that a new
+// deployment has someone to grant the other roles. It returns
+// user.ErrUserNotFound when the user has not registered yet.
+func (srv *authService) BootstrapAdmin(ctx context.Context, username string) error {
+	userFound, err := srv.repo.GetUserByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("error fetching user: %w", err)
+	}
+	if userFound == nil {
+		return fmt.Errorf("%w: %s", user.ErrUserNotFound, username)
+	}
+	if userFound.Role == user.RoleAdmin {
+		return nil
+	}
+	return srv.repo.UpdateUserRole(ctx, userFound.ID, user.RoleAdmin)
+}
+
+// SetUserRole changes the role of a user. It applies to the access tokens
+// issued from then on, including those of the next refresh.
+func (srv *authService) SetUserRole(ctx context.Context, id int, role user.Role) error {
+	if user.UserIDFromContext(ctx) == id {
+		return ErrOwnRole
+	}
+	return srv.repo.UpdateUserRole(ctx, id, role)
 }
@@ -0,0 +1,40 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out/mocks"
+)
+
+func TestAuthService_BootstrapAdmin(t *testing.T) {
+	mockUsers := new(mocks.UserRepository)
+	srv := NewAuthService(mockUsers, accessTokenSigner())
+
+	mockUsers.On("GetUserByUsername", mock.Anything, "alice").Return(&user.User{ID: 3, Username: "Alice", Role: user.RoleViewer}, nil)
+	mockUsers.On("GetUserByUsername", mock.Anything, "root").Return(&user.User{ID: 1, Username: "Root", Role: user.RoleAdmin}, nil)
+	mockUsers.On("GetUserByUsername", mock.Anything, "bob").Return(nil, nil)
+	mockUsers.On("UpdateUserRole", mock.Anything, 3, user.RoleAdmin).Return(nil)
+
+	require.NoError(t, srv.BootstrapAdmin(context.Background(), "alice"))
+	require.NoError(t, srv.BootstrapAdmin(context.Background(), "root"))
+	assert.ErrorIs(t, srv.BootstrapAdmin(context.Background(), "bob"), user.ErrUserNotFound)
+	mockUsers.AssertNumberOfCalls(t, "UpdateUserRole", 1)
+}
+
+func TestAuthService_SetUserRole(t *testing.T) {
+	mockUsers := new(mocks.UserRepository)
+	srv := NewAuthService(mockUsers, accessTokenSigner())
+
+	mockUsers.On("UpdateUserRole", mock.Anything, 2, user.RoleEditor).Return(nil)
+
+	ctx := user.WithPrincipal(context.Background(), &user.Principal{UserID: 1})
+	require.NoError(t, srv.SetUserRole(ctx, 2, user.RoleEditor))
+	assert.ErrorIs(t, srv.SetUserRole(ctx, 1, user.RoleViewer), ErrOwnRole)
+	mockUsers.AssertNumberOfCalls(t, "UpdateUserRole", 1)
+}
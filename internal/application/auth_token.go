@@ -0,0 +1,180 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+	"github.com/teamcubation/go-items-challenge/pkg/log"
+)
+
+// DefaultRefreshTokenTTL is the lifetime of a refresh token; every refresh
+// hands out a new one.
+const DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+var errRefreshTokensDisabled = errors.New("refresh tokens are not enabled")
+
+// WithRefreshTokens hands out refresh tokens valid for ttl at login, stored in
+// tokens, which also holds the denylist of revoked access tokens.
+func WithRefreshTokens(tokens out.TokenRepository, ttl time.Duration) AuthServiceOption {
+	return func(srv *authService) {
+		srv.tokens = tokens
+		srv.refreshTTL = ttl
+	}
+}
+
+// issueTokens signs an access token for u and, with refresh tokens enabled,
+// stores a new refresh token of the family.
+func (srv *authService) issueTokens(ctx context.Context, u *user.User, familyID string) (*user.TokenPair, error) {
+	jti := uuid.New().String()
+	accessToken, expiresAt, err := srv.signer.SignAccessToken(u.ID, u.Role, jti)
+	if err != nil {
+		return nil, err
+	}
+	pair := &user.TokenPair{AccessToken: accessToken, ExpiresIn: int(time.Until(expiresAt).Round(time.Second).Seconds())}
+	if srv.tokens == nil {
+		return pair, nil
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	pair.RefreshToken = base64.RawURLEncoding.EncodeToString(raw)
+	now := time.Now()
+	err = srv.tokens.CreateRefreshToken(ctx, &user.RefreshToken{
+		ID:                   uuid.New().String(),
+		FamilyID:             familyID,
+		UserID:               u.ID,
+		TokenHash:            hashToken(pair.RefreshToken),
+		ExpiresAt:            now.Add(srv.refreshTTL),
+		CreatedAt:            now,
+		AccessTokenID:        jti,
+		AccessTokenExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pair, nil
+}
+
+// hashToken hashes refresh tokens and API keys. Both are random enough that a
+// fast hash does not make them guessable.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh
+// token. A refresh token that was already exchanged revokes its whole family,
+// since either its owner or a thief holds the newer one.
+func (srv *authService) Refresh(ctx context.Context, refreshToken string) (*user.TokenPair, error) {
+	if srv.tokens == nil {
+		return nil, errRefreshTokensDisabled
+	}
+
+	var pair *user.TokenPair
+	reused := false
+	err := srv.tokens.WithinTransaction(ctx, func(ctx context.Context) error {
+		token, err := srv.tokens.GetRefreshTokenByHash(ctx, hashToken(refreshToken))
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		switch {
+		case token == nil || token.RevokedAt != nil || !now.Before(token.ExpiresAt):
+			return user.ErrInvalidRefreshToken
+		case token.RotatedAt != nil:
+			// the revocation must be committed, so it is not returned as an error
+			reused = true
+			return srv.revokeFamily(ctx, token.FamilyID, now)
+		}
+
+		u, err := srv.repo.GetUserByID(ctx, token.UserID)
+		if err != nil {
+			return err
+		}
+		if u == nil {
+			return user.ErrInvalidRefreshToken
+		}
+		if err := srv.tokens.MarkRefreshTokenRotated(ctx, token.ID, now); err != nil {
+			return err
+		}
+		pair, err = srv.issueTokens(ctx, u, token.FamilyID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		log.GetFromContext(ctx).Warnf("Refresh token reused, its family was revoked")
+		return nil, user.ErrRefreshTokenReused
+	}
+	return pair, nil
+}
+
+// Logout revokes the family of the refresh token, with the access tokens
+// issued along with it. Logging out twice is not an error.
+func (srv *authService) Logout(ctx context.Context, refreshToken string) error {
+	if srv.tokens == nil {
+		return errRefreshTokensDisabled
+	}
+	return srv.tokens.WithinTransaction(ctx, func(ctx context.Context) error {
+		token, err := srv.tokens.GetRefreshTokenByHash(ctx, hashToken(refreshToken))
+		if err != nil {
+			return err
+		}
+		if token == nil {
+			return user.ErrInvalidRefreshToken
+		}
+		return srv.revokeFamily(ctx, token.FamilyID, time.Now())
+	})
+}
+
+// revokeFamily revokes the refresh tokens of the family and denies the access
+// tokens issued with them that have not expired yet.
+func (srv *authService) revokeFamily(ctx context.Context, familyID string, now time.Time) error {
+	revoked, err := srv.tokens.RevokeRefreshTokenFamily(ctx, familyID, now)
+	if err != nil {
+		return err
+	}
+	var denied []user.RevokedToken
+	for _, token := range revoked {
+		if token.AccessTokenID != "" && token.AccessTokenExpiresAt.After(now) {
+			denied = append(denied, user.RevokedToken{ID: token.AccessTokenID, ExpiresAt: token.AccessTokenExpiresAt})
+		}
+	}
+	return srv.tokens.DenyAccessTokens(ctx, denied)
+}
+
+// RunTokenCleanup deletes, every interval, the refresh tokens and denied access
+// tokens that expired, until ctx is done.
+func (srv *authService) RunTokenCleanup(ctx context.Context, interval time.Duration) {
+	if srv.tokens == nil {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	logger := log.GetFromContext(ctx)
+	for {
+		deleted, err := srv.tokens.DeleteExpiredTokens(ctx, time.Now())
+		if err != nil && ctx.Err() == nil {
+			logger.Errorf("Error deleting expired tokens: %v", err)
+		} else if deleted > 0 {
+			logger.Infof("Deleted %d expired tokens", deleted)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out/mocks"
+)
+
+func accessTokenSigner() *mocks.AccessTokenSigner {
+	signer := new(mocks.AccessTokenSigner)
+	signer.On("SignAccessToken", mock.Anything, mock.Anything, mock.Anything).Return("access-token", time.Now().Add(time.Hour), nil)
+	return signer
+}
+
+func TestAuthService_Login_IssuesRefreshToken(t *testing.T) {
+	mockUsers := new(mocks.UserRepository)
+	mockTokens := new(mocks.TokenRepository)
+	srv := NewAuthService(mockUsers, accessTokenSigner(), WithRefreshTokens(mockTokens, time.Hour))
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	mockUsers.On("GetUserByUsername", mock.Anything, "Alice").Return(&user.User{ID: 3, Username: "Alice", Password: string(hash)}, nil)
+	var stored *user.RefreshToken
+	mockTokens.On("CreateRefreshToken", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*user.RefreshToken)
+	}).Return(nil)
+
+	pair, err := srv.Login(context.Background(), user.Credentials{Username: "Alice", Password: "password123"})
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, pair.AccessToken)
+	assert.Equal(t, 3600, pair.ExpiresIn)
+	require.NotNil(t, stored)
+	assert.Equal(t, 3, stored.UserID)
+	assert.Equal(t, hashToken(pair.RefreshToken), stored.TokenHash)
+	assert.NotContains(t, stored.TokenHash, pair.RefreshToken)
+	assert.NotEmpty(t, stored.AccessTokenID)
+}
+
+func TestAuthService_Refresh_RotatesToken(t *testing.T) {
+	mockUsers := new(mocks.UserRepository)
+	mockTokens := new(mocks.TokenRepository)
+	srv := NewAuthService(mockUsers, accessTokenSigner(), WithRefreshTokens(mockTokens, time.Hour))
+
+	current := &user.RefreshToken{ID: "rt-1", FamilyID: "fam", UserID: 3, ExpiresAt: time.Now().Add(time.Hour)}
+	mockTokens.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockTokens.On("GetRefreshTokenByHash", mock.Anything, hashToken("old")).Return(current, nil)
+	mockUsers.On("GetUserByID", mock.Anything, 3).Return(&user.User{ID: 3}, nil)
+	mockTokens.On("MarkRefreshTokenRotated", mock.Anything, "rt-1", mock.Anything).Return(nil)
+	mockTokens.On("CreateRefreshToken", mock.Anything, mock.MatchedBy(func(token *user.RefreshToken) bool {
+		return token.FamilyID == "fam" && token.UserID == 3
+	})).Return(nil)
+
+	pair, err := srv.Refresh(context.Background(), "old")
+	require.NoError(t, err)
+	assert.NotEqual(t, "old", pair.RefreshToken)
+	mockTokens.AssertExpectations(t)
+}
+
+func TestAuthService_Refresh_ReuseRevokesFamily(t *testing.T) {
+	mockTokens := new(mocks.TokenRepository)
+	srv := NewAuthService(new(mocks.UserRepository), accessTokenSigner(), WithRefreshTokens(mockTokens, time.Hour))
+
+	rotatedAt := time.Now().Add(-time.Minute)
+	accessExpiry := time.Now().Add(time.Hour)
+	mockTokens.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockTokens.On("GetRefreshTokenByHash", mock.Anything, hashToken("stolen")).Return(&user.RefreshToken{
+		ID: "rt-1", FamilyID: "fam", ExpiresAt: time.Now().Add(time.Hour), RotatedAt: &rotatedAt,
+	}, nil)
+	mockTokens.On("RevokeRefreshTokenFamily", mock.Anything, "fam", mock.Anything).Return([]user.RefreshToken{
+		{ID: "rt-2", AccessTokenID: "jti-live", AccessTokenExpiresAt: accessExpiry},
+		{ID: "rt-1", AccessTokenID: "jti-expired", AccessTokenExpiresAt: rotatedAt},
+	}, nil)
+	mockTokens.On("DenyAccessTokens", mock.Anything, []user.RevokedToken{{ID: "jti-live", ExpiresAt: accessExpiry}}).Return(nil)
+
+	_, err := srv.Refresh(context.Background(), "stolen")
+	assert.ErrorIs(t, err, user.ErrRefreshTokenReused)
+	mockTokens.AssertExpectations(t)
+	mockTokens.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Refresh_Invalid(t *testing.T) {
+	mockTokens := new(mocks.TokenRepository)
+	srv := NewAuthService(new(mocks.UserRepository), accessTokenSigner(), WithRefreshTokens(mockTokens, time.Hour))
+
+	revokedAt := time.Now()
+	mockTokens.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockTokens.On("GetRefreshTokenByHash", mock.Anything, hashToken("unknown")).Return(nil, nil)
+	mockTokens.On("GetRefreshTokenByHash", mock.Anything, hashToken("expired")).Return(&user.RefreshToken{
+		ID: "rt-1", ExpiresAt: time.Now().Add(-time.Second),
+	}, nil)
+	mockTokens.On("GetRefreshTokenByHash", mock.Anything, hashToken("revoked")).Return(&user.RefreshToken{
+		ID: "rt-2", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt,
+	}, nil)
+
+	for _, token := range []string{"unknown", "expired", "revoked"} {
+		_, err := srv.Refresh(context.Background(), token)
+		assert.ErrorIs(t, err, user.ErrInvalidRefreshToken, token)
+	}
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	mockTokens := new(mocks.TokenRepository)
+	srv := NewAuthService(new(mocks.UserRepository), accessTokenSigner(), WithRefreshTokens(mockTokens, time.Hour))
+
+	mockTokens.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockTokens.On("GetRefreshTokenByHash", mock.Anything, hashToken("rt")).Return(&user.RefreshToken{ID: "rt-1", FamilyID: "fam"}, nil)
+	mockTokens.On("RevokeRefreshTokenFamily", mock.Anything, "fam", mock.Anything).Return([]user.RefreshToken(nil), nil)
+	mockTokens.On("DenyAccessTokens", mock.Anything, []user.RevokedToken(nil)).Return(nil)
+
+	require.NoError(t, srv.Logout(context.Background(), "rt"))
+	mockTokens.AssertExpectations(t)
+}
@@ -0,0 +1,118 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+)
+
+const (
+	// exportProgressEvery is the number of items written between progress updates.
+	exportProgressEvery = 1000
+	defaultPollInterval = 5 * time.Second
+	// A running job is requeued once its heartbeat is older than
+	// defaultStaleAfter, which allows for a few missed heartbeats.
+	defaultHeartbeatInterval = 15 * time.Second
+	defaultStaleAfter        = 4 * defaultHeartbeatInterval
+)
+
+type exportJobService struct {
+	jobs         out.ExportJobRepository
+	items        out.ItemRepository
+	exporters    out.ItemExporters
+	storage      out.ExportStorage
+	pollInterval time.Duration
+	heartbeat    time.Duration
+	staleAfter   time.Duration
+	// wake lets a worker pick a new job without waiting for the next poll.
+	wake chan struct{}
+}
+
+func NewExportJobService(
+	jobs out.ExportJobRepository, items out.ItemRepository, exporters out.ItemExporters, storage out.ExportStorage,
+) *exportJobService {
+	return &exportJobService{
+		jobs:         jobs,
+		items:        items,
+		exporters:    exporters,
+		storage:      storage,
+		pollInterval: defaultPollInterval,
+		heartbeat:    defaultHeartbeatInterval,
+		staleAfter:   defaultStaleAfter,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// CreateExportJob validates the export and queues it for the workers. The job
+// belongs to the user in ctx.
+func (s *exportJobService) CreateExportJob(ctx context.Context, format string, query item.ListQuery) (*item.ExportJob, error) {
+	exporter, ok := s.exporters.Lookup(format)
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported format %q", item.ErrInvalidQuery, format)
+	}
+	if err := query.ValidateFilters(); err != nil {
+		return nil, err
+	}
+	// the workers have no caller, so mine is resolved now
+	if err := resolveOwner(ctx, &query); err != nil {
+		return nil, err
+	}
+
+	userID := user.UserIDFromContext(ctx)
+	id := uuid.NewString()
+	job := &item.ExportJob{
+		ID:          id,
+		Format:      strings.ToUpper(format),
+		Query:       query,
+		Status:      item.ExportJobPending,
+		FileName:    id + "." + exporter.FileExtension(),
+		ContentType: exporter.ContentType(),
+		CreatedBy:   userID,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.jobs.CreateExportJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+// GetExportJob returns a job of the user in ctx. Jobs of other users are
+// reported as not found.
+func (s *exportJobService) GetExportJob(ctx context.Context, id string) (*item.ExportJob, error) {
+	job, err := s.jobs.GetExportJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	userID := user.UserIDFromContext(ctx)
+	if job == nil || job.CreatedBy != userID {
+		return nil, item.ErrExportJobNotFound
+	}
+	return job, nil
+}
+
+func (s *exportJobService) OpenExportFile(ctx context.Context, id string) (*item.ExportJob, io.ReadSeekCloser, error) {
+	job, err := s.GetExportJob(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if job.Status != item.ExportJobCompleted {
+		return nil, nil, item.ErrExportNotReady
+	}
+	file, err := s.storage.Open(job.FileName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return job, file, nil
+}
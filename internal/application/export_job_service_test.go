@@ -0,0 +1,170 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/adapters/export"
+	"github.com/teamcubation/go-items-challenge/internal/adapters/storage"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out/mocks"
+)
+
+func newExportJobService(t *testing.T) (*exportJobService, *mocks.ExportJobRepository, *mocks.ItemRepository) {
+	t.Helper()
+	jobs := new(mocks.ExportJobRepository)
+	items := new(mocks.ItemRepository)
+	files, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	return NewExportJobService(jobs, items, export.DefaultRegistry(), files), jobs, items
+}
+
+func TestExportJobService_CreateExportJob(t *testing.T) {
+	service, jobs, _ := newExportJobService(t)
+	ctx := user.WithPrincipal(context.Background(), &user.Principal{UserID: 7})
+
+	jobs.On("CreateExportJob", mock.Anything, mock.AnythingOfType("*item.ExportJob")).Return(nil)
+
+	job, err := service.CreateExportJob(ctx, "xlsx", item.ListQuery{Status: "ACTIVE"})
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, job.ID)
+	assert.Equal(t, "XLSX", job.Format)
+	assert.Equal(t, item.ExportJobPending, job.Status)
+	assert.Equal(t, job.ID+".xlsx", job.FileName)
+	assert.Equal(t, 7, job.CreatedBy)
+	assert.Len(t, service.wake, 1, "a worker must be woken up")
+}
+
+func TestExportJobService_CreateExportJob_Invalid(t *testing.T) {
+	service, jobs, _ := newExportJobService(t)
+
+	_, err := service.CreateExportJob(context.Background(), "PDF", item.ListQuery{})
+	assert.ErrorIs(t, err, item.ErrInvalidQuery)
+
+	_, err = service.CreateExportJob(context.Background(), "CSV", item.ListQuery{Status: "SOLD"})
+	assert.ErrorIs(t, err, item.ErrInvalidQuery)
+
+	jobs.AssertNotCalled(t, "CreateExportJob", mock.Anything, mock.Anything)
+}
+
+func TestExportJobService_GetExportJob_OtherUser(t *testing.T) {
+	service, jobs, _ := newExportJobService(t)
+	ctx := user.WithPrincipal(context.Background(), &user.Principal{UserID: 7})
+
+	jobs.On("GetExportJob", mock.Anything, "job-1").Return(&item.ExportJob{ID: "job-1", CreatedBy: 8}, nil)
+	jobs.On("GetExportJob", mock.Anything, "job-2").Return(nil, nil)
+
+	_, err := service.GetExportJob(ctx, "job-1")
+	assert.ErrorIs(t, err, item.ErrExportJobNotFound)
+	_, err = service.GetExportJob(ctx, "job-2")
+	assert.ErrorIs(t, err, item.ErrExportJobNotFound)
+}
+
+func TestExportJobService_RunJob(t *testing.T) {
+	service, jobs, items := newExportJobService(t)
+	ctx := user.WithPrincipal(context.Background(), &user.Principal{UserID: 7})
+	job := &item.ExportJob{
+		ID: "job-1", Format: "CSV", Status: item.ExportJobRunning, FileName: "job-1.csv", ContentType: "text/csv", CreatedBy: 7,
+		ClaimedBy: "5f0c2a9e-claim",
+	}
+
+	exported := []item.Item{{ID: 1, Code: "A1"}, {ID: 2, Code: "A2"}}
+	items.On("CountItems", mock.Anything, job.Query).Return(2, nil)
+	items.On("IterateItems", mock.Anything, job.Query, mock.Anything).Return(func(_ context.Context, _ item.ListQuery, fn func(*item.Item) error) error {
+		for i := range exported {
+			if err := fn(&exported[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	jobs.On("UpdateExportJob", mock.Anything, job).Return(nil)
+	jobs.On("GetExportJob", mock.Anything, "job-1").Return(job, nil)
+
+	service.runJob(ctx, job)
+
+	assert.Equal(t, item.ExportJobCompleted, job.Status)
+	assert.Equal(t, "job-1-5f0c2a9e.csv", job.FileName, "every claim writes a file of its own")
+	assert.Equal(t, 2, job.Total)
+	assert.Equal(t, 2, job.Processed)
+	assert.NotNil(t, job.FinishedAt)
+	assert.Empty(t, job.Error)
+
+	_, file, err := service.OpenExportFile(ctx, "job-1")
+	require.NoError(t, err)
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "1,A1,")
+	assert.Contains(t, string(content), "2,A2,")
+	assert.Equal(t, int64(len(content)), job.Size)
+}
+
+func TestExportJobService_RunJob_Fails(t *testing.T) {
+	service, jobs, items := newExportJobService(t)
+	job := &item.ExportJob{ID: "job-1", Format: "CSV", Status: item.ExportJobRunning, FileName: "job-1.csv"}
+
+	items.On("CountItems", mock.Anything, job.Query).Return(10, nil)
+	items.On("IterateItems", mock.Anything, job.Query, mock.Anything).Return(errors.New("connection reset"))
+	jobs.On("UpdateExportJob", mock.Anything, job).Return(nil)
+
+	service.runJob(context.Background(), job)
+
+	assert.Equal(t, item.ExportJobFailed, job.Status)
+	assert.Equal(t, "connection reset", job.Error)
+	_, err := service.storage.Open(job.FileName)
+	assert.Error(t, err, "the partial file must be removed")
+}
+
+func TestExportJobService_RunJob_Interrupted(t *testing.T) {
+	service, jobs, items := newExportJobService(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &item.ExportJob{ID: "job-1", Format: "CSV", Status: item.ExportJobRunning, FileName: "job-1.csv"}
+
+	items.On("CountItems", mock.Anything, job.Query).Return(10, nil)
+	items.On("IterateItems", mock.Anything, job.Query, mock.Anything).Return(func(context.Context, item.ListQuery, func(*item.Item) error) error {
+		cancel()
+		return context.Canceled
+	})
+	jobs.On("UpdateExportJob", mock.Anything, job).Return(nil).Once()
+
+	service.runJob(ctx, job)
+
+	assert.Equal(t, item.ExportJobRunning, job.Status, "an interrupted job is requeued once its lease is stale")
+	jobs.AssertNumberOfCalls(t, "UpdateExportJob", 1)
+}
+
+func TestExportJobService_RunJob_LeaseLost(t *testing.T) {
+	service, jobs, items := newExportJobService(t)
+	job := &item.ExportJob{ID: "job-1", Format: "CSV", Status: item.ExportJobRunning, FileName: "job-1.csv", ClaimedBy: "stale-claim"}
+
+	items.On("CountItems", mock.Anything, job.Query).Return(10, nil)
+	jobs.On("UpdateExportJob", mock.Anything, job).Return(item.ErrExportJobLeaseLost).Once()
+
+	service.runJob(context.Background(), job)
+
+	assert.Equal(t, item.ExportJobRunning, job.Status, "the job belongs to its new worker")
+	jobs.AssertNumberOfCalls(t, "UpdateExportJob", 1)
+	items.AssertNotCalled(t, "IterateItems", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExportJobService_Beat_LeaseLost(t *testing.T) {
+	service, jobs, _ := newExportJobService(t)
+	service.heartbeat = time.Millisecond
+	job := &item.ExportJob{ID: "job-1", ClaimedBy: "claim"}
+
+	jobs.On("HeartbeatExportJob", mock.Anything, "job-1", "claim", mock.Anything).Return(nil).Once()
+	jobs.On("HeartbeatExportJob", mock.Anything, "job-1", "claim", mock.Anything).Return(item.ErrExportJobLeaseLost).Once()
+
+	err := service.beat(context.Background(), job)
+	assert.ErrorIs(t, err, item.ErrExportJobLeaseLost)
+	jobs.AssertNumberOfCalls(t, "HeartbeatExportJob", 2)
+}
@@ -0,0 +1,215 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/pkg/log"
+)
+
+// Run processes export jobs with the given number of workers until ctx is
+// done. Running jobs whose heartbeat went stale, as the process running them
+// died, are queued again; a job interrupted by ctx stops beating and is
+// queued again the same way.
+func (s *exportJobService) Run(ctx context.Context, workers int) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.requeueStale(ctx)
+	}()
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.work(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+func (s *exportJobService) requeueStale(ctx context.Context) {
+	ticker := time.NewTicker(s.staleAfter / 2)
+	defer ticker.Stop()
+
+	logger := log.GetFromContext(ctx)
+	for {
+		requeued, err := s.jobs.RequeueStaleExportJobs(ctx, time.Now().Add(-s.staleAfter))
+		if err != nil && ctx.Err() == nil {
+			logger.Errorf("Error requeueing export jobs: %v", err)
+		} else if requeued > 0 {
+			logger.Infof("Requeued %d interrupted export jobs", requeued)
+			select {
+			case s.wake <- struct{}{}:
+			default:
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *exportJobService) work(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// every claim is a new lease, even by the same process
+		job, err := s.jobs.ClaimExportJob(ctx, uuid.NewString())
+		if err != nil && ctx.Err() == nil {
+			log.GetFromContext(ctx).Errorf("Error claiming export job: %v", err)
+		}
+		if job != nil {
+			s.runJob(ctx, job)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *exportJobService) runJob(ctx context.Context, job *item.ExportJob) {
+	logger := log.GetFromContext(ctx).WithField("export_job", job.ID)
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	beating := make(chan struct{})
+	var leaseLost atomic.Bool
+	go func() {
+		defer close(beating)
+		if errors.Is(s.beat(jobCtx, job), item.ErrExportJobLeaseLost) {
+			leaseLost.Store(true)
+			cancel()
+		}
+	}()
+	err := s.writeExport(jobCtx, job)
+	cancel()
+	<-beating
+	if errors.Is(err, item.ErrExportJobLeaseLost) {
+		leaseLost.Store(true)
+	}
+
+	if err != nil {
+		if rmErr := s.storage.Remove(job.FileName); rmErr != nil {
+			logger.Errorf("Error removing export file: %v", rmErr)
+		}
+	}
+	if leaseLost.Load() {
+		logger.Warnf("Export job lease lost, leaving the job to its new worker")
+		return
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	finishedAt := time.Now()
+	job.FinishedAt = &finishedAt
+	job.Status = item.ExportJobCompleted
+	if err != nil {
+		logger.Errorf("Export job failed: %v", err)
+		job.Status = item.ExportJobFailed
+		job.Error = err.Error()
+		job.Size = 0
+	}
+	if err := s.jobs.UpdateExportJob(ctx, job); err != nil {
+		logger.Errorf("Error saving export job: %v", err)
+	}
+}
+
+// beat renews the lease of the job until ctx is done.
+func (s *exportJobService) beat(ctx context.Context, job *item.ExportJob) error {
+	ticker := time.NewTicker(s.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		err := s.jobs.HeartbeatExportJob(ctx, job.ID, job.ClaimedBy, time.Now())
+		if errors.Is(err, item.ErrExportJobLeaseLost) {
+			return err
+		}
+		if err != nil && ctx.Err() == nil {
+			log.GetFromContext(ctx).Errorf("Error renewing export job lease: %v", err)
+		}
+	}
+}
+
+// writeExport writes the items of the job to storage, saving the progress of
+// the job as it goes. A claimed job is written to a file of its own claim, so
+// a worker that lost the lease never overwrites the file of the next one.
+func (s *exportJobService) writeExport(ctx context.Context, job *item.ExportJob) (err error) {
+	exporter, ok := s.exporters.Lookup(job.Format)
+	if !ok {
+		return fmt.Errorf("unsupported format %q", job.Format)
+	}
+	if job.ClaimedBy != "" {
+		job.FileName = fmt.Sprintf("%s-%.8s.%s", job.ID, job.ClaimedBy, exporter.FileExtension())
+	}
+
+	if job.Total, err = s.items.CountItems(ctx, job.Query); err != nil {
+		return err
+	}
+	job.Processed = 0
+	if err := s.jobs.UpdateExportJob(ctx, job); err != nil {
+		return err
+	}
+
+	file, err := s.storage.Create(job.FileName)
+	if err != nil {
+		return err
+	}
+	counter := &countingWriter{w: file}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		job.Size = counter.n
+	}()
+
+	encoder, err := exporter.NewEncoder(counter)
+	if err != nil {
+		return err
+	}
+	err = s.items.IterateItems(ctx, job.Query, func(itm *item.Item) error {
+		if err := encoder.Encode(itm); err != nil {
+			return err
+		}
+		job.Processed++
+		if job.Processed%exportProgressEvery == 0 {
+			return s.jobs.UpdateExportJob(ctx, job)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,80 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+	"github.com/teamcubation/go-items-challenge/pkg/log"
+)
+
+// ItemServiceOption configures optional collaborators of the item service.
+type ItemServiceOption func(*itemService)
+
+// WithAuditLog records every change of an item in audit, in the same
+// transaction as the change. It also enables item snapshots and reverts.
+func WithAuditLog(audit out.AuditRepository) ItemServiceOption {
+	return func(s *itemService) {
+		s.audit = audit
+	}
+}
+
+// itemChange performs a write and returns the item before and after it.
+type itemChange func(ctx context.Context) (before, after *item.Item, err error)
+
+// recordChange runs change, updates the low stock flag of the item and stores
+// its audit entry in one transaction. Without an audit log or stock alerts the
+// change runs on its own.
+func (s *itemService) recordChange(ctx context.Context, action item.AuditAction, change itemChange) (*item.Item, error) {
+	if s.audit == nil && s.alerts == nil {
+		_, after, err := change(ctx)
+		return after, err
+	}
+
+	var result *item.Item
+	err := s.repo.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, after, err := change(ctx)
+		if err != nil {
+			return err
+		}
+		if err := s.trackLowStock(ctx, after); err != nil {
+			return err
+		}
+		if s.audit != nil {
+			actorID := user.UserIDFromContext(ctx)
+			entry := &item.AuditEntry{
+				ItemID:    after.ID,
+				Action:    action,
+				ActorID:   actorID,
+				RequestID: log.RequestID(ctx),
+				Version:   after.Version,
+				Changes:   item.Diff(before, after),
+				Snapshot:  after,
+				CreatedAt: time.Now(),
+			}
+			if err := s.audit.CreateAuditEntry(ctx, entry); err != nil {
+				return err
+			}
+		}
+		result = after
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ItemHistory lists the audit entries of an item, newest first.
+func (s *itemService) ItemHistory(ctx context.Context, query item.HistoryQuery) (*item.HistoryResponse, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	if s.audit == nil {
+		return nil, errors.New("item history is not enabled")
+	}
+	return s.audit.ListAuditEntries(ctx, query)
+}
@@ -0,0 +1,107 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out/mocks"
+	"github.com/teamcubation/go-items-challenge/pkg/log"
+)
+
+// auditContext returns a context with an authenticated user and a request ID,
+// as set up by the HTTP middlewares.
+func auditContext(userID int, requestID string) context.Context {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set(log.RequestIDKey, requestID)
+	return user.WithPrincipal(log.Context(r), &user.Principal{UserID: userID})
+}
+
+func TestItemService_UpdateItem_RecordsAuditEntry(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockAudit := new(mocks.AuditRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithAuditLog(mockAudit))
+
+	existing := &item.Item{ID: 1, Code: "A1", Title: "Old", Stock: 5, Status: "ACTIVE", Version: 3}
+	mockRepo.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockRepo.On("GetItemByID", mock.Anything, 1).Return(existing, nil)
+	mockRepo.On("UpdateItem", mock.Anything, mock.Anything).Return(func(_ context.Context, itm *item.Item) (*item.Item, error) {
+		updated := *itm
+		updated.Version++
+		return &updated, nil
+	})
+	mockAudit.On("CreateAuditEntry", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := service.UpdateItem(auditContext(7, "req-1"), &item.Item{ID: 1, Code: "A1", Title: "New", Stock: 5, Status: "ACTIVE"})
+	require.NoError(t, err)
+
+	entry := mockAudit.Calls[0].Arguments.Get(1).(*item.AuditEntry)
+	assert.Equal(t, 1, entry.ItemID)
+	assert.Equal(t, item.AuditUpdated, entry.Action)
+	assert.Equal(t, 7, entry.ActorID)
+	assert.Equal(t, "req-1", entry.RequestID)
+	assert.Equal(t, 4, entry.Version)
+	assert.Equal(t, []item.FieldChange{{Field: "title", Before: "Old", After: "New"}}, entry.Changes)
+	assert.False(t, entry.CreatedAt.IsZero())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestItemService_RestoreItem_RecordsDeletionUndone(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockAudit := new(mocks.AuditRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithAuditLog(mockAudit))
+
+	deleted := &item.Item{ID: 1, Code: "A1", DeletedBy: 7, Version: 2}
+	deleted.DeletedAt.Valid = true
+	mockRepo.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockRepo.On("FindItem", mock.Anything, 1, true).Return(deleted, nil)
+	mockRepo.On("RestoreItem", mock.Anything, 1, 0).Return(&item.Item{ID: 1, Code: "A1", Version: 3}, nil)
+	mockAudit.On("CreateAuditEntry", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := service.RestoreItem(auditContext(7, "req-2"), 1, 0)
+	require.NoError(t, err)
+
+	entry := mockAudit.Calls[0].Arguments.Get(1).(*item.AuditEntry)
+	assert.Equal(t, item.AuditRestored, entry.Action)
+	require.Len(t, entry.Changes, 2)
+	assert.Equal(t, "deleted_at", entry.Changes[0].Field)
+	assert.Nil(t, entry.Changes[0].After)
+	assert.Equal(t, item.FieldChange{Field: "deleted_by", Before: 7}, entry.Changes[1])
+}
+
+func TestItemService_AuditFailureFailsTheChange(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockAudit := new(mocks.AuditRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithAuditLog(mockAudit))
+
+	mockRepo.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockRepo.On("FindItem", mock.Anything, 1, false).Return(&item.Item{ID: 1, Version: 1}, nil)
+	mockRepo.On("DeleteItem", mock.Anything, 1, 1).Return(&item.Item{ID: 1, Version: 2}, nil)
+	mockAudit.On("CreateAuditEntry", mock.Anything, mock.Anything).Return(errors.New("connection refused"))
+
+	deleted, err := service.DeleteItem(auditContext(7, "req-3"), 1, 1)
+	assert.Error(t, err)
+	assert.Nil(t, deleted)
+}
+
+func TestItemService_ItemHistory(t *testing.T) {
+	mockAudit := new(mocks.AuditRepository)
+	service := NewItemService(new(mocks.ItemRepository), new(mocks.CategoryClient), WithAuditLog(mockAudit))
+
+	_, err := service.ItemHistory(context.Background(), item.HistoryQuery{ItemID: 1, Limit: 0, Page: 1})
+	assert.ErrorIs(t, err, item.ErrInvalidQuery)
+
+	query := item.HistoryQuery{ItemID: 1, Limit: 20, Page: 1}
+	expected := &item.HistoryResponse{TotalPages: 1, Data: []item.AuditEntry{{ID: 1, ItemID: 1}}}
+	mockAudit.On("ListAuditEntries", mock.Anything, query).Return(expected, nil)
+
+	history, err := service.ItemHistory(context.Background(), query)
+	require.NoError(t, err)
+	assert.Equal(t, expected, history)
+}
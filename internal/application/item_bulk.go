@@ -0,0 +1,133 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/utils"
+)
+
+var errBulkAborted = errors.New("bulk operation aborted")
+
+// bulkOp applies the entry at index and returns its result. A result with the
+// item.BulkFailed status aborts an atomic bulk operation.
+type bulkOp func(ctx context.Context, index int) item.BulkResult
+
+func (s *itemService) BulkCreateItems(ctx context.Context, items []*item.Item, mode item.BulkMode) (*item.BulkReport, error) {
+	categories := make(map[int]bool)
+	return s.runBulk(ctx, mode, len(items), func(ctx context.Context, i int) item.BulkResult {
+		itm := items[i]
+		if itm == nil {
+			return bulkFailure(i, "", errors.New("missing item"))
+		}
+		if err := utils.ValidateStruct(itm); err != nil {
+			return bulkFailure(i, itm.Code, fmt.Errorf("missing or invalid fields: %w", err))
+		}
+		created, err := s.createItem(ctx, itm, categories)
+		if err != nil {
+			return bulkFailure(i, itm.Code, err)
+		}
+		return item.BulkResult{Index: i, ID: created.ID, Code: created.Code, Status: item.BulkCreated}
+	})
+}
+
+// BulkUpdateItems replaces the items like UpdateItem. Every item must give the
+// version it is based on.
+func (s *itemService) BulkUpdateItems(ctx context.Context, items []*item.Item, mode item.BulkMode) (*item.BulkReport, error) {
+	return s.runBulk(ctx, mode, len(items), func(ctx context.Context, i int) item.BulkResult {
+		itm := items[i]
+		if itm == nil {
+			return bulkFailure(i, "", errors.New("missing item"))
+		}
+		if itm.ID <= 0 {
+			return bulkFailure(i, itm.Code, errors.New("invalid item ID"))
+		}
+		if itm.Version <= 0 {
+			return bulkFailure(i, itm.Code, item.ErrVersionRequired)
+		}
+		if err := utils.ValidateStruct(itm); err != nil {
+			return bulkFailure(i, itm.Code, fmt.Errorf("missing or invalid fields: %w", err))
+		}
+		updated, err := s.UpdateItem(ctx, itm)
+		if err != nil {
+			return bulkFailure(i, itm.Code, err)
+		}
+		return item.BulkResult{Index: i, ID: updated.ID, Code: updated.Code, Status: item.BulkUpdated}
+	})
+}
+
+// BulkDeleteItems deletes the items like DeleteItem. Every entry must give the
+// version it is based on.
+func (s *itemService) BulkDeleteItems(ctx context.Context, entries []item.BulkDeleteEntry, mode item.BulkMode) (*item.BulkReport, error) {
+	return s.runBulk(ctx, mode, len(entries), func(ctx context.Context, i int) item.BulkResult {
+		entry := entries[i]
+		fail := func(err error) item.BulkResult {
+			return item.BulkResult{Index: i, ID: entry.ID, Status: item.BulkFailed, Error: err.Error()}
+		}
+		if entry.ID <= 0 {
+			return fail(errors.New("invalid item ID"))
+		}
+		if entry.Version <= 0 {
+			return fail(item.ErrVersionRequired)
+		}
+		deleted, err := s.DeleteItem(ctx, entry.ID, entry.Version)
+		if err != nil {
+			return fail(err)
+		}
+		return item.BulkResult{Index: i, ID: deleted.ID, Code: deleted.Code, Status: item.BulkDeleted}
+	})
+}
+
+// runBulk applies op to n entries. In best effort mode every entry is applied
+// on its own; in atomic mode all entries share a transaction that is rolled
+// back on the first failure, and the report marks the entries applied before
+// it as rolled back and the ones after it as skipped.
+func (s *itemService) runBulk(ctx context.Context, mode item.BulkMode, n int, op bulkOp) (*item.BulkReport, error) {
+	report := &item.BulkReport{Mode: mode, Results: make([]item.BulkResult, n)}
+
+	if mode == item.BulkBestEffort {
+		for i := 0; i < n; i++ {
+			report.Results[i] = op(ctx, i)
+		}
+		report.Tally()
+		return report, nil
+	}
+
+	failedAt := -1
+	err := s.repo.WithinTransaction(ctx, func(ctx context.Context) error {
+		for i := 0; i < n; i++ {
+			report.Results[i] = op(ctx, i)
+			if report.Results[i].Status == item.BulkFailed {
+				failedAt = i
+				return errBulkAborted
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBulkAborted) {
+		return nil, err
+	}
+
+	if failedAt >= 0 {
+		for i := range report.Results {
+			switch {
+			case i < failedAt:
+				// IDs handed out to rolled back inserts do not exist
+				if report.Results[i].Status == item.BulkCreated {
+					report.Results[i].ID = 0
+				}
+				report.Results[i].Status = item.BulkRolledBack
+			case i > failedAt:
+				report.Results[i] = item.BulkResult{Index: i, Status: item.BulkSkipped}
+			}
+		}
+	}
+	report.Tally()
+	return report, nil
+}
+
+func bulkFailure(index int, code string, err error) item.BulkResult {
+	return item.BulkResult{Index: index, Code: code, Status: item.BulkFailed, Error: err.Error()}
+}
@@ -0,0 +1,117 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out/mocks"
+)
+
+func runInTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestItemService_BulkCreateItems_AtomicRollsBack(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockClient := new(mocks.CategoryClient)
+	service := NewItemService(mockRepo, mockClient)
+
+	items := []*item.Item{
+		{Code: "A1", CategoryID: 1, Stock: 1},
+		{Code: "A2", CategoryID: 1, Stock: 1},
+		{Code: "A3", CategoryID: 1, Stock: 1},
+	}
+
+	mockRepo.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockClient.On("IsAValidCategory", mock.Anything, 1).Return(true, nil).Once()
+	mockRepo.On("ItemExistsByCode", mock.Anything, "A1").Return(false)
+	mockRepo.On("ItemExistsByCode", mock.Anything, "A2").Return(true)
+	mockRepo.On("CreateItem", mock.Anything, items[0]).Return(&item.Item{ID: 10, Code: "A1"}, nil)
+
+	report, err := service.BulkCreateItems(context.Background(), items, item.BulkAtomic)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, report.Succeeded)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, item.BulkResult{Index: 0, Code: "A1", Status: item.BulkRolledBack}, report.Results[0])
+	assert.Equal(t, item.BulkFailed, report.Results[1].Status)
+	assert.Equal(t, item.BulkSkipped, report.Results[2].Status)
+	mockRepo.AssertNotCalled(t, "ItemExistsByCode", mock.Anything, "A3")
+	mockClient.AssertExpectations(t)
+}
+
+func TestItemService_BulkCreateItems_BestEffort(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockClient := new(mocks.CategoryClient)
+	service := NewItemService(mockRepo, mockClient)
+
+	items := []*item.Item{
+		{Code: "B1", CategoryID: 1},
+		{Code: "not valid!", CategoryID: 1},
+		{Code: "B3", CategoryID: 2},
+	}
+
+	mockClient.On("IsAValidCategory", mock.Anything, 1).Return(true, nil).Once()
+	mockClient.On("IsAValidCategory", mock.Anything, 2).Return(false, nil).Once()
+	mockRepo.On("ItemExistsByCode", mock.Anything, "B1").Return(false)
+	mockRepo.On("CreateItem", mock.Anything, items[0]).Return(&item.Item{ID: 11, Code: "B1"}, nil)
+
+	report, err := service.BulkCreateItems(context.Background(), items, item.BulkBestEffort)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, report.Succeeded)
+	assert.Equal(t, 2, report.Failed)
+	assert.Equal(t, item.BulkResult{Index: 0, ID: 11, Code: "B1", Status: item.BulkCreated}, report.Results[0])
+	assert.Contains(t, report.Results[1].Error, "missing or invalid fields")
+	assert.Equal(t, "invalid Category", report.Results[2].Error)
+	mockRepo.AssertNotCalled(t, "WithinTransaction", mock.Anything, mock.Anything)
+	mockClient.AssertExpectations(t)
+}
+
+func TestItemService_BulkDeleteItems_TransactionError(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient))
+
+	mockRepo.On("WithinTransaction", mock.Anything, mock.Anything).Return(errors.New("connection refused"))
+
+	report, err := service.BulkDeleteItems(context.Background(), []item.BulkDeleteEntry{{ID: 1, Version: 1}, {ID: 2, Version: 1}}, item.BulkAtomic)
+	assert.Error(t, err)
+	assert.Nil(t, report)
+}
+
+func TestItemService_BulkDeleteItems_RequiresVersion(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient))
+
+	mockRepo.On("DeleteItem", mock.Anything, 1, 3).Return(&item.Item{ID: 1, Code: "A1"}, nil)
+	mockRepo.On("DeleteItem", mock.Anything, 2, 1).Return(nil, item.ErrVersionConflict)
+
+	entries := []item.BulkDeleteEntry{{ID: 1, Version: 3}, {ID: 2, Version: 1}, {ID: 3}}
+	report, err := service.BulkDeleteItems(context.Background(), entries, item.BulkBestEffort)
+	require.NoError(t, err)
+
+	assert.Equal(t, item.BulkResult{Index: 0, ID: 1, Code: "A1", Status: item.BulkDeleted}, report.Results[0])
+	assert.Equal(t, item.ErrVersionConflict.Error(), report.Results[1].Error)
+	assert.Equal(t, item.BulkResult{Index: 2, ID: 3, Status: item.BulkFailed, Error: item.ErrVersionRequired.Error()}, report.Results[2])
+	mockRepo.AssertNumberOfCalls(t, "DeleteItem", 2)
+}
+
+func TestItemService_BulkUpdateItems_RequiresVersion(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient))
+
+	mockRepo.On("GetItemByID", mock.Anything, 1).Return(&item.Item{ID: 1, Code: "A1", CategoryID: 2, Version: 3}, nil)
+
+	items := []*item.Item{{ID: 1, Code: "A1", CategoryID: 2}, {ID: 1, Code: "A1", CategoryID: 2, Version: 2}}
+	report, err := service.BulkUpdateItems(context.Background(), items, item.BulkBestEffort)
+	require.NoError(t, err)
+
+	assert.Equal(t, item.ErrVersionRequired.Error(), report.Results[0].Error)
+	assert.Equal(t, item.ErrVersionConflict.Error(), report.Results[1].Error)
+	mockRepo.AssertNotCalled(t, "UpdateItem", mock.Anything, mock.Anything)
+}
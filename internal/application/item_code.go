@@ -0,0 +1,46 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+)
+
+// maxCodeAttempts bounds the numbers tried for a generated code that is
+// already taken, e.g. by a code given by a client.
+const maxCodeAttempts = 10
+
+// WithPublicIDs sets how the public IDs of new items are generated. Without
+// it they are UUIDs.
+func WithPublicIDs(strategy item.IDStrategy) ItemServiceOption {
+	return func(s *itemService) {
+		s.idStrategy = strategy
+	}
+}
+
+// WithCodeGenerator generates the codes of the items created without one from
+// pattern, numbered by sequences.
+func WithCodeGenerator(pattern *item.CodePattern, sequences out.CodeSequenceRepository) ItemServiceOption {
+	return func(s *itemService) {
+		s.codePattern = pattern
+		s.codeSequences = sequences
+	}
+}
+
+// generateCode returns the next free code of the pattern for the category.
+func (s *itemService) generateCode(ctx context.Context, categoryID int) (string, error) {
+	key := s.codePattern.SequenceKey(categoryID)
+	for attempt := 0; attempt < maxCodeAttempts; attempt++ {
+		seq, err := s.codeSequences.NextCodeSequence(ctx, key)
+		if err != nil {
+			return "", err
+		}
+		code := s.codePattern.Format(categoryID, seq)
+		if !s.repo.ItemExistsByCode(ctx, code) {
+			return code, nil
+		}
+	}
+	return "", fmt.Errorf("no free code found for sequence %s after %d attempts", key, maxCodeAttempts)
+}
@@ -0,0 +1,69 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out/mocks"
+)
+
+func TestItemService_CreateItem_GeneratesCode(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockClient := new(mocks.CategoryClient)
+	mockSequences := new(mocks.CodeSequenceRepository)
+	pattern, err := item.ParseCodePattern("IT{category:2}{seq:4}")
+	require.NoError(t, err)
+	service := NewItemService(mockRepo, mockClient,
+		WithPublicIDs(item.IDStrategyULID), WithCodeGenerator(pattern, mockSequences))
+
+	mockClient.On("IsAValidCategory", mock.Anything, 3).Return(true, nil)
+	mockSequences.On("NextCodeSequence", mock.Anything, "IT03").Return(int64(7), nil).Once()
+	mockSequences.On("NextCodeSequence", mock.Anything, "IT03").Return(int64(8), nil).Once()
+	// the first number was taken by a code given by a client
+	mockRepo.On("ItemExistsByCode", mock.Anything, "IT030007").Return(true)
+	mockRepo.On("ItemExistsByCode", mock.Anything, "IT030008").Return(false)
+	mockRepo.On("CreateItem", mock.Anything, mock.Anything).Return(func(_ context.Context, itm *item.Item) (*item.Item, error) {
+		itm.ID = 11
+		return itm, nil
+	})
+
+	created, err := service.CreateItem(context.Background(), &item.Item{ID: 99, CategoryID: 3})
+	require.NoError(t, err)
+	assert.Equal(t, "IT030008", created.Code)
+	assert.Equal(t, 11, created.ID)
+	assert.Len(t, created.PublicID, 26)
+	mockSequences.AssertExpectations(t)
+}
+
+func TestItemService_CreateItem_RequiresCodeWithoutGenerator(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient))
+
+	_, err := service.CreateItem(context.Background(), &item.Item{CategoryID: 3})
+	assert.ErrorIs(t, err, item.ErrInvalidItem)
+	mockRepo.AssertNotCalled(t, "CreateItem", mock.Anything, mock.Anything)
+}
+
+func TestItemService_CreateItem_AssignsPublicID(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockClient := new(mocks.CategoryClient)
+	service := NewItemService(mockRepo, mockClient)
+
+	mockClient.On("IsAValidCategory", mock.Anything, 1).Return(true, nil)
+	mockRepo.On("ItemExistsByCode", mock.Anything, "A1").Return(false)
+	mockRepo.On("ItemExistsByCode", mock.Anything, "B2").Return(true)
+	mockRepo.On("CreateItem", mock.Anything, mock.Anything).Return(func(_ context.Context, itm *item.Item) (*item.Item, error) {
+		return itm, nil
+	})
+
+	created, err := service.CreateItem(context.Background(), &item.Item{Code: "A1", CategoryID: 1, PublicID: "mine"})
+	require.NoError(t, err)
+	_, err = uuid.Parse(created.PublicID)
+	assert.NoError(t, err)
+	assert.True(t, service.ItemExistsByCode(context.Background(), "B2"))
+}
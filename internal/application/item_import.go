@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/utils"
+)
+
+// ImportItems upserts the decoded rows by item code. The stock of a row only
+// applies to the items it creates; that of existing items is left to the stock
+// ledger. Updates are unconditional, as the CSV layout has no version, unless
+// a row gives one, which must then match. With dryRun set every row is validated, including the category and
+// whether it would create or update an item, but nothing is written.
+func (s *itemService) ImportItems(ctx context.Context, rows []item.ImportRow, mode item.BulkMode, dryRun bool) (*item.BulkReport, error) {
+	categories := make(map[int]bool)
+	seen := make(map[string]int)
+
+	// a dry run never writes, so every row is checked independently
+	runMode := mode
+	if dryRun {
+		runMode = item.BulkBestEffort
+	}
+
+	report, err := s.runBulk(ctx, runMode, len(rows), func(ctx context.Context, i int) item.BulkResult {
+		res := s.importRow(ctx, rows[i], categories, seen, dryRun)
+		res.Index = i
+		res.Line = rows[i].Line
+		return res
+	})
+	if err != nil {
+		return nil, err
+	}
+	report.Mode = mode
+	report.DryRun = dryRun
+	return report, nil
+}
+
+func (s *itemService) importRow(
+	ctx context.Context, row item.ImportRow, categories map[int]bool, seen map[string]int, dryRun bool,
+) item.BulkResult {
+	if row.Error != "" {
+		return item.BulkResult{Status: item.BulkFailed, Error: row.Error}
+	}
+	itm := row.Item
+	fail := func(err error) item.BulkResult {
+		return item.BulkResult{Code: itm.Code, Status: item.BulkFailed, Error: err.Error()}
+	}
+
+	if err := utils.ValidateStruct(itm); err != nil {
+		return fail(fmt.Errorf("missing or invalid fields: %w", err))
+	}
+	// rows without a code are always created, with a generated one
+	var existing *item.Item
+	if itm.Code != "" {
+		if line, ok := seen[itm.Code]; ok {
+			return fail(fmt.Errorf("code %s already appears on line %d", itm.Code, line))
+		}
+		seen[itm.Code] = row.Line
+
+		var err error
+		if existing, err = s.repo.GetItemByCode(ctx, itm.Code); err != nil {
+			return fail(err)
+		}
+	}
+
+	if existing == nil {
+		if dryRun {
+			if err := s.checkCategory(ctx, itm.CategoryID, categories); err != nil {
+				return fail(err)
+			}
+			return item.BulkResult{Code: itm.Code, Status: item.BulkCreated}
+		}
+		created, err := s.createItem(ctx, itm, categories)
+		if err != nil {
+			return fail(err)
+		}
+		return item.BulkResult{ID: created.ID, Code: created.Code, Status: item.BulkCreated}
+	}
+
+	if err := s.checkCategory(ctx, itm.CategoryID, categories); err != nil {
+		return fail(err)
+	}
+	if dryRun {
+		return item.BulkResult{ID: existing.ID, Code: itm.Code, Status: item.BulkUpdated}
+	}
+	itm.ID = existing.ID
+	updated, err := s.UpdateItem(ctx, itm)
+	if err != nil {
+		return fail(err)
+	}
+	return item.BulkResult{ID: updated.ID, Code: updated.Code, Status: item.BulkUpdated}
+}
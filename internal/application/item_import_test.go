@@ -0,0 +1,70 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out/mocks"
+)
+
+func TestItemService_ImportItems_DryRun(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockClient := new(mocks.CategoryClient)
+	service := NewItemService(mockRepo, mockClient)
+
+	rows := []item.ImportRow{
+		{Line: 2, Item: &item.Item{Code: "NEW1", CategoryID: 1}},
+		{Line: 3, Item: &item.Item{Code: "OLD1", CategoryID: 1}},
+		{Line: 4, Item: &item.Item{Code: "NEW1", CategoryID: 1}},
+		{Line: 5, Error: "wrong number of fields"},
+	}
+
+	mockClient.On("IsAValidCategory", mock.Anything, 1).Return(true, nil).Once()
+	mockRepo.On("GetItemByCode", mock.Anything, "NEW1").Return(nil, nil)
+	mockRepo.On("GetItemByCode", mock.Anything, "OLD1").Return(&item.Item{ID: 4, Code: "OLD1"}, nil)
+
+	report, err := service.ImportItems(context.Background(), rows, item.BulkAtomic, true)
+	require.NoError(t, err)
+
+	assert.True(t, report.DryRun)
+	assert.Equal(t, item.BulkAtomic, report.Mode)
+	assert.Equal(t, 2, report.Succeeded)
+	assert.Equal(t, 2, report.Failed)
+	assert.Equal(t, item.BulkResult{Index: 0, Line: 2, Code: "NEW1", Status: item.BulkCreated}, report.Results[0])
+	assert.Equal(t, item.BulkResult{Index: 1, Line: 3, ID: 4, Code: "OLD1", Status: item.BulkUpdated}, report.Results[1])
+	assert.Equal(t, "code NEW1 already appears on line 2", report.Results[2].Error)
+	assert.Equal(t, 5, report.Results[3].Line)
+	mockRepo.AssertNotCalled(t, "CreateItem", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "UpdateItem", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "WithinTransaction", mock.Anything, mock.Anything)
+}
+
+func TestItemService_ImportItems_UpdatesWithoutVersion(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockClient := new(mocks.CategoryClient)
+	service := NewItemService(mockRepo, mockClient)
+	mockClient.On("IsAValidCategory", mock.Anything, 1).Return(true, nil)
+
+	// CSV rows carry no version, so they overwrite the item; JSON lines may
+	// carry one, which must match
+	rows := []item.ImportRow{
+		{Line: 2, Item: &item.Item{Code: "OLD1", Title: "Renamed", CategoryID: 1}},
+		{Line: 3, Item: &item.Item{Code: "OLD2", Title: "Renamed", CategoryID: 1, Version: 1}},
+	}
+	mockRepo.On("GetItemByCode", mock.Anything, "OLD1").Return(&item.Item{ID: 4, Code: "OLD1", CategoryID: 1, Version: 5}, nil)
+	mockRepo.On("GetItemByCode", mock.Anything, "OLD2").Return(&item.Item{ID: 6, Code: "OLD2", CategoryID: 1, Version: 2}, nil)
+	mockRepo.On("GetItemByID", mock.Anything, 4).Return(&item.Item{ID: 4, Code: "OLD1", CategoryID: 1, Version: 5}, nil)
+	mockRepo.On("GetItemByID", mock.Anything, 6).Return(&item.Item{ID: 6, Code: "OLD2", CategoryID: 1, Version: 2}, nil)
+	mockRepo.On("UpdateItem", mock.Anything, mock.Anything).Return(returnUpdated)
+
+	report, err := service.ImportItems(context.Background(), rows, item.BulkBestEffort, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, item.BulkUpdated, report.Results[0].Status)
+	assert.Equal(t, item.ErrVersionConflict.Error(), report.Results[1].Error)
+	mockRepo.AssertNumberOfCalls(t, "UpdateItem", 1)
+}
@@ -0,0 +1,119 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+)
+
+var errOwnershipDisabled = errors.New("item ownership is not enabled")
+
+// itemAccess is what a caller wants to do with an existing item.
+type itemAccess int
+
+const (
+	// accessChange covers updates, transitions and stock movements.
+	accessChange itemAccess = iota
+	// accessOwn covers deleting and restoring the item and managing its grants.
+	accessOwn
+)
+
+// WithOwnership lets non-admins change only the items they created or that
+// were shared with them through grants.
+func WithOwnership(grants out.ItemGrantRepository) ItemServiceOption {
+	return func(s *itemService) {
+		s.grants = grants
+	}
+}
+
+// authorizeItem checks that the caller may access itm. Admins may access any
+// item, and without ownership every caller may.
+func (s *itemService) authorizeItem(ctx context.Context, itm *item.Item, access itemAccess) error {
+	if s.grants == nil || user.IsAdmin(ctx) {
+		return nil
+	}
+	userID := user.UserIDFromContext(ctx)
+	if userID != 0 && itm.CreatedBy == userID {
+		return nil
+	}
+	if userID != 0 && access == accessChange {
+		granted, err := s.grants.HasItemGrant(ctx, itm.ID, userID)
+		if err != nil {
+			return err
+		}
+		if granted {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: item %d belongs to another user", item.ErrForbidden, itm.ID)
+}
+
+// authorizeItemID reads the item to check that the caller may access it. The
+// read is skipped when there is nothing to check.
+func (s *itemService) authorizeItemID(ctx context.Context, id int, includeDeleted bool, access itemAccess) error {
+	if s.grants == nil || user.IsAdmin(ctx) {
+		return nil
+	}
+	itm, err := s.repo.FindItem(ctx, id, includeDeleted)
+	if err != nil {
+		return err
+	}
+	if itm == nil {
+		return item.ErrItemNotFound
+	}
+	return s.authorizeItem(ctx, itm, access)
+}
+
+// resolveOwner turns query.Mine into a filter on the caller.
+func resolveOwner(ctx context.Context, query *item.ListQuery) error {
+	if !query.Mine {
+		return nil
+	}
+	userID := user.UserIDFromContext(ctx)
+	if userID == 0 {
+		return fmt.Errorf("%w: mine requires an authenticated user", item.ErrForbidden)
+	}
+	query.OwnerID = userID
+	return nil
+}
+
+// GrantItem shares the item with the user.
+func (s *itemService) GrantItem(ctx context.Context, id int, userID int) (*item.ItemGrant, error) {
+	if s.grants == nil {
+		return nil, errOwnershipDisabled
+	}
+	if err := s.authorizeItemID(ctx, id, false, accessOwn); err != nil {
+		return nil, err
+	}
+	grantedBy := user.UserIDFromContext(ctx)
+	grant := &item.ItemGrant{ItemID: id, UserID: userID, GrantedBy: grantedBy, CreatedAt: time.Now()}
+	if err := s.grants.CreateItemGrant(ctx, grant); err != nil {
+		return nil, err
+	}
+	return grant, nil
+}
+
+func (s *itemService) RevokeItemGrant(ctx context.Context, id int, userID int) error {
+	if s.grants == nil {
+		return errOwnershipDisabled
+	}
+	if err := s.authorizeItemID(ctx, id, false, accessOwn); err != nil {
+		return err
+	}
+	return s.grants.DeleteItemGrant(ctx, id, userID)
+}
+
+func (s *itemService) ListItemGrants(ctx context.Context, id int) ([]item.ItemGrant, error) {
+	if s.grants == nil {
+		return nil, errOwnershipDisabled
+	}
+	if err := s.authorizeItemID(ctx, id, false, accessOwn); err != nil {
+		return nil, err
+	}
+	return s.grants.ListItemGrants(ctx, id)
+}
@@ -0,0 +1,120 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out/mocks"
+)
+
+func roleContext(userID int, role user.Role) context.Context {
+	return user.WithPrincipal(context.Background(), &user.Principal{UserID: userID, Role: role})
+}
+
+func TestItemService_UpdateItem_Ownership(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockGrants := new(mocks.ItemGrantRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithOwnership(mockGrants))
+
+	existing := &item.Item{ID: 1, Code: "A1", CategoryID: 1, CreatedBy: 5, Version: 1}
+	mockRepo.On("GetItemByID", mock.Anything, 1).Return(existing, nil)
+	mockRepo.On("UpdateItem", mock.Anything, mock.Anything).Return(existing, nil)
+	mockGrants.On("HasItemGrant", mock.Anything, 1, 6).Return(true, nil)
+	mockGrants.On("HasItemGrant", mock.Anything, 1, 7).Return(false, nil)
+
+	for ctx, allowed := range map[context.Context]bool{
+		roleContext(5, user.RoleEditor): true,  // creator
+		roleContext(6, user.RoleEditor): true,  // grantee
+		roleContext(7, user.RoleEditor): false, // another seller
+		roleContext(8, user.RoleAdmin):  true,
+	} {
+		_, err := service.UpdateItem(ctx, &item.Item{ID: 1, Code: "A1", CategoryID: 1})
+		if allowed {
+			assert.NoError(t, err)
+		} else {
+			assert.ErrorIs(t, err, item.ErrForbidden)
+		}
+	}
+	mockRepo.AssertNumberOfCalls(t, "UpdateItem", 3)
+}
+
+func TestItemService_DeleteItem_GranteeForbidden(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockGrants := new(mocks.ItemGrantRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithOwnership(mockGrants))
+
+	mockRepo.On("FindItem", mock.Anything, 1, false).Return(&item.Item{ID: 1, CreatedBy: 5}, nil)
+	mockRepo.On("DeleteItem", mock.Anything, 1, 0).Return(&item.Item{ID: 1}, nil)
+
+	_, err := service.DeleteItem(roleContext(6, user.RoleEditor), 1, 0)
+	assert.ErrorIs(t, err, item.ErrForbidden)
+	mockGrants.AssertNotCalled(t, "HasItemGrant", mock.Anything, mock.Anything, mock.Anything)
+
+	_, err = service.DeleteItem(roleContext(5, user.RoleEditor), 1, 0)
+	require.NoError(t, err)
+	mockRepo.AssertNumberOfCalls(t, "DeleteItem", 1)
+}
+
+func TestItemService_GrantItem(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockGrants := new(mocks.ItemGrantRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithOwnership(mockGrants))
+
+	mockRepo.On("FindItem", mock.Anything, 1, false).Return(&item.Item{ID: 1, CreatedBy: 5}, nil)
+	mockGrants.On("CreateItemGrant", mock.Anything, mock.MatchedBy(func(grant *item.ItemGrant) bool {
+		return grant.ItemID == 1 && grant.UserID == 6 && grant.GrantedBy == 5
+	})).Return(nil)
+
+	grant, err := service.GrantItem(roleContext(5, user.RoleEditor), 1, 6)
+	require.NoError(t, err)
+	assert.Equal(t, 6, grant.UserID)
+
+	_, err = service.GrantItem(roleContext(7, user.RoleEditor), 1, 7)
+	assert.ErrorIs(t, err, item.ErrForbidden)
+	mockGrants.AssertNumberOfCalls(t, "CreateItemGrant", 1)
+}
+
+func TestItemService_ListItems_Mine(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient))
+
+	mockRepo.On("ListItems", mock.Anything, mock.MatchedBy(func(query item.ListQuery) bool {
+		return query.OwnerID == 5
+	})).Return(&item.Response{}, nil)
+
+	_, err := service.ListItems(roleContext(5, user.RoleViewer), item.ListQuery{Mine: true, Limit: 10, Page: 1})
+	require.NoError(t, err)
+
+	_, err = service.ListItems(context.Background(), item.ListQuery{Mine: true, Limit: 10, Page: 1})
+	assert.ErrorIs(t, err, item.ErrForbidden)
+	mockRepo.AssertNumberOfCalls(t, "ListItems", 1)
+}
+
+func TestItemService_Reservation_Ownership(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockGrants := new(mocks.ItemGrantRepository)
+	mockReservations := new(mocks.ReservationRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithOwnership(mockGrants),
+		WithStockLedger(new(mocks.StockMovementRepository)), WithReservations(mockReservations))
+
+	mockRepo.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockRepo.On("FindItem", mock.Anything, 1, mock.Anything).Return(&item.Item{ID: 1, CreatedBy: 5}, nil)
+	mockGrants.On("HasItemGrant", mock.Anything, 1, 7).Return(false, nil)
+	mockReservations.On("GetReservation", mock.Anything, "r-1", true).Return(heldReservation(), nil)
+
+	_, err := service.ReserveStock(roleContext(7, user.RoleEditor), 1, 2, time.Minute, 0)
+	assert.ErrorIs(t, err, item.ErrForbidden)
+	_, err = service.ConfirmReservation(roleContext(7, user.RoleEditor), "r-1")
+	assert.ErrorIs(t, err, item.ErrForbidden)
+	_, err = service.ReleaseReservation(roleContext(7, user.RoleEditor), "r-1")
+	assert.ErrorIs(t, err, item.ErrForbidden)
+
+	mockRepo.AssertNotCalled(t, "AdjustStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockReservations.AssertNotCalled(t, "UpdateReservation", mock.Anything, mock.Anything)
+}
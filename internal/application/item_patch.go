@@ -0,0 +1,117 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/utils"
+)
+
+// PatchItem applies a JSON Merge Patch or JSON Patch to the item and stores the
+// result as a full replacement, so explicit zero and null values are kept. A
+// non-zero version must match the stored one.
+func (s *itemService) PatchItem(
+	ctx context.Context, id int, version int, patchType item.PatchType, patch []byte,
+) (*item.Item, error) {
+	existing, err := s.repo.GetItemByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, item.ErrItemNotFound
+	}
+	if version != 0 && version != existing.Version {
+		return nil, item.ErrVersionConflict
+	}
+	if existing.Status == item.StatusArchived {
+		return nil, item.ErrItemArchived
+	}
+
+	patched, err := applyPatch(existing, patchType, patch)
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateStruct(patched); err != nil {
+		return nil, fmt.Errorf("%w: missing or invalid fields: %v", item.ErrInvalidPatch, err)
+	}
+	return s.replaceItem(ctx, item.AuditUpdated, existing, patched)
+}
+
+// applyPatch returns a copy of itm with the patch applied. Fields managed by
+// the server can appear in the patch only with their current value.
+func applyPatch(itm *item.Item, patchType item.PatchType, patch []byte) (*item.Item, error) {
+	doc, err := itemDocument(itm)
+	if err != nil {
+		return nil, err
+	}
+
+	switch patchType {
+	case item.MergePatch:
+		doc, err = jsonpatch.MergePatch(doc, patch)
+	case item.JSONPatch:
+		var ops jsonpatch.Patch
+		if ops, err = jsonpatch.DecodePatch(patch); err == nil {
+			doc, err = ops.Apply(doc)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported patch type %q", item.ErrInvalidPatch, patchType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", item.ErrInvalidPatch, err)
+	}
+
+	var patched item.Item
+	decoder := json.NewDecoder(bytes.NewReader(doc))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&patched); err != nil {
+		return nil, fmt.Errorf("%w: %v", item.ErrInvalidPatch, err)
+	}
+
+	switch {
+	case patched.ID != itm.ID:
+		return nil, fmt.Errorf("%w: id cannot be changed", item.ErrInvalidPatch)
+	case patched.Code != itm.Code || patched.PublicID != itm.PublicID:
+		return nil, fmt.Errorf("%w: code and public_id cannot be changed", item.ErrInvalidPatch)
+	case patched.Status != itm.Status:
+		return nil, fmt.Errorf("%w: status cannot be changed", item.ErrInvalidPatch)
+	case patched.Stock != itm.Stock:
+		return nil, fmt.Errorf("%w: %w", item.ErrInvalidPatch, item.ErrStockNotEditable)
+	case patched.Reserved != itm.Reserved || patched.Available != itm.Available:
+		return nil, fmt.Errorf("%w: reserved and available are changed through reservations", item.ErrInvalidPatch)
+	case patched.LowStock != itm.LowStock:
+		return nil, fmt.Errorf("%w: low_stock is derived from the reorder point", item.ErrInvalidPatch)
+	case patched.CreatedBy != itm.CreatedBy || !patched.CreatedAt.Equal(itm.CreatedAt):
+		return nil, fmt.Errorf("%w: created_by and created_at cannot be changed", item.ErrInvalidPatch)
+	case patched.UpdatedBy != itm.UpdatedBy || !patched.UpdatedAt.Equal(itm.UpdatedAt):
+		return nil, fmt.Errorf("%w: updated_by and updated_at cannot be changed", item.ErrInvalidPatch)
+	case patched.Version != itm.Version:
+		return nil, fmt.Errorf("%w: version cannot be changed", item.ErrInvalidPatch)
+	case patched.DeletedAt.Valid || patched.DeletedBy != 0:
+		return nil, fmt.Errorf("%w: deleted_at and deleted_by cannot be changed", item.ErrInvalidPatch)
+	}
+	return &patched, nil
+}
+
+// itemDocument encodes itm as JSON including the fields left out by omitempty,
+// so JSON Patch operations such as replace can address them.
+func itemDocument(itm *item.Item) ([]byte, error) {
+	raw, err := json.Marshal(itm)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{
+		"title":       itm.Title,
+		"description": itm.Description,
+		"price":       itm.Price,
+		"stock":       itm.Stock,
+		"status":      itm.Status,
+	}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
@@ -0,0 +1,164 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out/mocks"
+)
+
+func patchableItem() *item.Item {
+	return &item.Item{
+		ID: 1, Code: "A1", Title: "Blue shirt", Description: "Cotton", CategoryID: 2, Price: 10.5, Stock: 5,
+		Status: "ACTIVE", CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), CreatedBy: 7, Version: 3,
+	}
+}
+
+func returnUpdated(_ context.Context, itm *item.Item) (*item.Item, error) {
+	return itm, nil
+}
+
+func TestItemService_PatchItem_MergePatch(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient))
+
+	mockRepo.On("GetItemByID", mock.Anything, 1).Return(patchableItem(), nil)
+	mockRepo.On("UpdateItem", mock.Anything, mock.Anything).Return(returnUpdated)
+
+	patch := `{"price": 0, "description": null, "title": "Red shirt"}`
+	updated, err := service.PatchItem(context.Background(), 1, 0, item.MergePatch, []byte(patch))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Red shirt", updated.Title)
+	assert.Empty(t, updated.Description)
+	assert.Zero(t, updated.Price)
+	assert.Equal(t, 2, updated.CategoryID, "absent fields keep their value")
+	assert.Equal(t, "A1", updated.Code)
+}
+
+func TestItemService_PatchItem_JSONPatch(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockClient := new(mocks.CategoryClient)
+	service := NewItemService(mockRepo, mockClient)
+
+	mockRepo.On("GetItemByID", mock.Anything, 1).Return(patchableItem(), nil)
+	mockRepo.On("UpdateItem", mock.Anything, mock.Anything).Return(returnUpdated)
+	mockClient.On("IsAValidCategory", mock.Anything, 3).Return(true, nil).Once()
+
+	patch := `[
+		{"op": "test", "path": "/stock", "value": 5},
+		{"op": "replace", "path": "/price", "value": 0},
+		{"op": "replace", "path": "/category_id", "value": 3},
+		{"op": "remove", "path": "/description"}
+	]`
+	updated, err := service.PatchItem(context.Background(), 1, 0, item.JSONPatch, []byte(patch))
+	require.NoError(t, err)
+
+	assert.Zero(t, updated.Price)
+	assert.Equal(t, 3, updated.CategoryID)
+	assert.Empty(t, updated.Description)
+	mockClient.AssertExpectations(t)
+}
+
+func TestItemService_PatchItem_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		patchType item.PatchType
+		patch     string
+	}{
+		{name: "malformed JSON", patchType: item.MergePatch, patch: `{"price":`},
+		{name: "unknown field", patchType: item.MergePatch, patch: `{"colour": "red"}`},
+		{name: "read-only field", patchType: item.MergePatch, patch: `{"created_by": 9}`},
+		{name: "code change", patchType: item.MergePatch, patch: `{"code": "B2"}`},
+		{name: "version change", patchType: item.MergePatch, patch: `{"version": 4}`},
+		{name: "stock change", patchType: item.MergePatch, patch: `{"stock": 0}`},
+		{name: "soft delete", patchType: item.MergePatch, patch: `{"deleted_at": "2026-01-01T00:00:00Z"}`},
+		{name: "validation", patchType: item.MergePatch, patch: `{"price": -1}`},
+		{name: "failed test operation", patchType: item.JSONPatch, patch: `[{"op": "test", "path": "/stock", "value": 1}]`},
+		{name: "unsupported type", patchType: "text/plain", patch: `{}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.ItemRepository)
+			service := NewItemService(mockRepo, new(mocks.CategoryClient))
+			mockRepo.On("GetItemByID", mock.Anything, 1).Return(patchableItem(), nil)
+
+			_, err := service.PatchItem(context.Background(), 1, 0, tt.patchType, []byte(tt.patch))
+			assert.ErrorIs(t, err, item.ErrInvalidPatch)
+			mockRepo.AssertNotCalled(t, "UpdateItem", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestItemService_PatchItem_NotFound(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient))
+	mockRepo.On("GetItemByID", mock.Anything, 1).Return(nil, nil)
+
+	_, err := service.PatchItem(context.Background(), 1, 0, item.MergePatch, []byte(`{}`))
+	assert.ErrorIs(t, err, item.ErrItemNotFound)
+}
+
+func TestItemService_UpdateItem_ReplacesZeroValues(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient))
+
+	mockRepo.On("GetItemByID", mock.Anything, 1).Return(patchableItem(), nil)
+	mockRepo.On("UpdateItem", mock.Anything, mock.Anything).Return(returnUpdated)
+
+	updated, err := service.UpdateItem(context.Background(), &item.Item{ID: 1, Code: "A1", CategoryID: 2})
+	require.NoError(t, err)
+
+	assert.Empty(t, updated.Title)
+	assert.Empty(t, updated.Description)
+	assert.Zero(t, updated.Price)
+	assert.Equal(t, 5, updated.Stock, "the stock is owned by the stock ledger")
+
+	updated, err = service.UpdateItem(context.Background(), &item.Item{ID: 1, Code: "A1", CategoryID: 2, Stock: 7})
+	require.NoError(t, err)
+	assert.Equal(t, 5, updated.Stock, "a stale stock is ignored")
+}
+
+func TestItemService_UpdateItem_RejectsCodeChange(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient))
+	mockRepo.On("GetItemByID", mock.Anything, 1).Return(patchableItem(), nil)
+
+	_, err := service.UpdateItem(context.Background(), &item.Item{ID: 1, Code: "B1", CategoryID: 2, Stock: 5})
+	assert.ErrorIs(t, err, item.ErrCodeImmutable)
+	mockRepo.AssertNotCalled(t, "ItemExistsByCode", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "UpdateItem", mock.Anything, mock.Anything)
+}
+
+func TestItemService_PatchItem_VersionConflict(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient))
+	mockRepo.On("GetItemByID", mock.Anything, 1).Return(patchableItem(), nil)
+
+	_, err := service.PatchItem(context.Background(), 1, 2, item.MergePatch, []byte(`{"stock": 1}`))
+	assert.ErrorIs(t, err, item.ErrVersionConflict)
+	mockRepo.AssertNotCalled(t, "UpdateItem", mock.Anything, mock.Anything)
+}
+
+func TestItemService_UpdateItem_SendsReadVersion(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient))
+
+	mockRepo.On("GetItemByID", mock.Anything, 1).Return(patchableItem(), nil)
+	mockRepo.On("UpdateItem", mock.Anything, mock.MatchedBy(func(itm *item.Item) bool {
+		return itm.Version == 3
+	})).Return(returnUpdated)
+
+	_, err := service.UpdateItem(context.Background(), &item.Item{ID: 1, Code: "A1", CategoryID: 2})
+	require.NoError(t, err)
+
+	_, err = service.UpdateItem(context.Background(), &item.Item{ID: 1, Code: "A1", CategoryID: 2, Version: 2})
+	assert.ErrorIs(t, err, item.ErrVersionConflict)
+	mockRepo.AssertNumberOfCalls(t, "UpdateItem", 1)
+}
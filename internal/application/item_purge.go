@@ -0,0 +1,38 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/pkg/log"
+)
+
+// RunPurge permanently removes, every interval, the items soft-deleted more
+// than retention ago, until ctx is done.
+func (s *itemService) RunPurge(ctx context.Context, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.purgeDeletedItems(ctx, retention)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *itemService) purgeDeletedItems(ctx context.Context, retention time.Duration) {
+	logger := log.GetFromContext(ctx)
+	purged, err := s.repo.PurgeDeletedItems(ctx, time.Now().Add(-retention))
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Errorf("Error purging deleted items: %v", err)
+		}
+		return
+	}
+	if purged > 0 {
+		logger.Infof("Purged %d items deleted more than %s ago", purged, retention)
+	}
+}
@@ -0,0 +1,253 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+	"github.com/teamcubation/go-items-challenge/pkg/log"
+)
+
+// reapBatchSize is the number of expired reservations released per transaction.
+const reapBatchSize = 100
+
+var errReservationsDisabled = errors.New("stock reservations are not enabled")
+
+// WithReservations enables stock reservations, stored in reservations.
+// Confirmed reservations are recorded as sales, so the stock ledger must be
+// enabled as well.
+func WithReservations(reservations out.ReservationRepository) ItemServiceOption {
+	return func(s *itemService) {
+		s.reservations = reservations
+	}
+}
+
+// ReserveStock holds quantity units of the available stock of the item for
+// ttl. Only ACTIVE items can be reserved. With warehouses, the stock is held in warehouseID, or in the default
+// warehouse when it is zero, and confirming the reservation sells it from
+// there.
+func (s *itemService) ReserveStock(
+	ctx context.Context, id int, quantity int, ttl time.Duration, warehouseID int,
+) (*item.Reservation, error) {
+	if s.reservations == nil {
+		return nil, errReservationsDisabled
+	}
+	if err := item.ValidateReservation(quantity, ttl); err != nil {
+		return nil, err
+	}
+	if s.warehouses == nil && warehouseID != 0 {
+		return nil, fmt.Errorf("%w: %v", item.ErrInvalidReservation, errWarehousesDisabled)
+	}
+
+	now := time.Now()
+	userID := user.UserIDFromContext(ctx)
+	reservation := &item.Reservation{
+		ID:          uuid.New().String(),
+		ItemID:      id,
+		Quantity:    quantity,
+		WarehouseID: warehouseID,
+		Status:      item.ReservationHeld,
+		ExpiresAt:   now.Add(ttl),
+		CreatedBy:   userID,
+		CreatedAt:   now,
+	}
+	err := s.repo.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.authorizeItemID(ctx, id, false, accessChange); err != nil {
+			return err
+		}
+		// the item is locked by the adjustment, which is rolled back if the item
+		// is not for sale
+		adjusted, err := s.repo.AdjustStock(ctx, id, 0, quantity)
+		if err != nil {
+			return err
+		}
+		if adjusted.Status != item.StatusActive {
+			return fmt.Errorf("%w: item %d is %s", item.ErrItemNotActive, id, adjusted.Status)
+		}
+		if s.warehouses != nil {
+			if err := s.resolveWarehouse(ctx, &reservation.WarehouseID); err != nil {
+				return err
+			}
+			_, err := s.warehouses.AdjustStockLevel(ctx, id, reservation.WarehouseID, 0, quantity)
+			if err != nil {
+				return err
+			}
+		}
+		return s.reservations.CreateReservation(ctx, reservation)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+func (s *itemService) GetReservation(ctx context.Context, id string) (*item.Reservation, error) {
+	if s.reservations == nil {
+		return nil, errReservationsDisabled
+	}
+	reservation, err := s.reservations.GetReservation(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	if reservation == nil {
+		return nil, item.ErrReservationNotFound
+	}
+	return reservation, nil
+}
+
+// ConfirmReservation turns a held reservation into a sale of the reserved
+// stock, recorded in the stock ledger.
+func (s *itemService) ConfirmReservation(ctx context.Context, id string) (*item.Reservation, error) {
+	return s.resolveReservation(ctx, id, func(ctx context.Context, reservation *item.Reservation) error {
+		movement := &item.StockMovement{
+			Type:        item.MovementSale,
+			Quantity:    reservation.Quantity,
+			Reason:      fmt.Sprintf("reservation %s confirmed", reservation.ID),
+			WarehouseID: reservation.WarehouseID,
+		}
+		if err := movement.Validate(); err != nil {
+			return err
+		}
+		if err := s.applyMovement(ctx, reservation.ItemID, movement, -reservation.Quantity); err != nil {
+			return err
+		}
+		reservation.Status = item.ReservationConfirmed
+		return nil
+	})
+}
+
+// ReleaseReservation returns the stock of a held reservation to the available
+// stock.
+func (s *itemService) ReleaseReservation(ctx context.Context, id string) (*item.Reservation, error) {
+	return s.resolveReservation(ctx, id, func(ctx context.Context, reservation *item.Reservation) error {
+		if err := s.releaseStock(ctx, reservation); err != nil {
+			return err
+		}
+		reservation.Status = item.ReservationReleased
+		return nil
+	})
+}
+
+// resolveReservation locks the reservation, checks that it is still held and
+// that the caller may change its item, and runs resolve, which sets the final
+// status, in one transaction.
+func (s *itemService) resolveReservation(
+	ctx context.Context, id string, resolve func(ctx context.Context, reservation *item.Reservation) error,
+) (*item.Reservation, error) {
+	if s.reservations == nil || s.stock == nil {
+		return nil, errReservationsDisabled
+	}
+
+	var resolved *item.Reservation
+	err := s.repo.WithinTransaction(ctx, func(ctx context.Context) error {
+		reservation, err := s.reservations.GetReservation(ctx, id, true)
+		if err != nil {
+			return err
+		}
+		if reservation == nil {
+			return item.ErrReservationNotFound
+		}
+		if err := s.authorizeItemID(ctx, reservation.ItemID, true, accessChange); err != nil {
+			return err
+		}
+		now := time.Now()
+		// expired reservations are left for the reaper
+		if !reservation.Held(now) {
+			return item.ErrReservationClosed
+		}
+		if err := resolve(ctx, reservation); err != nil {
+			return err
+		}
+		reservation.ResolvedAt = &now
+		resolved = reservation
+		return s.reservations.UpdateReservation(ctx, reservation)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resolved, nil
+}
+
+// releaseStock removes the reservation from the reserved stock of its item
+// and of its warehouse. Items purged since the reservation was made have
+// nothing to release.
+func (s *itemService) releaseStock(ctx context.Context, reservation *item.Reservation) error {
+	_, err := s.repo.AdjustStock(ctx, reservation.ItemID, 0, -reservation.Quantity)
+	if errors.Is(err, item.ErrItemNotFound) {
+		return nil
+	}
+	if err != nil || s.warehouses == nil || reservation.WarehouseID == 0 {
+		return err
+	}
+	_, err = s.warehouses.AdjustStockLevel(ctx, reservation.ItemID, reservation.WarehouseID, 0, -reservation.Quantity)
+	return err
+}
+
+// RunReservationReaper releases, every interval, the reservations that expired
+// while held, until ctx is done.
+func (s *itemService) RunReservationReaper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.reapExpiredReservations(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *itemService) reapExpiredReservations(ctx context.Context) {
+	logger := log.GetFromContext(ctx)
+	total := 0
+	for {
+		reaped, err := s.reapReservationBatch(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Errorf("Error releasing expired reservations: %v", err)
+			}
+			break
+		}
+		total += reaped
+		if reaped < reapBatchSize {
+			break
+		}
+	}
+	if total > 0 {
+		logger.Infof("Released %d expired reservations", total)
+	}
+}
+
+// reapReservationBatch expires one batch of reservations in a transaction.
+// Reapers in other processes skip the locked rows.
+func (s *itemService) reapReservationBatch(ctx context.Context) (int, error) {
+	reaped := 0
+	err := s.repo.WithinTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		expired, err := s.reservations.ClaimExpiredReservations(ctx, now, reapBatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range expired {
+			reservation := &expired[i]
+			if err := s.releaseStock(ctx, reservation); err != nil {
+				return err
+			}
+			reservation.Status = item.ReservationExpired
+			reservation.ResolvedAt = &now
+			if err := s.reservations.UpdateReservation(ctx, reservation); err != nil {
+				return err
+			}
+		}
+		reaped = len(expired)
+		return nil
+	})
+	return reaped, err
+}
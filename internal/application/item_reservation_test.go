@@ -0,0 +1,168 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/domain/warehouse"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out/mocks"
+)
+
+func reservationService() (*itemService, *mocks.ItemRepository, *mocks.StockMovementRepository, *mocks.ReservationRepository) {
+	mockRepo := new(mocks.ItemRepository)
+	mockStock := new(mocks.StockMovementRepository)
+	mockReservations := new(mocks.ReservationRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithStockLedger(mockStock), WithReservations(mockReservations))
+	mockRepo.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	return service, mockRepo, mockStock, mockReservations
+}
+
+func heldReservation() *item.Reservation {
+	return &item.Reservation{
+		ID: "r-1", ItemID: 1, Quantity: 2, Status: item.ReservationHeld, ExpiresAt: time.Now().Add(time.Minute),
+	}
+}
+
+func TestItemService_ReserveStock(t *testing.T) {
+	service, mockRepo, _, mockReservations := reservationService()
+
+	mockRepo.On("AdjustStock", mock.Anything, 1, 0, 2).Return(&item.Item{ID: 1, Stock: 5, Reserved: 2, Status: item.StatusActive}, nil)
+	mockReservations.On("CreateReservation", mock.Anything, mock.Anything).Return(nil)
+
+	before := time.Now()
+	reservation, err := service.ReserveStock(auditContext(7, "req-1"), 1, 2, 10*time.Minute, 0)
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, reservation.ID)
+	assert.Equal(t, item.ReservationHeld, reservation.Status)
+	assert.Equal(t, 7, reservation.CreatedBy)
+	assert.WithinDuration(t, before.Add(10*time.Minute), reservation.ExpiresAt, time.Second)
+	mockReservations.AssertCalled(t, "CreateReservation", mock.Anything, reservation)
+}
+
+func TestItemService_ReserveStock_Insufficient(t *testing.T) {
+	service, mockRepo, _, mockReservations := reservationService()
+	mockRepo.On("AdjustStock", mock.Anything, 1, 0, 9).Return(nil, item.ErrInsufficientStock)
+
+	_, err := service.ReserveStock(context.Background(), 1, 9, time.Minute, 0)
+	assert.ErrorIs(t, err, item.ErrInsufficientStock)
+	mockReservations.AssertNotCalled(t, "CreateReservation", mock.Anything, mock.Anything)
+
+	_, err = service.ReserveStock(context.Background(), 1, 0, time.Minute, 0)
+	assert.ErrorIs(t, err, item.ErrInvalidReservation)
+}
+
+func TestItemService_ReserveStock_NotActive(t *testing.T) {
+	service, mockRepo, _, mockReservations := reservationService()
+	mockRepo.On("AdjustStock", mock.Anything, 1, 0, 2).
+		Return(&item.Item{ID: 1, Stock: 5, Reserved: 2, Status: item.StatusArchived}, nil)
+
+	_, err := service.ReserveStock(context.Background(), 1, 2, time.Minute, 0)
+	assert.ErrorIs(t, err, item.ErrItemNotActive)
+	mockReservations.AssertNotCalled(t, "CreateReservation", mock.Anything, mock.Anything)
+}
+
+func TestItemService_ConfirmReservation(t *testing.T) {
+	service, mockRepo, mockStock, mockReservations := reservationService()
+
+	mockReservations.On("GetReservation", mock.Anything, "r-1", true).Return(heldReservation(), nil)
+	mockRepo.On("AdjustStock", mock.Anything, 1, -2, -2).Return(&item.Item{ID: 1, Stock: 3}, nil)
+	mockStock.On("CreateStockMovement", mock.Anything, mock.MatchedBy(func(movement *item.StockMovement) bool {
+		return movement.Type == item.MovementSale && movement.Delta == -2 && movement.StockAfter == 3
+	})).Return(nil)
+	mockReservations.On("UpdateReservation", mock.Anything, mock.Anything).Return(nil)
+
+	reservation, err := service.ConfirmReservation(auditContext(7, "req-1"), "r-1")
+	require.NoError(t, err)
+	assert.Equal(t, item.ReservationConfirmed, reservation.Status)
+	assert.NotNil(t, reservation.ResolvedAt)
+	mockStock.AssertExpectations(t)
+}
+
+func TestItemService_Reservation_Warehouse(t *testing.T) {
+	service, mockRepo, mockStock, mockReservations := reservationService()
+	mockWarehouses := new(mocks.WarehouseRepository)
+	WithWarehouses(mockWarehouses)(service)
+
+	// the stock is held in the warehouse chosen at reserve time
+	mockWarehouses.On("GetWarehouse", mock.Anything, 2).Return(&warehouse.Warehouse{ID: 2, Code: "EAST"}, nil)
+	mockRepo.On("AdjustStock", mock.Anything, 1, 0, 2).Return(&item.Item{ID: 1, Stock: 5, Reserved: 2, Status: item.StatusActive}, nil)
+	mockWarehouses.On("AdjustStockLevel", mock.Anything, 1, 2, 0, 2).Return(&item.StockLevel{Stock: 3, Reserved: 2}, nil)
+	mockReservations.On("CreateReservation", mock.Anything, mock.Anything).Return(nil)
+
+	reservation, err := service.ReserveStock(auditContext(7, "req-1"), 1, 2, time.Minute, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, reservation.WarehouseID)
+
+	// and sold from it on confirm
+	mockReservations.On("GetReservation", mock.Anything, reservation.ID, true).Return(reservation, nil)
+	mockWarehouses.On("AdjustStockLevel", mock.Anything, 1, 2, -2, -2).Return(&item.StockLevel{Stock: 1}, nil)
+	mockRepo.On("AdjustStock", mock.Anything, 1, -2, -2).Return(&item.Item{ID: 1, Stock: 3}, nil)
+	mockStock.On("CreateStockMovement", mock.Anything, mock.MatchedBy(func(movement *item.StockMovement) bool {
+		return movement.Type == item.MovementSale && movement.WarehouseID == 2
+	})).Return(nil)
+	mockReservations.On("UpdateReservation", mock.Anything, mock.Anything).Return(nil)
+
+	_, err = service.ConfirmReservation(auditContext(7, "req-2"), reservation.ID)
+	require.NoError(t, err)
+	mockWarehouses.AssertExpectations(t)
+	mockWarehouses.AssertNotCalled(t, "GetDefaultWarehouse", mock.Anything)
+}
+
+func TestItemService_ReleaseReservation(t *testing.T) {
+	service, mockRepo, _, mockReservations := reservationService()
+
+	mockReservations.On("GetReservation", mock.Anything, "r-1", true).Return(heldReservation(), nil)
+	mockRepo.On("AdjustStock", mock.Anything, 1, 0, -2).Return(&item.Item{ID: 1, Stock: 5}, nil)
+	mockReservations.On("UpdateReservation", mock.Anything, mock.Anything).Return(nil)
+
+	reservation, err := service.ReleaseReservation(context.Background(), "r-1")
+	require.NoError(t, err)
+	assert.Equal(t, item.ReservationReleased, reservation.Status)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestItemService_ResolveReservation_NotHeld(t *testing.T) {
+	service, mockRepo, _, mockReservations := reservationService()
+
+	expired := heldReservation()
+	expired.ExpiresAt = time.Now().Add(-time.Second)
+	confirmed := heldReservation()
+	confirmed.Status = item.ReservationConfirmed
+	mockReservations.On("GetReservation", mock.Anything, "expired", true).Return(expired, nil)
+	mockReservations.On("GetReservation", mock.Anything, "confirmed", true).Return(confirmed, nil)
+	mockReservations.On("GetReservation", mock.Anything, "missing", true).Return(nil, nil)
+
+	_, err := service.ConfirmReservation(context.Background(), "expired")
+	assert.ErrorIs(t, err, item.ErrReservationClosed)
+	_, err = service.ReleaseReservation(context.Background(), "confirmed")
+	assert.ErrorIs(t, err, item.ErrReservationClosed)
+	_, err = service.ConfirmReservation(context.Background(), "missing")
+	assert.ErrorIs(t, err, item.ErrReservationNotFound)
+	mockRepo.AssertNotCalled(t, "AdjustStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestItemService_ReapExpiredReservations(t *testing.T) {
+	service, mockRepo, _, mockReservations := reservationService()
+
+	expired := []item.Reservation{
+		{ID: "r-1", ItemID: 1, Quantity: 2, Status: item.ReservationHeld},
+		{ID: "r-2", ItemID: 2, Quantity: 1, Status: item.ReservationHeld},
+	}
+	mockReservations.On("ClaimExpiredReservations", mock.Anything, mock.Anything, reapBatchSize).Return(expired, nil).Once()
+	mockRepo.On("AdjustStock", mock.Anything, 1, 0, -2).Return(&item.Item{ID: 1}, nil)
+	// the item of r-2 was purged
+	mockRepo.On("AdjustStock", mock.Anything, 2, 0, -1).Return(nil, item.ErrItemNotFound)
+	mockReservations.On("UpdateReservation", mock.Anything, mock.MatchedBy(func(r *item.Reservation) bool {
+		return r.Status == item.ReservationExpired && r.ResolvedAt != nil
+	})).Return(nil).Twice()
+
+	service.reapExpiredReservations(context.Background())
+	mockRepo.AssertExpectations(t)
+	mockReservations.AssertExpectations(t)
+}
@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/utils"
+)
+
+var errRevisionsDisabled = errors.New("item revisions are not enabled")
+
+// GetItemAsOf returns the item as it was at the given time, from the snapshots
+// of the audit log. Items that did not exist or were deleted at that time are
+// not found.
+func (s *itemService) GetItemAsOf(ctx context.Context, id int, at time.Time) (*item.Item, error) {
+	if s.audit == nil {
+		return nil, errRevisionsDisabled
+	}
+	entry, err := s.audit.FindRevisionAt(ctx, id, at)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, s.missingRevision(ctx, id, item.ErrItemNotFound)
+	}
+	if entry.Snapshot == nil || entry.Snapshot.DeletedAt.Valid {
+		return nil, item.ErrItemNotFound
+	}
+	return entry.Snapshot, nil
+}
+
+// RevertItem replaces the item with the editable fields of one of its
+// revisions, with the same checks as UpdateItem. A non-zero version must match
+// the stored one.
+func (s *itemService) RevertItem(ctx context.Context, id int, revision int, version int) (*item.Item, error) {
+	if s.audit == nil {
+		return nil, errRevisionsDisabled
+	}
+	entry, err := s.audit.FindRevision(ctx, id, revision)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, s.missingRevision(ctx, id, item.ErrRevisionNotFound)
+	}
+	if entry.Snapshot == nil {
+		return nil, item.ErrRevisionNotFound
+	}
+
+	existing, err := s.repo.GetItemByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, item.ErrItemNotFound
+	}
+	if version != 0 && version != existing.Version {
+		return nil, item.ErrVersionConflict
+	}
+
+	snapshot := entry.Snapshot
+	reverted := &item.Item{
+		Code:        snapshot.Code,
+		Title:       snapshot.Title,
+		Description: snapshot.Description,
+		CategoryID:  snapshot.CategoryID,
+		Price:       snapshot.Price,
+		// the stock is owned by the stock ledger, not by the revisions
+		Stock: existing.Stock,
+		// the reorder point is part of the revision, the low stock flag is not
+		ReorderPoint: snapshot.ReorderPoint,
+	}
+	// validation rules may have changed since the revision was stored
+	if err := utils.ValidateStruct(reverted); err != nil {
+		return nil, fmt.Errorf("%w: %v", item.ErrInvalidItem, err)
+	}
+	return s.replaceItem(ctx, item.AuditReverted, existing, reverted)
+}
+
+// missingRevision returns notFound when the item has a history that lacks the
+// revision looked for, and item.ErrNoHistory when it has none at all.
+func (s *itemService) missingRevision(ctx context.Context, id int, notFound error) error {
+	history, err := s.audit.ListAuditEntries(ctx, item.HistoryQuery{ItemID: id, Limit: 1, Page: 1})
+	if err != nil {
+		return err
+	}
+	if len(history.Data) == 0 {
+		return item.ErrNoHistory
+	}
+	return notFound
+}
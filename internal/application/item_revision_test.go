@@ -0,0 +1,105 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out/mocks"
+)
+
+func TestItemService_GetItemAsOf(t *testing.T) {
+	mockAudit := new(mocks.AuditRepository)
+	service := NewItemService(new(mocks.ItemRepository), new(mocks.CategoryClient), WithAuditLog(mockAudit))
+
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	snapshot := &item.Item{ID: 1, Code: "A1", Title: "Old", Version: 2}
+	mockAudit.On("FindRevisionAt", mock.Anything, 1, at).Return(&item.AuditEntry{ItemID: 1, Version: 2, Snapshot: snapshot}, nil)
+
+	itm, err := service.GetItemAsOf(context.Background(), 1, at)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot, itm)
+}
+
+func TestItemService_GetItemAsOf_NotFound(t *testing.T) {
+	mockAudit := new(mocks.AuditRepository)
+	service := NewItemService(new(mocks.ItemRepository), new(mocks.CategoryClient), WithAuditLog(mockAudit))
+
+	deleted := &item.Item{ID: 2, Code: "A2", Version: 3}
+	deleted.DeletedAt.Valid = true
+	mockAudit.On("FindRevisionAt", mock.Anything, 2, mock.Anything).Return(&item.AuditEntry{ItemID: 2, Snapshot: deleted}, nil)
+	mockAudit.On("FindRevisionAt", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	mockAudit.On("ListAuditEntries", mock.Anything, item.HistoryQuery{ItemID: 1, Limit: 1, Page: 1}).
+		Return(&item.HistoryResponse{Data: []item.AuditEntry{{ItemID: 1, Version: 1}}}, nil)
+	mockAudit.On("ListAuditEntries", mock.Anything, item.HistoryQuery{ItemID: 3, Limit: 1, Page: 1}).
+		Return(&item.HistoryResponse{Data: []item.AuditEntry{}}, nil)
+
+	// item 1 was created after the given time
+	_, err := service.GetItemAsOf(context.Background(), 1, time.Now())
+	assert.ErrorIs(t, err, item.ErrItemNotFound)
+	_, err = service.GetItemAsOf(context.Background(), 2, time.Now())
+	assert.ErrorIs(t, err, item.ErrItemNotFound)
+	// item 3 predates the audit log
+	_, err = service.GetItemAsOf(context.Background(), 3, time.Now())
+	assert.ErrorIs(t, err, item.ErrNoHistory)
+}
+
+func TestItemService_RevertItem(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockClient := new(mocks.CategoryClient)
+	mockAudit := new(mocks.AuditRepository)
+	service := NewItemService(mockRepo, mockClient, WithAuditLog(mockAudit))
+
+	snapshot := &item.Item{ID: 1, Code: "A1", Title: "Old title", CategoryID: 2, Price: 10, Stock: 5, Version: 2}
+	existing := &item.Item{ID: 1, Code: "A1", Title: "New title", CategoryID: 3, Price: 12, Stock: 5, Version: 4}
+	mockAudit.On("FindRevision", mock.Anything, 1, 2).Return(&item.AuditEntry{ItemID: 1, Version: 2, Snapshot: snapshot}, nil)
+	mockRepo.On("GetItemByID", mock.Anything, 1).Return(existing, nil)
+	mockClient.On("IsAValidCategory", mock.Anything, 2).Return(true, nil).Once()
+	mockRepo.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockRepo.On("UpdateItem", mock.Anything, mock.MatchedBy(func(itm *item.Item) bool {
+		return itm.ID == 1 && itm.Title == "Old title" && itm.CategoryID == 2 && itm.Price == 10 && itm.Version == 4
+	})).Return(&item.Item{ID: 1, Code: "A1", Title: "Old title", CategoryID: 2, Price: 10, Stock: 5, Version: 5}, nil)
+	mockAudit.On("CreateAuditEntry", mock.Anything, mock.MatchedBy(func(entry *item.AuditEntry) bool {
+		return entry.Action == item.AuditReverted && entry.Version == 5
+	})).Return(nil)
+
+	reverted, err := service.RevertItem(context.Background(), 1, 2, 4)
+	require.NoError(t, err)
+	assert.Equal(t, 5, reverted.Version)
+	mockAudit.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+	mockClient.AssertExpectations(t)
+}
+
+func TestItemService_RevertItem_Errors(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockClient := new(mocks.CategoryClient)
+	mockAudit := new(mocks.AuditRepository)
+	service := NewItemService(mockRepo, mockClient, WithAuditLog(mockAudit))
+
+	mockAudit.On("FindRevision", mock.Anything, mock.Anything, 9).Return(nil, nil)
+	mockAudit.On("ListAuditEntries", mock.Anything, item.HistoryQuery{ItemID: 1, Limit: 1, Page: 1}).
+		Return(&item.HistoryResponse{Data: []item.AuditEntry{{ItemID: 1, Version: 3}}}, nil)
+	mockAudit.On("ListAuditEntries", mock.Anything, item.HistoryQuery{ItemID: 5, Limit: 1, Page: 1}).
+		Return(&item.HistoryResponse{Data: []item.AuditEntry{}}, nil)
+	_, err := service.RevertItem(context.Background(), 1, 9, 0)
+	assert.ErrorIs(t, err, item.ErrRevisionNotFound)
+	_, err = service.RevertItem(context.Background(), 5, 9, 0)
+	assert.ErrorIs(t, err, item.ErrNoHistory)
+
+	invalid := &item.Item{ID: 1, Code: "A-1", Version: 1}
+	mockAudit.On("FindRevision", mock.Anything, 1, 1).Return(&item.AuditEntry{Snapshot: invalid}, nil)
+	mockRepo.On("GetItemByID", mock.Anything, 1).Return(&item.Item{ID: 1, Code: "A1", Version: 3}, nil)
+
+	_, err = service.RevertItem(context.Background(), 1, 1, 2)
+	assert.ErrorIs(t, err, item.ErrVersionConflict)
+
+	_, err = service.RevertItem(context.Background(), 1, 1, 3)
+	assert.ErrorIs(t, err, item.ErrInvalidItem)
+
+	mockRepo.AssertNotCalled(t, "UpdateItem", mock.Anything, mock.Anything)
+}
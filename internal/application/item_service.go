@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
 	"github.com/teamcubation/go-items-challenge/internal/ports/out"
 	"github.com/teamcubation/go-items-challenge/pkg/log"
 )
@@ -14,34 +15,102 @@ import (
 type itemService struct {
 	repo   out.ItemRepository
 	client out.CategoryClient
+	audit  out.AuditRepository
+	stock  out.StockMovementRepository
+
+	reservations out.ReservationRepository
+	warehouses   out.WarehouseRepository
+	alerts       out.StockAlertRepository
+	notifier     out.StockAlertNotifier
+
+	idStrategy    item.IDStrategy
+	codePattern   *item.CodePattern
+	codeSequences out.CodeSequenceRepository
+	grants        out.ItemGrantRepository
+}
+
+func NewItemService(repo out.ItemRepository, client out.CategoryClient, opts ...ItemServiceOption) *itemService {
+	s := &itemService{repo: repo, client: client}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-func NewItemService(repo out.ItemRepository, client out.CategoryClient) *itemService {
-	return &itemService{repo: repo, client: client}
+func (s *itemService) CreateItem(ctx context.Context, newItem *item.Item) (*item.Item, error) {
+	return s.createItem(ctx, newItem, nil)
 }
 
-func (s *itemService) CreateItem(ctx context.Context, item *item.Item) (*item.Item, error) {
-	if item.Code == "" {
-		return nil, errors.New("invalid request body")
+// createItem validates and stores a new item. Category lookups are memoized in
+// categories when it is not nil.
+func (s *itemService) createItem(ctx context.Context, newItem *item.Item, categories map[int]bool) (*item.Item, error) {
+	if newItem.Code == "" && s.codePattern == nil {
+		return nil, fmt.Errorf("%w: code is required", item.ErrInvalidItem)
 	}
 
 	// calling the client to validate the category
-	isValid, err := s.client.IsAValidCategory(ctx, item.CategoryID)
-	if err != nil {
-		return nil, errors.New("client error")
+	if err := s.checkCategory(ctx, newItem.CategoryID, categories); err != nil {
+		return nil, err
 	}
-	if !isValid {
-		return nil, errors.New("invalid Category")
+
+	if newItem.Code == "" {
+		code, err := s.generateCode(ctx, newItem.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		newItem.Code = code
+	} else if s.repo.ItemExistsByCode(ctx, newItem.Code) {
+		return nil, item.ErrDuplicateCode
+	}
+	// the database assigns the ID
+	newItem.ID = 0
+	newItem.PublicID = s.idStrategy.NewID()
+	// new items are sold right away unless they are created as drafts
+	if newItem.Status != item.StatusDraft {
+		newItem.Status = item.StockStatus(newItem.Stock)
+	}
+	newItem.LowStock = false
+	newItem.CreatedAt = time.Now()
+	newItem.UpdatedAt = time.Now()
+	insert := func(ctx context.Context) (*item.Item, *item.Item, error) {
+		created, err := s.repo.CreateItem(ctx, newItem)
+		if err == nil && s.stock != nil && created.Stock > 0 {
+			err = s.recordOpeningReceipt(ctx, created)
+		}
+		return nil, created, err
 	}
+	if s.stock == nil || newItem.Stock <= 0 {
+		return s.recordChange(ctx, item.AuditCreated, insert)
+	}
+
+	var created *item.Item
+	err := s.repo.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = s.recordChange(ctx, item.AuditCreated, insert)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
 
-	if s.repo.ItemExistsByCode(ctx, item.Code) {
-		return nil, errors.New("item with this code already exists")
+func (s *itemService) checkCategory(ctx context.Context, categoryID int, categories map[int]bool) error {
+	isValid, cached := categories[categoryID]
+	if !cached {
+		var err error
+		isValid, err = s.client.IsAValidCategory(ctx, categoryID)
+		if err != nil {
+			return errors.New("client error")
+		}
+		if categories != nil {
+			categories[categoryID] = isValid
+		}
+	}
+	if !isValid {
+		return errors.New("invalid Category")
 	}
-	item.ID = generateID()
-	item.Status = determineStatus(item.Stock)
-	item.CreatedAt = time.Now()
-	item.UpdatedAt = time.Now()
-	return s.repo.CreateItem(ctx, item)
+	return nil
 }
 
 func (s *itemService) GetItemByID(ctx context.Context, id int) (*item.Item, error) {
@@ -58,69 +127,143 @@ func (s *itemService) GetItemByID(ctx context.Context, id int) (*item.Item, erro
 	return itm, nil
 }
 
+// UpdateItem replaces every editable field of the item, so zero values in
+// updatedItem are stored as given. The stock is not editable and is kept. A
+// non-zero Version must match the stored one.
 func (s *itemService) UpdateItem(ctx context.Context, updatedItem *item.Item) (*item.Item, error) {
 	existingItem, err := s.repo.GetItemByID(ctx, updatedItem.ID)
 	if err != nil {
 		return nil, err
 	}
 	if existingItem == nil {
-		return nil, errors.New("item not found")
+		return nil, item.ErrItemNotFound
 	}
+	if updatedItem.Version != 0 && updatedItem.Version != existingItem.Version {
+		return nil, item.ErrVersionConflict
+	}
+	return s.replaceItem(ctx, item.AuditUpdated, existingItem, updatedItem)
+}
 
-	// Retain original values if new values are not provided
-	if updatedItem.Title == "" {
-		updatedItem.Title = existingItem.Title
+// replaceItem stores updatedItem over existingItem, checking the category when
+// it changes. The change is audited as action.
+func (s *itemService) replaceItem(
+	ctx context.Context, action item.AuditAction, existingItem, updatedItem *item.Item,
+) (*item.Item, error) {
+	if err := s.authorizeItem(ctx, existingItem, accessChange); err != nil {
+		return nil, err
+	}
+	if existingItem.Status == item.StatusArchived {
+		return nil, item.ErrItemArchived
 	}
-	if updatedItem.Description == "" {
-		updatedItem.Description = existingItem.Description
+	if updatedItem.Code != existingItem.Code {
+		return nil, item.ErrCodeImmutable
 	}
-	if updatedItem.Price == 0 {
-		updatedItem.Price = existingItem.Price
+	if updatedItem.CategoryID != existingItem.CategoryID {
+		if err := s.checkCategory(ctx, updatedItem.CategoryID, nil); err != nil {
+			return nil, err
+		}
 	}
 
+	updatedItem.ID = existingItem.ID
+	// the stock is owned by the stock ledger, so the one given is ignored
+	updatedItem.Stock = existingItem.Stock
+	// the repository only writes if the item is still at the version read here
+	updatedItem.Version = existingItem.Version
+	updatedItem.LowStock = existingItem.LowStock
 	updatedItem.CreatedAt = existingItem.CreatedAt
 	updatedItem.UpdatedAt = time.Now()
 
-	result, err := s.repo.UpdateItem(ctx, updatedItem)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
+	return s.recordChange(ctx, action, func(ctx context.Context) (*item.Item, *item.Item, error) {
+		result, err := s.repo.UpdateItem(ctx, updatedItem)
+		return existingItem, result, err
+	})
 }
 
-func (s *itemService) DeleteItem(ctx context.Context, id int) (*item.Item, error) {
-	return s.repo.DeleteItem(ctx, id)
+// DeleteItem deletes the item if it is still at version; a zero version skips
+// the check.
+func (s *itemService) DeleteItem(ctx context.Context, id int, version int) (*item.Item, error) {
+	return s.recordChange(ctx, item.AuditDeleted, func(ctx context.Context) (*item.Item, *item.Item, error) {
+		if err := s.authorizeItemID(ctx, id, false, accessOwn); err != nil {
+			return nil, nil, err
+		}
+		return s.changeItem(ctx, id, false, func() (*item.Item, error) {
+			return s.repo.DeleteItem(ctx, id, version)
+		})
+	})
 }
 
-func (s *itemService) ListItems(ctx context.Context, status string, limit int, page int) ([]*item.Item, int, error) {
-	if status == "" {
-		status = "ACTIVE"
-	}
+// RestoreItem undoes the soft delete of an item. A non-zero version must match
+// the stored one.
+func (s *itemService) RestoreItem(ctx context.Context, id int, version int) (*item.Item, error) {
+	return s.recordChange(ctx, item.AuditRestored, func(ctx context.Context) (*item.Item, *item.Item, error) {
+		if err := s.authorizeItemID(ctx, id, true, accessOwn); err != nil {
+			return nil, nil, err
+		}
+		return s.changeItem(ctx, id, true, func() (*item.Item, error) {
+			return s.repo.RestoreItem(ctx, id, version)
+		})
+	})
+}
 
-	items, err := s.repo.ListItems(ctx, status, limit, page)
-	if err != nil {
-		return nil, 0, err
+// changeItem reads the item before running write, for the audit diff. The
+// read is skipped when no audit log is configured.
+func (s *itemService) changeItem(
+	ctx context.Context, id int, includeDeleted bool, write func() (*item.Item, error),
+) (*item.Item, *item.Item, error) {
+	var before *item.Item
+	if s.audit != nil {
+		var err error
+		if before, err = s.repo.FindItem(ctx, id, includeDeleted); err != nil {
+			return nil, nil, err
+		}
 	}
+	after, err := write()
+	return before, after, err
+}
 
-	totalPages := (len(items.Data) + limit - 1) / limit
-	var result []*item.Item
-	for _, itm := range items.Data {
-		result = append(result, &itm)
+func (s *itemService) ListItems(ctx context.Context, query item.ListQuery) (*item.Response, error) {
+	if query.Status == "" {
+		query.Status = string(item.StatusActive)
+	}
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	if query.IncludeDeleted && !user.IsAdmin(ctx) {
+		return nil, fmt.Errorf("%w: only admins can list deleted items", item.ErrForbidden)
+	}
+	if err := resolveOwner(ctx, &query); err != nil {
+		return nil, err
 	}
-	return result, totalPages, nil
-}
 
-func (s *itemService) ItemExistsByCode(_ context.Context, _ string) bool {
-	return true
+	return s.repo.ListItems(ctx, query)
 }
 
-func generateID() int {
-	return 0
+// ExportItems calls fn for every item matching the filters of query, without
+// pagination. Unlike ListItems, an empty status exports items of any status.
+func (s *itemService) ExportItems(ctx context.Context, query item.ListQuery, fn func(itm *item.Item) error) error {
+	if err := query.ValidateFilters(); err != nil {
+		return err
+	}
+	if err := resolveOwner(ctx, &query); err != nil {
+		return err
+	}
+	return s.repo.IterateItems(ctx, query, fn)
 }
 
-func determineStatus(stock int) string {
-	if stock > 0 {
-		return "ACTIVE"
+// SearchItems searches the ACTIVE items unless query asks for another status,
+// like ListItems.
+func (s *itemService) SearchItems(ctx context.Context, query item.SearchQuery) (*item.SearchResponse, error) {
+	if query.Status == "" {
+		query.Status = string(item.StatusActive)
+	}
+	if err := query.Validate(); err != nil {
+		return nil, err
 	}
-	return "INACTIVE"
+	return s.repo.SearchItems(ctx, query)
+}
+
+// ItemExistsByCode also counts soft-deleted items, whose codes cannot be
+// reused.
+func (s *itemService) ItemExistsByCode(ctx context.Context, code string) bool {
+	return s.repo.ItemExistsByCode(ctx, code)
 }
@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out/mocks"
+)
+
+func TestItemService_ListItems_IncludeDeletedRequiresAdmin(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient))
+	query := item.ListQuery{Status: "ACTIVE", Limit: 10, Page: 1, IncludeDeleted: true}
+
+	_, err := service.ListItems(context.Background(), query)
+	assert.ErrorIs(t, err, item.ErrForbidden)
+	mockRepo.AssertNotCalled(t, "ListItems", mock.Anything, mock.Anything)
+
+	mockRepo.On("ListItems", mock.Anything, query).Return(&item.Response{}, nil)
+	adminCtx := user.WithPrincipal(context.Background(), &user.Principal{Role: user.RoleAdmin})
+	_, err = service.ListItems(adminCtx, query)
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestItemService_PurgeDeletedItems(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient))
+
+	retention := 48 * time.Hour
+	before := time.Now().Add(-retention)
+	mockRepo.On("PurgeDeletedItems", mock.Anything, mock.MatchedBy(func(cutoff time.Time) bool {
+		return !cutoff.Before(before) && cutoff.Before(before.Add(time.Minute))
+	})).Return(3, nil).Once()
+
+	service.purgeDeletedItems(context.Background(), retention)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestItemService_RunPurge_StopsWithContext(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient))
+	mockRepo.On("PurgeDeletedItems", mock.Anything, mock.Anything).Return(0, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	done := make(chan struct{})
+	go func() {
+		service.RunPurge(ctx, time.Hour, time.Hour)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RunPurge did not stop")
+	}
+	mockRepo.AssertNumberOfCalls(t, "PurgeDeletedItems", 1)
+}
@@ -0,0 +1,35 @@
+package application
+
+import (
+	"context"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+// TransitionItem moves the item to the status to, if the state machine allows
+// it from its current status. A non-zero version must match the stored one.
+func (s *itemService) TransitionItem(ctx context.Context, id int, to item.Status, version int) (*item.Item, error) {
+	return s.recordChange(ctx, item.AuditTransitioned, func(ctx context.Context) (*item.Item, *item.Item, error) {
+		existing, err := s.repo.FindItem(ctx, id, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		if existing == nil {
+			return nil, nil, item.ErrItemNotFound
+		}
+		if version != 0 && version != existing.Version {
+			return nil, nil, item.ErrVersionConflict
+		}
+		if err := s.authorizeItem(ctx, existing, accessChange); err != nil {
+			return nil, nil, err
+		}
+
+		status, err := existing.Status.Transition(to, existing.Stock)
+		if err != nil {
+			return nil, nil, err
+		}
+		// the version read above guards against a concurrent change of status
+		updated, err := s.repo.UpdateStatus(ctx, id, status, existing.Version)
+		return existing, updated, err
+	})
+}
@@ -0,0 +1,105 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out/mocks"
+)
+
+func TestItemService_TransitionItem(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockAudit := new(mocks.AuditRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithAuditLog(mockAudit))
+
+	existing := &item.Item{ID: 1, Code: "A1", Stock: 0, Status: item.StatusDraft, Version: 2}
+	mockRepo.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockRepo.On("FindItem", mock.Anything, 1, false).Return(existing, nil)
+	mockRepo.On("UpdateStatus", mock.Anything, 1, item.StatusOutOfStock, 2).
+		Return(&item.Item{ID: 1, Code: "A1", Status: item.StatusOutOfStock, Version: 3}, nil)
+	mockAudit.On("CreateAuditEntry", mock.Anything, mock.MatchedBy(func(entry *item.AuditEntry) bool {
+		return entry.Action == item.AuditTransitioned && entry.Version == 3 &&
+			assert.ObjectsAreEqual([]item.FieldChange{{Field: "status", Before: "DRAFT", After: "OUT_OF_STOCK"}}, entry.Changes)
+	})).Return(nil)
+
+	published, err := service.TransitionItem(auditContext(7, "req-1"), 1, item.StatusActive, 0)
+	require.NoError(t, err)
+	assert.Equal(t, item.StatusOutOfStock, published.Status)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestItemService_TransitionItem_Rejected(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient))
+
+	mockRepo.On("FindItem", mock.Anything, 1, false).Return(&item.Item{ID: 1, Status: item.StatusArchived, Version: 4}, nil)
+	mockRepo.On("FindItem", mock.Anything, 2, false).Return(nil, nil)
+
+	_, err := service.TransitionItem(context.Background(), 1, item.StatusActive, 0)
+	assert.ErrorIs(t, err, item.ErrInvalidTransition)
+
+	_, err = service.TransitionItem(context.Background(), 1, item.StatusDiscontinued, 3)
+	assert.ErrorIs(t, err, item.ErrVersionConflict)
+
+	_, err = service.TransitionItem(context.Background(), 2, item.StatusArchived, 0)
+	assert.ErrorIs(t, err, item.ErrItemNotFound)
+	mockRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestItemService_CreateItem_Status(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested item.Status
+		stock     int
+		want      item.Status
+	}{
+		{name: "in stock", stock: 3, want: item.StatusActive},
+		{name: "without stock", stock: 0, want: item.StatusOutOfStock},
+		{name: "draft", requested: item.StatusDraft, stock: 3, want: item.StatusDraft},
+		{name: "archived is not an initial status", requested: item.StatusArchived, stock: 3, want: item.StatusActive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.ItemRepository)
+			mockClient := new(mocks.CategoryClient)
+			service := NewItemService(mockRepo, mockClient)
+
+			mockClient.On("IsAValidCategory", mock.Anything, 1).Return(true, nil)
+			mockRepo.On("ItemExistsByCode", mock.Anything, "A1").Return(false)
+			mockRepo.On("CreateItem", mock.Anything, mock.Anything).Return(func(_ context.Context, itm *item.Item) (*item.Item, error) {
+				return itm, nil
+			})
+
+			created, err := service.CreateItem(context.Background(), &item.Item{Code: "A1", CategoryID: 1, Stock: tt.stock, Status: tt.requested})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, created.Status)
+		})
+	}
+}
+
+func TestItemService_ArchivedItemIsFinal(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockStock := new(mocks.StockMovementRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithStockLedger(mockStock))
+
+	archived := &item.Item{ID: 1, Code: "A1", CategoryID: 2, Stock: 5, Status: item.StatusArchived, Version: 4}
+	mockRepo.On("GetItemByID", mock.Anything, 1).Return(archived, nil)
+	mockRepo.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockRepo.On("AdjustStock", mock.Anything, 1, 3, 0).Return(&item.Item{ID: 1, Stock: 8, Status: item.StatusArchived}, nil)
+
+	_, err := service.UpdateItem(context.Background(), &item.Item{ID: 1, Code: "A1", CategoryID: 2, Stock: 5, Title: "New"})
+	assert.ErrorIs(t, err, item.ErrItemArchived)
+	_, err = service.PatchItem(context.Background(), 1, 0, item.MergePatch, []byte(`{"title": "New"}`))
+	assert.ErrorIs(t, err, item.ErrItemArchived)
+	_, err = service.RecordStockMovement(context.Background(), 1, &item.StockMovement{
+		Type: item.MovementReceipt, Quantity: 3, Reason: "po 8",
+	})
+	assert.ErrorIs(t, err, item.ErrItemArchived)
+
+	mockRepo.AssertNotCalled(t, "UpdateItem", mock.Anything, mock.Anything)
+	mockStock.AssertNotCalled(t, "CreateStockMovement", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+	"github.com/teamcubation/go-items-challenge/pkg/log"
+)
+
+var errStockLedgerDisabled = errors.New("the stock ledger is not enabled")
+
+// WithStockLedger records stock movements in movements. The initial stock of
+// new items is recorded as a receipt.
+func WithStockLedger(movements out.StockMovementRepository) ItemServiceOption {
+	return func(s *itemService) {
+		s.stock = movements
+	}
+}
+
+// RecordStockMovement applies the movement to the stock of the item and adds
+// it to the ledger, in one transaction.
+func (s *itemService) RecordStockMovement(
+	ctx context.Context, id int, movement *item.StockMovement,
+) (*item.StockMovement, error) {
+	if s.stock == nil {
+		return nil, errStockLedgerDisabled
+	}
+	if err := movement.Validate(); err != nil {
+		return nil, err
+	}
+	if movement.Type == item.MovementTransferOut || movement.Type == item.MovementTransferIn {
+		return nil, fmt.Errorf("%w: transfers are recorded with TransferStock", item.ErrInvalidMovement)
+	}
+
+	err := s.repo.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.authorizeItemID(ctx, id, false, accessChange); err != nil {
+			return err
+		}
+		return s.applyMovement(ctx, id, movement, 0)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+// applyMovement adjusts the stock of the item and of its warehouse by the
+// movement, and its reserved stock by reservedDelta, then adds the movement to
+// the ledger. It must run in a transaction.
+func (s *itemService) applyMovement(ctx context.Context, id int, movement *item.StockMovement, reservedDelta int) error {
+	_, err := s.recordChange(ctx, item.AuditStockMoved, func(ctx context.Context) (*item.Item, *item.Item, error) {
+		return s.changeItem(ctx, id, false, func() (*item.Item, error) {
+			if err := s.moveWarehouseStock(ctx, id, movement, reservedDelta); err != nil {
+				return nil, err
+			}
+			updated, err := s.repo.AdjustStock(ctx, id, movement.Delta, reservedDelta)
+			if err != nil {
+				return nil, err
+			}
+			// the transaction undoes the adjustment
+			if updated.Status == item.StatusArchived {
+				return nil, item.ErrItemArchived
+			}
+			stampMovement(ctx, movement, updated)
+			return updated, s.stock.CreateStockMovement(ctx, movement)
+		})
+	})
+	return err
+}
+
+// ListStockMovements lists the stock movements of an item, newest first.
+func (s *itemService) ListStockMovements(ctx context.Context, query item.MovementQuery) (*item.MovementResponse, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	if s.stock == nil {
+		return nil, errStockLedgerDisabled
+	}
+	return s.stock.ListStockMovements(ctx, query)
+}
+
+// recordOpeningReceipt records the stock of a new item as a receipt into the
+// default warehouse.
+func (s *itemService) recordOpeningReceipt(ctx context.Context, created *item.Item) error {
+	movement := &item.StockMovement{
+		Type:     item.MovementReceipt,
+		Quantity: created.Stock,
+		Delta:    created.Stock,
+		Reason:   "initial stock",
+	}
+	if err := s.moveWarehouseStock(ctx, created.ID, movement, 0); err != nil {
+		return err
+	}
+	stampMovement(ctx, movement, created)
+	return s.stock.CreateStockMovement(ctx, movement)
+}
+
+// stampMovement fills the fields of movement known once it has been applied to
+// itm.
+func stampMovement(ctx context.Context, movement *item.StockMovement, itm *item.Item) {
+	movement.ItemID = itm.ID
+	movement.StockAfter = itm.Stock
+	movement.ActorID = user.UserIDFromContext(ctx)
+	movement.RequestID = log.RequestID(ctx)
+	movement.CreatedAt = time.Now()
+}
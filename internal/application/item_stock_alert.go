@@ -0,0 +1,231 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+	"github.com/teamcubation/go-items-challenge/internal/utils"
+	"github.com/teamcubation/go-items-challenge/pkg/log"
+)
+
+const (
+	// alertBatchSize is the number of stock alerts claimed at once.
+	alertBatchSize = 100
+	// alertLease is how long a dispatcher has to send the alerts it claimed
+	// before another one may claim them again.
+	alertLease = 5 * time.Minute
+)
+
+var errStockAlertsDisabled = errors.New("stock alerts are not enabled")
+
+// WithStockAlerts keeps the low stock flag of every item up to date with the
+// reorder points of the items and of their categories, stored in alerts, and
+// queues an alert each time an item falls to its reorder point.
+// RunStockAlertDispatcher sends the queued alerts to notifier.
+func WithStockAlerts(alerts out.StockAlertRepository, notifier out.StockAlertNotifier) ItemServiceOption {
+	return func(s *itemService) {
+		s.alerts = alerts
+		s.notifier = notifier
+	}
+}
+
+// trackLowStock updates the low stock flag of itm and queues an alert when the
+// item falls to its reorder point. Deleted items are left as they are. It must
+// run in a transaction.
+func (s *itemService) trackLowStock(ctx context.Context, itm *item.Item) error {
+	if s.alerts == nil || itm == nil || itm.DeletedAt.Valid {
+		return nil
+	}
+	reorderPoint, err := s.reorderPoint(ctx, itm)
+	if err != nil {
+		return err
+	}
+	lowStock := item.IsLowStock(itm.Stock, reorderPoint)
+	crossed, err := s.repo.SetLowStock(ctx, itm.ID, lowStock)
+	if err != nil {
+		return err
+	}
+	if crossed {
+		itm.Version++
+	}
+	itm.LowStock = lowStock
+	// an item already low was alerted when it crossed, and is not again until
+	// it is replenished
+	if !crossed || !lowStock {
+		return nil
+	}
+	return s.alerts.CreateStockAlert(ctx, &item.StockAlert{
+		ItemID:       itm.ID,
+		Code:         itm.Code,
+		Stock:        itm.Stock,
+		ReorderPoint: *reorderPoint,
+		CreatedAt:    time.Now(),
+	})
+}
+
+// reorderPoint returns the reorder point in effect for itm: its own, or else
+// the one of its category.
+func (s *itemService) reorderPoint(ctx context.Context, itm *item.Item) (*int, error) {
+	if itm.ReorderPoint != nil {
+		return itm.ReorderPoint, nil
+	}
+	point, err := s.alerts.GetCategoryReorderPoint(ctx, itm.CategoryID)
+	if err != nil || point == nil {
+		return nil, err
+	}
+	return &point.ReorderPoint, nil
+}
+
+func (s *itemService) GetCategoryReorderPoint(ctx context.Context, categoryID int) (*item.CategoryReorderPoint, error) {
+	if s.alerts == nil {
+		return nil, errStockAlertsDisabled
+	}
+	point, err := s.alerts.GetCategoryReorderPoint(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if point == nil {
+		return nil, item.ErrReorderPointNotFound
+	}
+	return point, nil
+}
+
+// SetCategoryReorderPoint sets the default reorder point of a category and
+// updates the low stock flag of its items that have no reorder point of their
+// own.
+func (s *itemService) SetCategoryReorderPoint(ctx context.Context, point *item.CategoryReorderPoint) (*item.CategoryReorderPoint, error) {
+	if s.alerts == nil {
+		return nil, errStockAlertsDisabled
+	}
+	if err := utils.ValidateStruct(point); err != nil {
+		return nil, fmt.Errorf("%w: %v", item.ErrInvalidReorderPoint, err)
+	}
+	point.UpdatedAt = time.Now()
+
+	err := s.repo.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.alerts.SetCategoryReorderPoint(ctx, point); err != nil {
+			return err
+		}
+		return s.refreshCategoryLowStock(ctx, point.CategoryID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return point, nil
+}
+
+// DeleteCategoryReorderPoint removes the default reorder point of a category.
+// Its items without a reorder point of their own are no longer low on stock.
+func (s *itemService) DeleteCategoryReorderPoint(ctx context.Context, categoryID int) error {
+	if s.alerts == nil {
+		return errStockAlertsDisabled
+	}
+	return s.repo.WithinTransaction(ctx, func(ctx context.Context) error {
+		point, err := s.alerts.GetCategoryReorderPoint(ctx, categoryID)
+		if err != nil {
+			return err
+		}
+		if point == nil {
+			return item.ErrReorderPointNotFound
+		}
+		if err := s.alerts.DeleteCategoryReorderPoint(ctx, categoryID); err != nil {
+			return err
+		}
+		return s.refreshCategoryLowStock(ctx, categoryID)
+	})
+}
+
+// refreshCategoryLowStock re-evaluates the low stock flag of the items of the
+// category that follow its reorder point.
+func (s *itemService) refreshCategoryLowStock(ctx context.Context, categoryID int) error {
+	// the items are collected first, as no other query can run on the
+	// connection while the rows are open
+	var items []*item.Item
+	err := s.repo.IterateItems(ctx, item.ListQuery{CategoryID: &categoryID}, func(itm *item.Item) error {
+		if itm.ReorderPoint == nil {
+			items = append(items, itm)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, itm := range items {
+		if err := s.trackLowStock(ctx, itm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunStockAlertDispatcher sends, every interval, the queued stock alerts to the
+// notifier, until ctx is done. Alerts are sent at least once: a failed alert
+// is retried on the next run, and an alert whose dispatcher died is claimed
+// again once its lease ends.
+func (s *itemService) RunStockAlertDispatcher(ctx context.Context, interval time.Duration) {
+	if s.alerts == nil || s.notifier == nil {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.dispatchStockAlerts(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *itemService) dispatchStockAlerts(ctx context.Context) {
+	logger := log.GetFromContext(ctx)
+	total := 0
+	for {
+		sent, err := s.sendStockAlertBatch(ctx)
+		total += sent
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Errorf("Error sending stock alerts: %v", err)
+			}
+			break
+		}
+		if sent < alertBatchSize {
+			break
+		}
+	}
+	if total > 0 {
+		logger.Infof("Sent %d stock alerts", total)
+	}
+}
+
+// sendStockAlertBatch leases one batch of alerts and sends them, outside of
+// any transaction, marking each one as sent once delivered. When an alert
+// fails, it and the rest of the batch are released for the next run.
+func (s *itemService) sendStockAlertBatch(ctx context.Context) (int, error) {
+	alerts, err := s.alerts.ClaimPendingStockAlerts(ctx, alertBatchSize, time.Now().Add(alertLease))
+	if err != nil {
+		return 0, err
+	}
+	for i := range alerts {
+		if err := s.notifier.NotifyLowStock(ctx, &alerts[i]); err != nil {
+			unsent := make([]int64, 0, len(alerts)-i)
+			for _, alert := range alerts[i:] {
+				unsent = append(unsent, alert.ID)
+			}
+			if releaseErr := s.alerts.ReleaseStockAlerts(ctx, unsent); releaseErr != nil {
+				log.GetFromContext(ctx).Errorf("Error releasing stock alerts: %v", releaseErr)
+			}
+			return i, err
+		}
+		if err := s.alerts.MarkStockAlertSent(ctx, alerts[i].ID, time.Now()); err != nil {
+			return i, err
+		}
+	}
+	return len(alerts), nil
+}
@@ -0,0 +1,157 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out/mocks"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestItemService_RecordStockMovement_RaisesLowStockAlert(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockStock := new(mocks.StockMovementRepository)
+	mockAlerts := new(mocks.StockAlertRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithStockLedger(mockStock), WithStockAlerts(mockAlerts, nil))
+
+	mockRepo.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockRepo.On("AdjustStock", mock.Anything, 1, -6, 0).
+		Return(&item.Item{ID: 1, Code: "A1", CategoryID: 2, Stock: 4, ReorderPoint: intPtr(5)}, nil)
+	mockRepo.On("SetLowStock", mock.Anything, 1, true).Return(true, nil)
+	mockStock.On("CreateStockMovement", mock.Anything, mock.Anything).Return(nil)
+	mockAlerts.On("CreateStockAlert", mock.Anything, mock.MatchedBy(func(alert *item.StockAlert) bool {
+		return alert.ItemID == 1 && alert.Stock == 4 && alert.ReorderPoint == 5
+	})).Return(nil)
+
+	_, err := service.RecordStockMovement(context.Background(), 1, &item.StockMovement{
+		Type: item.MovementSale, Quantity: 6, Reason: "order 9",
+	})
+	require.NoError(t, err)
+	mockAlerts.AssertExpectations(t)
+	mockAlerts.AssertNotCalled(t, "GetCategoryReorderPoint", mock.Anything, mock.Anything)
+}
+
+func TestItemService_TrackLowStock_BumpsVersion(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockAlerts := new(mocks.StockAlertRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithStockAlerts(mockAlerts, nil))
+
+	mockRepo.On("SetLowStock", mock.Anything, 1, false).Return(true, nil)
+
+	// a replenished item is no longer low on stock, which changes its ETag
+	itm := &item.Item{ID: 1, Stock: 9, ReorderPoint: intPtr(5), LowStock: true, Version: 3}
+	require.NoError(t, service.trackLowStock(context.Background(), itm))
+	assert.False(t, itm.LowStock)
+	assert.Equal(t, 4, itm.Version)
+	mockAlerts.AssertNotCalled(t, "CreateStockAlert", mock.Anything, mock.Anything)
+}
+
+func TestItemService_RecordStockMovement_AlreadyLowStock(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockStock := new(mocks.StockMovementRepository)
+	mockAlerts := new(mocks.StockAlertRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithStockLedger(mockStock), WithStockAlerts(mockAlerts, nil))
+
+	mockRepo.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockRepo.On("AdjustStock", mock.Anything, 1, -1, 0).
+		Return(&item.Item{ID: 1, CategoryID: 2, Stock: 3, LowStock: true}, nil)
+	mockAlerts.On("GetCategoryReorderPoint", mock.Anything, 2).Return(&item.CategoryReorderPoint{CategoryID: 2, ReorderPoint: 5}, nil)
+	mockRepo.On("SetLowStock", mock.Anything, 1, true).Return(false, nil)
+	mockStock.On("CreateStockMovement", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := service.RecordStockMovement(context.Background(), 1, &item.StockMovement{
+		Type: item.MovementSale, Quantity: 1, Reason: "order 10",
+	})
+	require.NoError(t, err)
+	mockAlerts.AssertNotCalled(t, "CreateStockAlert", mock.Anything, mock.Anything)
+}
+
+func TestItemService_RecordStockMovement_Replenished(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockStock := new(mocks.StockMovementRepository)
+	mockAlerts := new(mocks.StockAlertRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithStockLedger(mockStock), WithStockAlerts(mockAlerts, nil))
+
+	mockRepo.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockRepo.On("AdjustStock", mock.Anything, 1, 20, 0).
+		Return(&item.Item{ID: 1, Stock: 23, LowStock: true, ReorderPoint: intPtr(5)}, nil)
+	mockRepo.On("SetLowStock", mock.Anything, 1, false).Return(true, nil)
+	mockStock.On("CreateStockMovement", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := service.RecordStockMovement(context.Background(), 1, &item.StockMovement{
+		Type: item.MovementReceipt, Quantity: 20, Reason: "po 3",
+	})
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockAlerts.AssertNotCalled(t, "CreateStockAlert", mock.Anything, mock.Anything)
+}
+
+func TestItemService_SetCategoryReorderPoint(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockAlerts := new(mocks.StockAlertRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithStockAlerts(mockAlerts, nil))
+
+	point := &item.CategoryReorderPoint{CategoryID: 2, ReorderPoint: 5}
+	mockRepo.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockAlerts.On("SetCategoryReorderPoint", mock.Anything, point).Return(nil)
+	mockAlerts.On("GetCategoryReorderPoint", mock.Anything, 2).Return(point, nil)
+	mockRepo.On("IterateItems", mock.Anything, mock.MatchedBy(func(query item.ListQuery) bool {
+		return query.CategoryID != nil && *query.CategoryID == 2
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func(itm *item.Item) error)
+		for _, itm := range []*item.Item{
+			{ID: 1, CategoryID: 2, Stock: 2},
+			{ID: 2, CategoryID: 2, Stock: 2, ReorderPoint: intPtr(1)},
+			{ID: 3, CategoryID: 2, Stock: 9},
+		} {
+			require.NoError(t, fn(itm))
+		}
+	}).Return(nil)
+	mockRepo.On("SetLowStock", mock.Anything, 1, true).Return(true, nil)
+	mockRepo.On("SetLowStock", mock.Anything, 3, false).Return(false, nil)
+	mockAlerts.On("CreateStockAlert", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := service.SetCategoryReorderPoint(context.Background(), point)
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockAlerts.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "SetLowStock", mock.Anything, 2, mock.Anything)
+
+	_, err = service.SetCategoryReorderPoint(context.Background(), &item.CategoryReorderPoint{CategoryID: 2, ReorderPoint: -1})
+	assert.ErrorIs(t, err, item.ErrInvalidReorderPoint)
+}
+
+func TestItemService_SendStockAlertBatch(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockAlerts := new(mocks.StockAlertRepository)
+	mockNotifier := new(mocks.StockAlertNotifier)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithStockAlerts(mockAlerts, mockNotifier))
+
+	mockAlerts.On("ClaimPendingStockAlerts", mock.Anything, alertBatchSize, mock.Anything).
+		Return([]item.StockAlert{{ID: 1, ItemID: 1}, {ID: 2, ItemID: 2}, {ID: 3, ItemID: 3}}, nil)
+	mockNotifier.On("NotifyLowStock", mock.Anything, mock.MatchedBy(func(alert *item.StockAlert) bool {
+		return alert.ID == 1
+	})).Return(nil)
+	mockNotifier.On("NotifyLowStock", mock.Anything, mock.MatchedBy(func(alert *item.StockAlert) bool {
+		return alert.ID == 2
+	})).Return(errors.New("webhook down"))
+	mockAlerts.On("MarkStockAlertSent", mock.Anything, int64(1), mock.Anything).Return(nil)
+	mockAlerts.On("ReleaseStockAlerts", mock.Anything, []int64{2, 3}).Return(nil)
+
+	sent, err := service.sendStockAlertBatch(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 1, sent)
+	mockAlerts.AssertNumberOfCalls(t, "MarkStockAlertSent", 1)
+	mockAlerts.AssertCalled(t, "ReleaseStockAlerts", mock.Anything, []int64{2, 3})
+	mockNotifier.AssertNumberOfCalls(t, "NotifyLowStock", 2)
+	// the alerts are not sent in a transaction
+	mockRepo.AssertNotCalled(t, "WithinTransaction", mock.Anything, mock.Anything)
+}
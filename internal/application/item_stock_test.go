@@ -0,0 +1,104 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out/mocks"
+)
+
+func TestItemService_RecordStockMovement(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockStock := new(mocks.StockMovementRepository)
+	mockAudit := new(mocks.AuditRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithAuditLog(mockAudit), WithStockLedger(mockStock))
+
+	mockRepo.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockRepo.On("FindItem", mock.Anything, 1, false).Return(&item.Item{ID: 1, Stock: 5, Status: "ACTIVE", Version: 2}, nil)
+	mockRepo.On("AdjustStock", mock.Anything, 1, -5, 0).Return(&item.Item{ID: 1, Stock: 0, Status: "INACTIVE", Version: 3}, nil)
+	mockStock.On("CreateStockMovement", mock.Anything, mock.Anything).Return(nil)
+	mockAudit.On("CreateAuditEntry", mock.Anything, mock.MatchedBy(func(entry *item.AuditEntry) bool {
+		return entry.Action == item.AuditStockMoved && len(entry.Changes) == 2
+	})).Return(nil)
+
+	movement, err := service.RecordStockMovement(auditContext(7, "req-1"), 1, &item.StockMovement{
+		Type: item.MovementSale, Quantity: 5, Reason: "order 42",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, movement.ItemID)
+	assert.Equal(t, -5, movement.Delta)
+	assert.Equal(t, 0, movement.StockAfter)
+	assert.Equal(t, 7, movement.ActorID)
+	assert.Equal(t, "req-1", movement.RequestID)
+	mockStock.AssertCalled(t, "CreateStockMovement", mock.Anything, movement)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestItemService_RecordStockMovement_InsufficientStock(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockStock := new(mocks.StockMovementRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithStockLedger(mockStock))
+
+	mockRepo.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockRepo.On("AdjustStock", mock.Anything, 1, -6, 0).Return(nil, item.ErrInsufficientStock)
+
+	_, err := service.RecordStockMovement(context.Background(), 1, &item.StockMovement{
+		Type: item.MovementWriteOff, Quantity: 6, Reason: "damaged",
+	})
+	assert.ErrorIs(t, err, item.ErrInsufficientStock)
+	mockStock.AssertNotCalled(t, "CreateStockMovement", mock.Anything, mock.Anything)
+}
+
+func TestItemService_RecordStockMovement_Invalid(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithStockLedger(new(mocks.StockMovementRepository)))
+
+	_, err := service.RecordStockMovement(context.Background(), 1, &item.StockMovement{Type: item.MovementSale, Quantity: 1})
+	assert.ErrorIs(t, err, item.ErrInvalidMovement)
+	mockRepo.AssertNotCalled(t, "WithinTransaction", mock.Anything, mock.Anything)
+}
+
+func TestItemService_CreateItem_RecordsOpeningReceipt(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockClient := new(mocks.CategoryClient)
+	mockStock := new(mocks.StockMovementRepository)
+	service := NewItemService(mockRepo, mockClient, WithStockLedger(mockStock))
+
+	newItem := &item.Item{Code: "A1", CategoryID: 1, Stock: 8}
+	mockClient.On("IsAValidCategory", mock.Anything, 1).Return(true, nil)
+	mockRepo.On("ItemExistsByCode", mock.Anything, "A1").Return(false)
+	mockRepo.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockRepo.On("CreateItem", mock.Anything, newItem).Return(func(_ context.Context, itm *item.Item) (*item.Item, error) {
+		return itm, nil
+	})
+	mockStock.On("CreateStockMovement", mock.Anything, mock.MatchedBy(func(movement *item.StockMovement) bool {
+		return movement.Type == item.MovementReceipt && movement.Delta == 8 && movement.StockAfter == 8 &&
+			movement.ItemID == newItem.ID
+	})).Return(nil)
+
+	_, err := service.CreateItem(context.Background(), newItem)
+	require.NoError(t, err)
+	mockStock.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestItemService_ListStockMovements(t *testing.T) {
+	mockStock := new(mocks.StockMovementRepository)
+	service := NewItemService(new(mocks.ItemRepository), new(mocks.CategoryClient), WithStockLedger(mockStock))
+
+	query := item.MovementQuery{ItemID: 1, Type: item.MovementSale, Limit: 20, Page: 1}
+	expected := &item.MovementResponse{TotalPages: 1, Data: []item.StockMovement{{ID: 1, ItemID: 1}}}
+	mockStock.On("ListStockMovements", mock.Anything, query).Return(expected, nil)
+
+	movements, err := service.ListStockMovements(context.Background(), query)
+	require.NoError(t, err)
+	assert.Equal(t, expected, movements)
+
+	_, err = service.ListStockMovements(context.Background(), item.MovementQuery{ItemID: 1, Type: "theft", Limit: 20, Page: 1})
+	assert.ErrorIs(t, err, item.ErrInvalidQuery)
+}
@@ -0,0 +1,143 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/domain/warehouse"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+)
+
+var errWarehousesDisabled = errors.New("warehouses are not enabled")
+
+// WithWarehouses keeps the stock of every item per warehouse, in warehouses.
+// Movements without a warehouse use the default one. It requires the stock
+// ledger.
+func WithWarehouses(warehouses out.WarehouseRepository) ItemServiceOption {
+	return func(s *itemService) {
+		s.warehouses = warehouses
+	}
+}
+
+// moveWarehouseStock applies the movement, and reservedDelta, to the stock
+// level of its warehouse, setting the default warehouse when the movement has
+// none. Movements without a warehouse hold no reserved stock of the default
+// one, as they come from reservations made before warehouses.
+func (s *itemService) moveWarehouseStock(ctx context.Context, itemID int, movement *item.StockMovement, reservedDelta int) error {
+	if s.warehouses == nil {
+		if movement.WarehouseID != 0 {
+			return fmt.Errorf("%w: %v", item.ErrInvalidMovement, errWarehousesDisabled)
+		}
+		return nil
+	}
+	if movement.WarehouseID == 0 {
+		reservedDelta = 0
+	}
+	if err := s.resolveWarehouse(ctx, &movement.WarehouseID); err != nil {
+		return err
+	}
+	_, err := s.warehouses.AdjustStockLevel(ctx, itemID, movement.WarehouseID, movement.Delta, reservedDelta)
+	return err
+}
+
+// resolveWarehouse checks that the warehouse exists, replacing a zero ID with
+// the default warehouse.
+func (s *itemService) resolveWarehouse(ctx context.Context, id *int) error {
+	var (
+		w   *warehouse.Warehouse
+		err error
+	)
+	if *id == 0 {
+		w, err = s.warehouses.GetDefaultWarehouse(ctx)
+	} else {
+		w, err = s.warehouses.GetWarehouse(ctx, *id)
+	}
+	if err != nil {
+		return err
+	}
+	if w == nil {
+		return fmt.Errorf("%w: %d", warehouse.ErrWarehouseNotFound, *id)
+	}
+	*id = w.ID
+	return nil
+}
+
+// TransferStock moves stock of the item between two warehouses in one
+// transaction, recording a transfer_out and a transfer_in movement. The total
+// stock of the item does not change.
+func (s *itemService) TransferStock(ctx context.Context, id int, transfer *item.StockTransfer) (*item.StockTransfer, error) {
+	if s.stock == nil || s.warehouses == nil {
+		return nil, errWarehousesDisabled
+	}
+	if err := transfer.Validate(); err != nil {
+		return nil, err
+	}
+	transfer.ID = uuid.New().String()
+	transfer.ItemID = id
+
+	err := s.repo.WithinTransaction(ctx, func(ctx context.Context) error {
+		itm, err := s.repo.FindItem(ctx, id, false)
+		if err != nil {
+			return err
+		}
+		if itm == nil {
+			return item.ErrItemNotFound
+		}
+		if err := s.authorizeItem(ctx, itm, accessChange); err != nil {
+			return err
+		}
+		if itm.Status == item.StatusArchived {
+			return item.ErrItemArchived
+		}
+		legs := []struct {
+			movementType item.MovementType
+			warehouseID  int
+		}{
+			{item.MovementTransferOut, transfer.FromWarehouseID},
+			{item.MovementTransferIn, transfer.ToWarehouseID},
+		}
+		for _, leg := range legs {
+			movement := &item.StockMovement{
+				Type:        leg.movementType,
+				Quantity:    transfer.Quantity,
+				Reason:      transfer.Reason,
+				WarehouseID: leg.warehouseID,
+				TransferID:  transfer.ID,
+			}
+			if err := movement.Validate(); err != nil {
+				return err
+			}
+			if err := s.moveWarehouseStock(ctx, id, movement, 0); err != nil {
+				return err
+			}
+			stampMovement(ctx, movement, itm)
+			if err := s.stock.CreateStockMovement(ctx, movement); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// ListStockLevels returns the stock of the item in each warehouse holding or
+// having held it.
+func (s *itemService) ListStockLevels(ctx context.Context, id int) ([]item.StockLevel, error) {
+	if s.warehouses == nil {
+		return nil, errWarehousesDisabled
+	}
+	itm, err := s.repo.FindItem(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	if itm == nil {
+		return nil, item.ErrItemNotFound
+	}
+	return s.warehouses.ListStockLevels(ctx, id)
+}
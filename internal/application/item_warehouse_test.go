@@ -0,0 +1,132 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/domain/warehouse"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out/mocks"
+)
+
+func TestItemService_RecordStockMovement_DefaultWarehouse(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockStock := new(mocks.StockMovementRepository)
+	mockWarehouses := new(mocks.WarehouseRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithStockLedger(mockStock), WithWarehouses(mockWarehouses))
+
+	mockRepo.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockWarehouses.On("GetDefaultWarehouse", mock.Anything).Return(&warehouse.Warehouse{ID: 1, Code: "MAIN", Default: true}, nil)
+	mockWarehouses.On("AdjustStockLevel", mock.Anything, 4, 1, 10, 0).Return(&item.StockLevel{ItemID: 4, WarehouseID: 1, Stock: 10}, nil)
+	mockRepo.On("AdjustStock", mock.Anything, 4, 10, 0).Return(&item.Item{ID: 4, Stock: 10}, nil)
+	mockStock.On("CreateStockMovement", mock.Anything, mock.Anything).Return(nil)
+
+	movement, err := service.RecordStockMovement(context.Background(), 4, &item.StockMovement{
+		Type: item.MovementReceipt, Quantity: 10, Reason: "po 7",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, movement.WarehouseID)
+	assert.Equal(t, 10, movement.StockAfter)
+	mockWarehouses.AssertExpectations(t)
+}
+
+func TestItemService_RecordStockMovement_UnknownWarehouse(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockStock := new(mocks.StockMovementRepository)
+	mockWarehouses := new(mocks.WarehouseRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithStockLedger(mockStock), WithWarehouses(mockWarehouses))
+
+	mockRepo.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockWarehouses.On("GetWarehouse", mock.Anything, 9).Return(nil, nil)
+
+	_, err := service.RecordStockMovement(context.Background(), 4, &item.StockMovement{
+		Type: item.MovementReceipt, Quantity: 10, Reason: "po 7", WarehouseID: 9,
+	})
+	assert.ErrorIs(t, err, warehouse.ErrWarehouseNotFound)
+	mockRepo.AssertNotCalled(t, "AdjustStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestItemService_RecordStockMovement_RejectsTransfers(t *testing.T) {
+	service := NewItemService(new(mocks.ItemRepository), new(mocks.CategoryClient), WithStockLedger(new(mocks.StockMovementRepository)))
+
+	_, err := service.RecordStockMovement(context.Background(), 4, &item.StockMovement{
+		Type: item.MovementTransferIn, Quantity: 1, Reason: "sneaky",
+	})
+	assert.ErrorIs(t, err, item.ErrInvalidMovement)
+}
+
+func TestItemService_TransferStock(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockStock := new(mocks.StockMovementRepository)
+	mockWarehouses := new(mocks.WarehouseRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithStockLedger(mockStock), WithWarehouses(mockWarehouses))
+
+	mockRepo.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockRepo.On("FindItem", mock.Anything, 4, false).Return(&item.Item{ID: 4, Stock: 12}, nil)
+	mockWarehouses.On("GetWarehouse", mock.Anything, 1).Return(&warehouse.Warehouse{ID: 1}, nil)
+	mockWarehouses.On("GetWarehouse", mock.Anything, 2).Return(&warehouse.Warehouse{ID: 2}, nil)
+	mockWarehouses.On("AdjustStockLevel", mock.Anything, 4, 1, -5, 0).Return(&item.StockLevel{Stock: 7}, nil)
+	mockWarehouses.On("AdjustStockLevel", mock.Anything, 4, 2, 5, 0).Return(&item.StockLevel{Stock: 5}, nil)
+	var movements []*item.StockMovement
+	mockStock.On("CreateStockMovement", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		movements = append(movements, args.Get(1).(*item.StockMovement))
+	}).Return(nil)
+
+	transfer, err := service.TransferStock(context.Background(), 4, &item.StockTransfer{
+		FromWarehouseID: 1, ToWarehouseID: 2, Quantity: 5, Reason: "rebalance",
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, transfer.ID)
+	assert.Equal(t, 4, transfer.ItemID)
+
+	require.Len(t, movements, 2)
+	assert.Equal(t, item.MovementTransferOut, movements[0].Type)
+	assert.Equal(t, -5, movements[0].Delta)
+	assert.Equal(t, item.MovementTransferIn, movements[1].Type)
+	assert.Equal(t, 5, movements[1].Delta)
+	for _, movement := range movements {
+		assert.Equal(t, transfer.ID, movement.TransferID)
+		assert.Equal(t, 12, movement.StockAfter)
+	}
+	mockRepo.AssertNotCalled(t, "AdjustStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockWarehouses.AssertExpectations(t)
+}
+
+func TestItemService_TransferStock_InsufficientStock(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockStock := new(mocks.StockMovementRepository)
+	mockWarehouses := new(mocks.WarehouseRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithStockLedger(mockStock), WithWarehouses(mockWarehouses))
+
+	mockRepo.On("WithinTransaction", mock.Anything, mock.Anything).Return(runInTransaction)
+	mockRepo.On("FindItem", mock.Anything, 4, false).Return(&item.Item{ID: 4, Stock: 12}, nil)
+	mockWarehouses.On("GetWarehouse", mock.Anything, 1).Return(&warehouse.Warehouse{ID: 1}, nil)
+	mockWarehouses.On("AdjustStockLevel", mock.Anything, 4, 1, -20, 0).Return(nil, item.ErrInsufficientStock)
+
+	_, err := service.TransferStock(context.Background(), 4, &item.StockTransfer{
+		FromWarehouseID: 1, ToWarehouseID: 2, Quantity: 20, Reason: "rebalance",
+	})
+	assert.ErrorIs(t, err, item.ErrInsufficientStock)
+	mockStock.AssertNotCalled(t, "CreateStockMovement", mock.Anything, mock.Anything)
+}
+
+func TestItemService_ListStockLevels(t *testing.T) {
+	mockRepo := new(mocks.ItemRepository)
+	mockWarehouses := new(mocks.WarehouseRepository)
+	service := NewItemService(mockRepo, new(mocks.CategoryClient), WithWarehouses(mockWarehouses))
+
+	mockRepo.On("FindItem", mock.Anything, 4, false).Return(&item.Item{ID: 4}, nil)
+	mockRepo.On("FindItem", mock.Anything, 5, false).Return(nil, nil)
+	levels := []item.StockLevel{{ItemID: 4, WarehouseID: 1, Stock: 3}}
+	mockWarehouses.On("ListStockLevels", mock.Anything, 4).Return(levels, nil)
+
+	got, err := service.ListStockLevels(context.Background(), 4)
+	require.NoError(t, err)
+	assert.Equal(t, levels, got)
+
+	_, err = service.ListStockLevels(context.Background(), 5)
+	assert.ErrorIs(t, err, item.ErrItemNotFound)
+}
@@ -0,0 +1,97 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/warehouse"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out"
+	"github.com/teamcubation/go-items-challenge/internal/utils"
+)
+
+type warehouseService struct {
+	repo out.WarehouseRepository
+}
+
+func NewWarehouseService(repo out.WarehouseRepository) *warehouseService {
+	return &warehouseService{repo: repo}
+}
+
+// CreateWarehouse creates a warehouse with a unique code. The default
+// warehouse is created by the migrations, so new ones are never the default.
+func (s *warehouseService) CreateWarehouse(ctx context.Context, w *warehouse.Warehouse) (*warehouse.Warehouse, error) {
+	if err := utils.ValidateStruct(w); err != nil {
+		return nil, fmt.Errorf("%w: %v", warehouse.ErrInvalidWarehouse, err)
+	}
+	exists, err := s.repo.WarehouseExistsByCode(ctx, w.Code)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, warehouse.ErrCodeExists
+	}
+
+	now := time.Now()
+	w.ID = 0
+	w.Default = false
+	w.CreatedAt = now
+	w.UpdatedAt = now
+	if err := s.repo.CreateWarehouse(ctx, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (s *warehouseService) GetWarehouse(ctx context.Context, id int) (*warehouse.Warehouse, error) {
+	w, err := s.repo.GetWarehouse(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if w == nil {
+		return nil, warehouse.ErrWarehouseNotFound
+	}
+	return w, nil
+}
+
+func (s *warehouseService) ListWarehouses(ctx context.Context) ([]warehouse.Warehouse, error) {
+	return s.repo.ListWarehouses(ctx)
+}
+
+// UpdateWarehouse replaces the name and address of the warehouse. Its code
+// identifies it in the stock movements, so it cannot change.
+func (s *warehouseService) UpdateWarehouse(ctx context.Context, id int, w *warehouse.Warehouse) (*warehouse.Warehouse, error) {
+	existing, err := s.GetWarehouse(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if w.Code == "" {
+		w.Code = existing.Code
+	}
+	if w.Code != existing.Code {
+		return nil, warehouse.ErrCodeImmutable
+	}
+	if err := utils.ValidateStruct(w); err != nil {
+		return nil, fmt.Errorf("%w: %v", warehouse.ErrInvalidWarehouse, err)
+	}
+
+	existing.Name = w.Name
+	existing.Address = w.Address
+	existing.UpdatedAt = time.Now()
+	if err := s.repo.UpdateWarehouse(ctx, existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// DeleteWarehouse deletes an empty warehouse other than the default one.
+func (s *warehouseService) DeleteWarehouse(ctx context.Context, id int) error {
+	existing, err := s.GetWarehouse(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing.Default {
+		return warehouse.ErrDefaultWarehouse
+	}
+	return s.repo.DeleteWarehouse(ctx, id)
+}
@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/domain/warehouse"
+	"github.com/teamcubation/go-items-challenge/internal/ports/out/mocks"
+)
+
+func TestWarehouseService_CreateWarehouse(t *testing.T) {
+	mockRepo := new(mocks.WarehouseRepository)
+	service := NewWarehouseService(mockRepo)
+
+	mockRepo.On("WarehouseExistsByCode", mock.Anything, "NORTH").Return(false, nil)
+	mockRepo.On("WarehouseExistsByCode", mock.Anything, "MAIN").Return(true, nil)
+	mockRepo.On("CreateWarehouse", mock.Anything, mock.Anything).Return(nil)
+
+	created, err := service.CreateWarehouse(context.Background(), &warehouse.Warehouse{Code: "NORTH", Name: "North", Default: true})
+	require.NoError(t, err)
+	assert.False(t, created.Default)
+	assert.False(t, created.CreatedAt.IsZero())
+
+	_, err = service.CreateWarehouse(context.Background(), &warehouse.Warehouse{Code: "MAIN", Name: "Main"})
+	assert.ErrorIs(t, err, warehouse.ErrCodeExists)
+
+	_, err = service.CreateWarehouse(context.Background(), &warehouse.Warehouse{Code: "no spaces", Name: "x"})
+	assert.ErrorIs(t, err, warehouse.ErrInvalidWarehouse)
+}
+
+func TestWarehouseService_UpdateWarehouse(t *testing.T) {
+	mockRepo := new(mocks.WarehouseRepository)
+	service := NewWarehouseService(mockRepo)
+
+	mockRepo.On("GetWarehouse", mock.Anything, 2).Return(&warehouse.Warehouse{ID: 2, Code: "NORTH", Name: "North"}, nil)
+	mockRepo.On("GetWarehouse", mock.Anything, 3).Return(nil, nil)
+	mockRepo.On("UpdateWarehouse", mock.Anything, mock.Anything).Return(nil)
+
+	updated, err := service.UpdateWarehouse(context.Background(), 2, &warehouse.Warehouse{Name: "North DC", Address: "Rua A"})
+	require.NoError(t, err)
+	assert.Equal(t, "NORTH", updated.Code)
+	assert.Equal(t, "North DC", updated.Name)
+
+	_, err = service.UpdateWarehouse(context.Background(), 2, &warehouse.Warehouse{Code: "SOUTH", Name: "North"})
+	assert.ErrorIs(t, err, warehouse.ErrCodeImmutable)
+
+	_, err = service.UpdateWarehouse(context.Background(), 3, &warehouse.Warehouse{Name: "x"})
+	assert.ErrorIs(t, err, warehouse.ErrWarehouseNotFound)
+}
+
+func TestWarehouseService_DeleteWarehouse(t *testing.T) {
+	mockRepo := new(mocks.WarehouseRepository)
+	service := NewWarehouseService(mockRepo)
+
+	mockRepo.On("GetWarehouse", mock.Anything, 1).Return(&warehouse.Warehouse{ID: 1, Code: "MAIN", Default: true}, nil)
+	mockRepo.On("GetWarehouse", mock.Anything, 2).Return(&warehouse.Warehouse{ID: 2, Code: "NORTH"}, nil)
+	mockRepo.On("DeleteWarehouse", mock.Anything, 2).Return(warehouse.ErrWarehouseNotEmpty)
+
+	assert.ErrorIs(t, service.DeleteWarehouse(context.Background(), 1), warehouse.ErrDefaultWarehouse)
+	assert.ErrorIs(t, service.DeleteWarehouse(context.Background(), 2), warehouse.ErrWarehouseNotEmpty)
+	mockRepo.AssertNotCalled(t, "DeleteWarehouse", mock.Anything, 1)
+}
@@ -0,0 +1,133 @@
+package item
+
+import (
+	"fmt"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditCreated  AuditAction = "created"
+	AuditUpdated  AuditAction = "updated"
+	AuditDeleted  AuditAction = "deleted"
+	AuditRestored AuditAction = "restored"
+	AuditReverted AuditAction = "reverted"
+	// AuditStockMoved records a movement of the stock ledger.
+	AuditStockMoved AuditAction = "stock_moved"
+	// AuditTransitioned records a status change requested through the state
+	// machine. Status changes caused by the stock are recorded as stock_moved.
+	AuditTransitioned AuditAction = "transitioned"
+)
+
+// FieldChange is the value of one item field before and after a change. Before
+// is nil for created items.
+type FieldChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditEntry records one change of an item. Entries are never updated or
+// deleted, and outlive purged items. Version is the revision of the item
+// created by the change, and Snapshot the full item at that revision.
+type AuditEntry struct {
+	ID        int64         `json:"id" gorm:"primaryKey"`
+	ItemID    int           `json:"item_id" gorm:"index"`
+	Action    AuditAction   `json:"action"`
+	ActorID   int           `json:"actor_id"`
+	RequestID string        `json:"request_id,omitempty"`
+	Version   int           `json:"version"`
+	Changes   []FieldChange `json:"changes" gorm:"serializer:json"`
+	Snapshot  *Item         `json:"-" gorm:"serializer:json"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+func (AuditEntry) TableName() string {
+	return "item_audit_entries"
+}
+
+type HistoryQuery struct {
+	ItemID int
+	Limit  int
+	Page   int
+}
+
+func (q HistoryQuery) Validate() error {
+	if q.Limit <= 0 {
+		return fmt.Errorf("%w: limit must be greater than zero", ErrInvalidQuery)
+	}
+	if q.Page <= 0 {
+		return fmt.Errorf("%w: page must be greater than zero", ErrInvalidQuery)
+	}
+	return nil
+}
+
+// HistoryResponse lists audit entries from the newest to the oldest.
+type HistoryResponse struct {
+	TotalPages int          `json:"totalPages"`
+	Data       []AuditEntry `json:"data"`
+}
+
+// auditedFields are the item fields compared by Diff.
+var auditedFields = []struct {
+	name  string
+	value func(itm *Item) interface{}
+}{
+	{"code", func(itm *Item) interface{} { return itm.Code }},
+	{"title", func(itm *Item) interface{} { return itm.Title }},
+	{"description", func(itm *Item) interface{} { return itm.Description }},
+	{"category_id", func(itm *Item) interface{} { return itm.CategoryID }},
+	{"price", func(itm *Item) interface{} { return itm.Price }},
+	{"stock", func(itm *Item) interface{} { return itm.Stock }},
+	{"status", func(itm *Item) interface{} { return string(itm.Status) }},
+	{"reorder_point", func(itm *Item) interface{} {
+		if itm.ReorderPoint == nil {
+			return nil
+		}
+		return *itm.ReorderPoint
+	}},
+	{"deleted_at", func(itm *Item) interface{} {
+		if !itm.DeletedAt.Valid {
+			return nil
+		}
+		return itm.DeletedAt.Time.UTC().Format(time.RFC3339Nano)
+	}},
+	{"deleted_by", func(itm *Item) interface{} {
+		if itm.DeletedBy == 0 {
+			return nil
+		}
+		return itm.DeletedBy
+	}},
+}
+
+// Diff lists the audited fields that differ between before and after. With a
+// nil before, every field set in after is listed.
+func Diff(before, after *Item) []FieldChange {
+	changes := []FieldChange{}
+	for _, f := range auditedFields {
+		newValue := f.value(after)
+		if before == nil {
+			if newValue != nil && newValue != zeroOf(newValue) {
+				changes = append(changes, FieldChange{Field: f.name, After: newValue})
+			}
+			continue
+		}
+		if oldValue := f.value(before); oldValue != newValue {
+			changes = append(changes, FieldChange{Field: f.name, Before: oldValue, After: newValue})
+		}
+	}
+	return changes
+}
+
+func zeroOf(v interface{}) interface{} {
+	switch v.(type) {
+	case string:
+		return ""
+	case int:
+		return 0
+	case float64:
+		return 0.0
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package item_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"gorm.io/gorm"
+)
+
+func TestDiff(t *testing.T) {
+	before := &item.Item{ID: 1, Code: "A1", Title: "Old", Price: 10, Stock: 5, Status: "ACTIVE", Version: 1}
+	after := *before
+	after.Title = "New"
+	after.Stock = 0
+	after.Status = "INACTIVE"
+	after.Version = 2
+
+	assert.Equal(t, []item.FieldChange{
+		{Field: "title", Before: "Old", After: "New"},
+		{Field: "stock", Before: 5, After: 0},
+		{Field: "status", Before: "ACTIVE", After: "INACTIVE"},
+	}, item.Diff(before, &after))
+}
+
+func TestDiff_Created(t *testing.T) {
+	created := &item.Item{ID: 1, Code: "A1", Price: 10, Status: "INACTIVE"}
+
+	assert.Equal(t, []item.FieldChange{
+		{Field: "code", After: "A1"},
+		{Field: "price", After: 10.0},
+		{Field: "status", After: "INACTIVE"},
+	}, item.Diff(nil, created))
+}
+
+func TestDiff_Deleted(t *testing.T) {
+	deletedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	before := &item.Item{ID: 1, Code: "A1"}
+	after := *before
+	after.DeletedAt = gorm.DeletedAt{Time: deletedAt, Valid: true}
+	after.DeletedBy = 7
+
+	assert.Equal(t, []item.FieldChange{
+		{Field: "deleted_at", After: "2024-05-01T12:00:00Z"},
+		{Field: "deleted_by", After: 7},
+	}, item.Diff(before, &after))
+}
+
+func TestHistoryQuery_Validate(t *testing.T) {
+	assert.NoError(t, item.HistoryQuery{ItemID: 1, Limit: 20, Page: 1}.Validate())
+	assert.ErrorIs(t, item.HistoryQuery{ItemID: 1, Limit: 0, Page: 1}.Validate(), item.ErrInvalidQuery)
+	assert.ErrorIs(t, item.HistoryQuery{ItemID: 1, Limit: 20, Page: 0}.Validate(), item.ErrInvalidQuery)
+}
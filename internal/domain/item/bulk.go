@@ -0,0 +1,79 @@
+package item
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BulkMode controls how a bulk operation reacts to a failing entry.
+type BulkMode string
+
+const (
+	// BulkAtomic runs every entry in a single transaction and rolls
+	// everything back on the first failure.
+	BulkAtomic BulkMode = "atomic"
+	// BulkBestEffort applies every entry independently.
+	BulkBestEffort BulkMode = "best_effort"
+)
+
+type BulkStatus string
+
+const (
+	BulkCreated    BulkStatus = "created"
+	BulkUpdated    BulkStatus = "updated"
+	BulkDeleted    BulkStatus = "deleted"
+	BulkFailed     BulkStatus = "failed"
+	BulkRolledBack BulkStatus = "rolled_back"
+	BulkSkipped    BulkStatus = "skipped"
+)
+
+// BulkResult reports the outcome of one entry, identified by its position in
+// the request and, for imports, by its line in the uploaded file.
+type BulkResult struct {
+	Index  int        `json:"index"`
+	Line   int        `json:"line,omitempty"`
+	ID     int        `json:"id,omitempty"`
+	Code   string     `json:"code,omitempty"`
+	Status BulkStatus `json:"status"`
+	Error  string     `json:"error,omitempty"`
+}
+
+// BulkDeleteEntry names an item to delete and the version of it that the
+// caller read.
+type BulkDeleteEntry struct {
+	ID      int `json:"id" example:"1"`
+	Version int `json:"version" example:"3"`
+}
+
+type BulkReport struct {
+	Mode      BulkMode     `json:"mode"`
+	DryRun    bool         `json:"dry_run,omitempty"`
+	Succeeded int          `json:"succeeded"`
+	Failed    int          `json:"failed"`
+	Results   []BulkResult `json:"results"`
+}
+
+// ParseBulkMode parses a bulk mode, defaulting to BulkAtomic when empty.
+func ParseBulkMode(s string) (BulkMode, error) {
+	switch mode := BulkMode(strings.ToLower(s)); mode {
+	case "":
+		return BulkAtomic, nil
+	case BulkAtomic, BulkBestEffort:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid bulk mode: %s", s)
+	}
+}
+
+// Tally counts the succeeded and failed entries of the report.
+func (r *BulkReport) Tally() {
+	r.Succeeded, r.Failed = 0, 0
+	for _, res := range r.Results {
+		switch res.Status {
+		case BulkCreated, BulkUpdated, BulkDeleted:
+			r.Succeeded++
+		case BulkFailed:
+			r.Failed++
+		}
+	}
+}
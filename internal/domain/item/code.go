@@ -0,0 +1,178 @@
+package item
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidCodePattern = errors.New("invalid item code pattern")
+
+type codePartKind int
+
+const (
+	codeLiteral codePartKind = iota
+	codeCategory
+	codeSequence
+	codeCheck
+)
+
+type codePart struct {
+	kind    codePartKind
+	literal string
+	width   int
+}
+
+// CodePattern generates item codes for the items created without one. A
+// pattern is literal text with the placeholders {category}, {seq} and {check},
+// e.g. "IT{category:3}{seq:6}{check}". The optional width pads the category ID
+// and the sequence with zeros; {check} is a check digit over the characters
+// before it and must come last.
+type CodePattern struct {
+	parts []codePart
+}
+
+func ParseCodePattern(raw string) (*CodePattern, error) {
+	p := &CodePattern{}
+	sequences := 0
+	for rest := raw; rest != ""; {
+		if len(p.parts) > 0 && p.parts[len(p.parts)-1].kind == codeCheck {
+			return nil, fmt.Errorf("%w: {check} must come last", ErrInvalidCodePattern)
+		}
+		if rest[0] != '{' {
+			end := strings.IndexByte(rest, '{')
+			if end < 0 {
+				end = len(rest)
+			}
+			literal := rest[:end]
+			for _, r := range literal {
+				if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+					return nil, fmt.Errorf("%w: codes are alphanumeric, found %q", ErrInvalidCodePattern, r)
+				}
+			}
+			p.parts = append(p.parts, codePart{kind: codeLiteral, literal: literal})
+			rest = rest[end:]
+			continue
+		}
+
+		end := strings.IndexByte(rest, '}')
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unclosed placeholder", ErrInvalidCodePattern)
+		}
+		part, err := parsePlaceholder(rest[1:end])
+		if err != nil {
+			return nil, err
+		}
+		if part.kind == codeSequence {
+			sequences++
+		}
+		p.parts = append(p.parts, part)
+		rest = rest[end+1:]
+	}
+	if sequences != 1 {
+		return nil, fmt.Errorf("%w: {seq} must appear exactly once", ErrInvalidCodePattern)
+	}
+	return p, nil
+}
+
+func parsePlaceholder(raw string) (codePart, error) {
+	name, rawWidth, hasWidth := strings.Cut(raw, ":")
+	var part codePart
+	switch name {
+	case "category":
+		part.kind = codeCategory
+	case "seq":
+		part.kind = codeSequence
+	case "check":
+		part.kind = codeCheck
+	default:
+		return part, fmt.Errorf("%w: unknown placeholder {%s}", ErrInvalidCodePattern, raw)
+	}
+	if hasWidth {
+		width, err := strconv.Atoi(rawWidth)
+		if err != nil || width < 1 || width > 18 || part.kind == codeCheck {
+			return part, fmt.Errorf("%w: invalid width in {%s}", ErrInvalidCodePattern, raw)
+		}
+		part.width = width
+	}
+	return part, nil
+}
+
+// SequenceKey names the sequence that numbers the codes of the category: the
+// code up to the {seq} placeholder, so every prefix and category is numbered
+// on its own.
+func (p *CodePattern) SequenceKey(categoryID int) string {
+	var b strings.Builder
+	for _, part := range p.parts {
+		if part.kind == codeSequence {
+			break
+		}
+		b.WriteString(part.format(categoryID, 0))
+	}
+	return b.String()
+}
+
+// Format returns the code of the item of the category numbered seq.
+func (p *CodePattern) Format(categoryID int, seq int64) string {
+	var b strings.Builder
+	for _, part := range p.parts {
+		if part.kind == codeCheck {
+			b.WriteByte(CheckDigit(b.String()))
+			continue
+		}
+		b.WriteString(part.format(categoryID, seq))
+	}
+	return b.String()
+}
+
+func (part codePart) format(categoryID int, seq int64) string {
+	switch part.kind {
+	case codeCategory:
+		return fmt.Sprintf("%0*d", part.width, categoryID)
+	case codeSequence:
+		return fmt.Sprintf("%0*d", part.width, seq)
+	default:
+		return part.literal
+	}
+}
+
+// CheckDigit computes the Luhn check digit of an alphanumeric code, with
+// letters first replaced by their two-digit value (A=10 … Z=35) as in ISINs.
+func CheckDigit(code string) byte {
+	var digits []int
+	for _, r := range strings.ToUpper(code) {
+		switch {
+		case r >= '0' && r <= '9':
+			digits = append(digits, int(r-'0'))
+		case r >= 'A' && r <= 'Z':
+			v := int(r-'A') + 10
+			digits = append(digits, v/10, v%10)
+		}
+	}
+
+	// the rightmost digit is doubled, as the check digit will follow it
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := digits[i]
+		if (len(digits)-1-i)%2 == 0 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// CodeSequence is the last number given out by the sequence named Key.
+type CodeSequence struct {
+	Key   string `gorm:"primaryKey"`
+	Value int64  `gorm:"not null"`
+}
+
+func (CodeSequence) TableName() string {
+	return "item_code_sequences"
+}
@@ -0,0 +1,54 @@
+package item_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+func TestCodePattern_Format(t *testing.T) {
+	pattern, err := item.ParseCodePattern("IT{category:3}{seq:5}{check}")
+	require.NoError(t, err)
+
+	assert.Equal(t, "IT012", pattern.SequenceKey(12))
+	code := pattern.Format(12, 42)
+	assert.Equal(t, "IT01200042", code[:len(code)-1])
+	assert.Equal(t, item.CheckDigit("IT01200042"), code[len(code)-1])
+
+	// a sequence wider than its placeholder is not truncated
+	assert.Equal(t, "A123", mustParse(t, "A{seq:2}").Format(1, 123))
+	assert.Equal(t, "A", mustParse(t, "A{seq}").SequenceKey(1))
+}
+
+func TestParseCodePattern_Invalid(t *testing.T) {
+	for _, raw := range []string{
+		"",
+		"IT",
+		"IT-{seq}",
+		"IT{seq}{seq}",
+		"IT{seq}{check}X",
+		"IT{seq:0}",
+		"IT{check:2}{seq}",
+		"IT{serial}",
+		"IT{seq",
+	} {
+		_, err := item.ParseCodePattern(raw)
+		assert.ErrorIs(t, err, item.ErrInvalidCodePattern, raw)
+	}
+}
+
+func TestCheckDigit(t *testing.T) {
+	// ISINs use the same check digit
+	assert.Equal(t, byte('5'), item.CheckDigit("US037833100"))
+	assert.Equal(t, byte('3'), item.CheckDigit("AU0000XVGZA"))
+	assert.Equal(t, byte('6'), item.CheckDigit("GB000263494"))
+}
+
+func mustParse(t *testing.T, raw string) *item.CodePattern {
+	t.Helper()
+	pattern, err := item.ParseCodePattern(raw)
+	require.NoError(t, err)
+	return pattern
+}
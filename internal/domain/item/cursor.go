@@ -0,0 +1,43 @@
+package item
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Cursor marks a position in a keyset-paginated listing. Items are ordered by
+// (updated_at, id), so a cursor holds the values of the last item returned.
+// The zero value points to the beginning of the listing.
+type Cursor struct {
+	UpdatedAt time.Time `json:"u"`
+	ID        int       `json:"i"`
+}
+
+func (c Cursor) IsZero() bool {
+	return c.ID == 0 && c.UpdatedAt.IsZero()
+}
+
+// Encode returns the opaque representation of the cursor sent to clients.
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor parses a cursor produced by Cursor.Encode. An empty string
+// decodes to the zero cursor.
+func DecodeCursor(s string) (Cursor, error) {
+	var c Cursor
+	if s == "" {
+		return c, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return c, fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+	return c, nil
+}
@@ -0,0 +1,24 @@
+package item
+
+import "errors"
+
+var (
+	ErrItemNotFound = errors.New("item not found")
+	// ErrVersionConflict means the item changed since the version the caller
+	// based its write on.
+	ErrVersionConflict = errors.New("item was modified by another request")
+	ErrItemNotDeleted  = errors.New("item is not deleted")
+	// ErrVersionRequired means a write that must be conditional did not give
+	// the version of the item it is based on.
+	ErrVersionRequired = errors.New("version is required")
+	ErrForbidden       = errors.New("forbidden")
+	// ErrRevisionNotFound means no snapshot was recorded for the requested
+	// revision of an item.
+	ErrRevisionNotFound = errors.New("revision not found")
+	// ErrNoHistory means no change of the item was audited, as for items
+	// created before the audit log, so its past states are unknown.
+	ErrNoHistory     = errors.New("item has no recorded history")
+	ErrInvalidItem   = errors.New("missing or invalid fields")
+	ErrDuplicateCode = errors.New("item with this code already exists")
+	ErrCodeImmutable = errors.New("the code of an item cannot be changed")
+)
@@ -0,0 +1,48 @@
+package item
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	ExportJobPending   = "PENDING"
+	ExportJobRunning   = "RUNNING"
+	ExportJobCompleted = "COMPLETED"
+	ExportJobFailed    = "FAILED"
+)
+
+var (
+	ErrExportJobNotFound = errors.New("export job not found")
+	ErrExportNotReady    = errors.New("export file is not ready")
+	// ErrExportJobLeaseLost means the job was requeued, or claimed by another
+	// worker, while it ran, as its heartbeat went stale.
+	ErrExportJobLeaseLost = errors.New("export job lease lost")
+)
+
+// ExportJob is an export written to storage in the background. Query holds the
+// filters of the export and is stored as JSON. A running job is leased by the
+// claim in ClaimedBy for as long as its worker keeps HeartbeatAt fresh.
+type ExportJob struct {
+	ID          string     `json:"id" gorm:"primaryKey"`
+	Format      string     `json:"format"`
+	Query       ListQuery  `json:"-" gorm:"serializer:json"`
+	Status      string     `json:"status" gorm:"index"`
+	Total       int        `json:"total"`
+	Processed   int        `json:"processed"`
+	FileName    string     `json:"-"`
+	ContentType string     `json:"-"`
+	Size        int64      `json:"size,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	CreatedBy   int        `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+	ClaimedBy   string     `json:"-" gorm:"not null;default:''"`
+	HeartbeatAt *time.Time `json:"-"`
+}
+
+// Finished reports whether the job reached a final status.
+func (j *ExportJob) Finished() bool {
+	return j.Status == ExportJobCompleted || j.Status == ExportJobFailed
+}
@@ -0,0 +1,22 @@
+package item
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrGrantNotFound = errors.New("grant not found")
+
+// ItemGrant shares an item with a user other than its creator. The user may
+// then change it as its creator does, except deleting it or managing its
+// grants.
+type ItemGrant struct {
+	ItemID    int       `json:"item_id" gorm:"primaryKey;autoIncrement:false"`
+	UserID    int       `json:"user_id" gorm:"primaryKey;autoIncrement:false;index"`
+	GrantedBy int       `json:"granted_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (ItemGrant) TableName() string {
+	return "item_grants"
+}
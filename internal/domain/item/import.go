@@ -0,0 +1,9 @@
+package item
+
+// ImportRow is an item read from an import file. Error holds the reason the
+// line could not be decoded, in which case Item is nil.
+type ImportRow struct {
+	Line  int
+	Item  *Item
+	Error string
+}
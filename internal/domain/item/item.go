@@ -2,19 +2,54 @@ package item
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Item struct {
 	ID          int       `json:"id"`
-	Code        string    `json:"code" validate:"required,alphanum"`
+	Code        string    `json:"code" validate:"omitempty,alphanum"`
 	Title       string    `json:"title,omitempty" validate:"omitempty,min=4"`
 	Description string    `json:"description,omitempty" validate:"omitempty,max=255"`
 	CategoryID  int       `json:"category_id"`
 	Price       float64   `json:"price,omitempty" validate:"omitempty,gt=0"`
 	Stock       int       `json:"stock,omitempty" validate:"omitempty,min=0"`
-	Status      string    `json:"status,omitempty"`
+	Status      Status    `json:"status,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 	CreatedBy   int       `json:"created_by"`
 	UpdatedBy   int       `json:"updated_by"`
+	Version     int       `json:"version" gorm:"not null;default:1"`
+	// DeletedAt marks a soft-deleted item; gorm leaves those out of queries
+	// unless Unscoped is used.
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index" swaggertype:"string" format:"date-time"`
+	DeletedBy int            `json:"deleted_by,omitempty"`
+	// Reserved is the part of Stock held by pending reservations, and
+	// Available the part that can still be reserved or sold.
+	Reserved  int `json:"reserved" gorm:"not null;default:0"`
+	Available int `json:"available" gorm:"-"`
+	// ReorderPoint overrides the reorder point of the category of the item.
+	// LowStock is set while the stock is at or below the reorder point in
+	// effect.
+	ReorderPoint *int `json:"reorder_point,omitempty" validate:"omitempty,min=0"`
+	LowStock     bool `json:"low_stock" gorm:"not null;default:false;index"`
+	// PublicID identifies the item outside the service; it is assigned on
+	// creation and never changes.
+	PublicID string `json:"public_id" gorm:"uniqueIndex"`
+}
+
+// DeriveAvailable sets Available from Stock and Reserved. gorm calls it through
+// the hooks below; rows read with Scan must call it themselves.
+func (i *Item) DeriveAvailable() {
+	i.Available = i.Stock - i.Reserved
+}
+
+func (i *Item) AfterFind(*gorm.DB) error {
+	i.DeriveAvailable()
+	return nil
+}
+
+func (i *Item) AfterSave(*gorm.DB) error {
+	i.DeriveAvailable()
+	return nil
 }
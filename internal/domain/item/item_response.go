@@ -3,4 +3,6 @@ package item
 type Response struct {
 	TotalPages int    `json:"totalPages"`
 	Data       []Item `json:"data"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
 }
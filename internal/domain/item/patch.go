@@ -0,0 +1,16 @@
+package item
+
+import "errors"
+
+// PatchType is the media type of a PATCH request body.
+type PatchType string
+
+const (
+	// MergePatch is an RFC 7396 JSON Merge Patch: present fields replace the
+	// current value and null clears it.
+	MergePatch PatchType = "application/merge-patch+json"
+	// JSONPatch is an RFC 6902 JSON Patch, a list of operations.
+	JSONPatch PatchType = "application/json-patch+json"
+)
+
+var ErrInvalidPatch = errors.New("invalid patch")
@@ -0,0 +1,65 @@
+package item
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidIDStrategy = errors.New("invalid public ID strategy")
+
+// IDStrategy is how the public IDs of new items are generated. The numeric ID
+// is assigned by a database sequence; the public ID can be handed out without
+// revealing how many items exist.
+type IDStrategy string
+
+const (
+	IDStrategyUUID IDStrategy = "uuid"
+	// ULIDs sort by creation time.
+	IDStrategyULID IDStrategy = "ulid"
+)
+
+func ParseIDStrategy(raw string) (IDStrategy, error) {
+	switch strategy := IDStrategy(strings.ToLower(strings.TrimSpace(raw))); strategy {
+	case IDStrategyUUID, IDStrategyULID:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidIDStrategy, raw)
+	}
+}
+
+// NewID returns a new public ID. An empty strategy generates UUIDs.
+func (s IDStrategy) NewID() string {
+	if s == IDStrategyULID {
+		return newULID(time.Now())
+	}
+	return uuid.New().String()
+}
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// newULID encodes the milliseconds of t followed by 80 random bits in 26
+// characters of Crockford's base32.
+func newULID(t time.Time) string {
+	var id [16]byte
+	binary.BigEndian.PutUint64(id[:8], uint64(t.UnixMilli())<<16)
+	if _, err := rand.Read(id[6:]); err != nil {
+		panic(err)
+	}
+
+	// 128 bits are written as 26 groups of 5 bits, the first one padded
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+	out := make([]byte, 26)
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out)
+}
@@ -0,0 +1,29 @@
+package item_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+func TestParseIDStrategy(t *testing.T) {
+	strategy, err := item.ParseIDStrategy(" ULID ")
+	require.NoError(t, err)
+	assert.Equal(t, item.IDStrategyULID, strategy)
+
+	_, err = item.ParseIDStrategy("serial")
+	assert.ErrorIs(t, err, item.ErrInvalidIDStrategy)
+}
+
+func TestIDStrategy_NewID(t *testing.T) {
+	_, err := uuid.Parse(item.IDStrategy("").NewID())
+	assert.NoError(t, err)
+
+	first := item.IDStrategyULID.NewID()
+	assert.Regexp(t, regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`), first)
+	assert.NotEqual(t, first, item.IDStrategyULID.NewID())
+}
@@ -0,0 +1,136 @@
+package item
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrInvalidQuery = errors.New("invalid query")
+
+// sortableFields is the whitelist of fields accepted by the sort parameter.
+var sortableFields = map[string]bool{
+	"id":          true,
+	"code":        true,
+	"title":       true,
+	"category_id": true,
+	"price":       true,
+	"stock":       true,
+	"status":      true,
+	"created_at":  true,
+	"updated_at":  true,
+}
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ListQuery holds the filters, sorting and pagination used to list items.
+// Nil pointers and empty strings mean "no filter". When Cursor is set the
+// listing uses keyset pagination: Page is ignored and Sort must be empty.
+type ListQuery struct {
+	Status      string
+	CategoryID  *int
+	MinPrice    *float64
+	MaxPrice    *float64
+	MinStock    *int
+	MaxStock    *int
+	CreatedBy   *int
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	UpdatedFrom *time.Time
+	UpdatedTo   *time.Time
+	CodePrefix  string
+	LowStock    *bool
+	// Mine limits the items to those the caller created or was granted; the
+	// service resolves it to OwnerID.
+	Mine    bool `json:"-"`
+	OwnerID int
+	// IncludeDeleted also lists soft-deleted items. Only admins may set it.
+	IncludeDeleted bool
+	Sort           []SortField
+	Limit          int
+	Page           int
+	Cursor         *Cursor
+}
+
+// ParseSort parses a comma separated list of sort fields. Each entry may be
+// "field", "-field", "field:asc" or "field:desc".
+func ParseSort(raw string) ([]SortField, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var fields []SortField
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		sf := SortField{Field: part}
+		if strings.HasPrefix(part, "-") {
+			sf = SortField{Field: part[1:], Desc: true}
+		} else if name, dir, found := strings.Cut(part, ":"); found {
+			sf.Field = name
+			switch strings.ToLower(dir) {
+			case "asc":
+			case "desc":
+				sf.Desc = true
+			default:
+				return nil, fmt.Errorf("%w: invalid sort direction %q", ErrInvalidQuery, dir)
+			}
+		}
+
+		sf.Field = strings.ToLower(sf.Field)
+		if !sortableFields[sf.Field] {
+			return nil, fmt.Errorf("%w: cannot sort by %q", ErrInvalidQuery, sf.Field)
+		}
+		fields = append(fields, sf)
+	}
+	return fields, nil
+}
+
+// Validate checks that the pagination values and ranges are consistent.
+func (q ListQuery) Validate() error {
+	if q.Limit <= 0 {
+		return fmt.Errorf("%w: limit must be greater than zero", ErrInvalidQuery)
+	}
+	if q.Cursor == nil && q.Page <= 0 {
+		return fmt.Errorf("%w: page must be greater than zero", ErrInvalidQuery)
+	}
+	if q.Cursor != nil && len(q.Sort) > 0 {
+		return fmt.Errorf("%w: sort is not supported with cursor pagination", ErrInvalidQuery)
+	}
+	return q.ValidateFilters()
+}
+
+// ValidateFilters checks the filters and sorting, ignoring pagination. It is
+// used on its own by exports, which always return every matching item.
+func (q ListQuery) ValidateFilters() error {
+	if q.Status != "" {
+		if _, err := ParseStatus(q.Status); err != nil {
+			return fmt.Errorf("%w: invalid status %q", ErrInvalidQuery, q.Status)
+		}
+	}
+	if q.MinPrice != nil && q.MaxPrice != nil && *q.MinPrice > *q.MaxPrice {
+		return fmt.Errorf("%w: min_price is greater than max_price", ErrInvalidQuery)
+	}
+	if q.MinStock != nil && q.MaxStock != nil && *q.MinStock > *q.MaxStock {
+		return fmt.Errorf("%w: min_stock is greater than max_stock", ErrInvalidQuery)
+	}
+	if q.CreatedFrom != nil && q.CreatedTo != nil && q.CreatedFrom.After(*q.CreatedTo) {
+		return fmt.Errorf("%w: created_from is after created_to", ErrInvalidQuery)
+	}
+	if q.UpdatedFrom != nil && q.UpdatedTo != nil && q.UpdatedFrom.After(*q.UpdatedTo) {
+		return fmt.Errorf("%w: updated_from is after updated_to", ErrInvalidQuery)
+	}
+	for _, sf := range q.Sort {
+		if !sortableFields[sf.Field] {
+			return fmt.Errorf("%w: cannot sort by %q", ErrInvalidQuery, sf.Field)
+		}
+	}
+	return nil
+}
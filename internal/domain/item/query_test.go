@@ -0,0 +1,34 @@
+package item_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+func TestParseSort(t *testing.T) {
+	fields, err := item.ParseSort("-created_at, price:asc,Title:DESC")
+	assert.NoError(t, err)
+	assert.Equal(t, []item.SortField{
+		{Field: "created_at", Desc: true},
+		{Field: "price"},
+		{Field: "title", Desc: true},
+	}, fields)
+
+	_, err = item.ParseSort("password")
+	assert.ErrorIs(t, err, item.ErrInvalidQuery)
+
+	_, err = item.ParseSort("price:sideways")
+	assert.ErrorIs(t, err, item.ErrInvalidQuery)
+}
+
+func TestListQuery_Validate(t *testing.T) {
+	minPrice, maxPrice := 50.0, 10.0
+
+	assert.NoError(t, item.ListQuery{Limit: 10, Page: 1}.Validate())
+	assert.NoError(t, item.ListQuery{Status: "out_of_stock", Limit: 10, Page: 1}.Validate())
+	assert.ErrorIs(t, item.ListQuery{Status: "SOLD", Limit: 10, Page: 1}.Validate(), item.ErrInvalidQuery)
+	assert.ErrorIs(t, item.ListQuery{Limit: 0, Page: 1}.Validate(), item.ErrInvalidQuery)
+	assert.ErrorIs(t, item.ListQuery{Limit: 10, Page: 1, MinPrice: &minPrice, MaxPrice: &maxPrice}.Validate(), item.ErrInvalidQuery)
+}
@@ -0,0 +1,62 @@
+package item
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrReservationNotFound = errors.New("reservation not found")
+	// ErrReservationClosed means the reservation was already confirmed,
+	// released or expired.
+	ErrReservationClosed  = errors.New("reservation is no longer held")
+	ErrInvalidReservation = errors.New("invalid reservation")
+)
+
+const (
+	DefaultReservationTTL = 15 * time.Minute
+	MaxReservationTTL     = 24 * time.Hour
+)
+
+type ReservationStatus string
+
+const (
+	ReservationHeld      ReservationStatus = "HELD"
+	ReservationConfirmed ReservationStatus = "CONFIRMED"
+	ReservationReleased  ReservationStatus = "RELEASED"
+	ReservationExpired   ReservationStatus = "EXPIRED"
+)
+
+// Reservation holds part of the stock of an item until it is confirmed as a
+// sale, released, or expires. With warehouses, the stock is held in, and sold
+// from, WarehouseID; zero means the default warehouse, for reservations made
+// before warehouses, which hold no stock of a warehouse.
+type Reservation struct {
+	ID          string            `json:"id" gorm:"primaryKey"`
+	ItemID      int               `json:"item_id" gorm:"index"`
+	Quantity    int               `json:"quantity"`
+	WarehouseID int               `json:"warehouse_id,omitempty"`
+	Status      ReservationStatus `json:"status" gorm:"index"`
+	ExpiresAt   time.Time         `json:"expires_at" gorm:"index"`
+	CreatedBy   int               `json:"created_by"`
+	CreatedAt   time.Time         `json:"created_at"`
+	ResolvedAt  *time.Time        `json:"resolved_at,omitempty"`
+}
+
+// Held reports whether the reservation still holds stock at now.
+func (r *Reservation) Held(now time.Time) bool {
+	return r.Status == ReservationHeld && now.Before(r.ExpiresAt)
+}
+
+// ValidateReservation checks the quantity and time to live of a new
+// reservation.
+func ValidateReservation(quantity int, ttl time.Duration) error {
+	if quantity <= 0 {
+		return fmt.Errorf("%w: quantity must be greater than zero", ErrInvalidReservation)
+	}
+	if ttl <= 0 || ttl > MaxReservationTTL {
+		return fmt.Errorf("%w: ttl must be between 1s and %s", ErrInvalidReservation, MaxReservationTTL)
+	}
+	return nil
+}
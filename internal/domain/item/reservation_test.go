@@ -0,0 +1,33 @@
+package item_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+func TestReservation_Held(t *testing.T) {
+	now := time.Now()
+	held := item.Reservation{Status: item.ReservationHeld, ExpiresAt: now.Add(time.Minute)}
+	assert.True(t, held.Held(now))
+	assert.False(t, held.Held(now.Add(time.Minute)), "expired")
+
+	released := item.Reservation{Status: item.ReservationReleased, ExpiresAt: now.Add(time.Minute)}
+	assert.False(t, released.Held(now))
+}
+
+func TestValidateReservation(t *testing.T) {
+	assert.NoError(t, item.ValidateReservation(1, time.Minute))
+	assert.NoError(t, item.ValidateReservation(1, item.MaxReservationTTL))
+	assert.ErrorIs(t, item.ValidateReservation(0, time.Minute), item.ErrInvalidReservation)
+	assert.ErrorIs(t, item.ValidateReservation(1, 0), item.ErrInvalidReservation)
+	assert.ErrorIs(t, item.ValidateReservation(1, item.MaxReservationTTL+time.Second), item.ErrInvalidReservation)
+}
+
+func TestItem_DeriveAvailable(t *testing.T) {
+	itm := item.Item{Stock: 10, Reserved: 4}
+	itm.DeriveAvailable()
+	assert.Equal(t, 6, itm.Available)
+}
@@ -0,0 +1,54 @@
+package item
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var searchTermPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// SearchQuery describes a full-text search over item code, title and
+// description. Every term must match, and terms match as prefixes. Only items
+// in Status are searched.
+type SearchQuery struct {
+	Text   string
+	Status string
+	Limit  int
+	Page   int
+}
+
+// SearchResult is an item matched by a search together with its relevance
+// and a snippet where the matched terms are wrapped in <mark> tags. The rest of
+// the snippet is HTML-escaped.
+type SearchResult struct {
+	Item    Item    `json:"item"`
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+type SearchResponse struct {
+	TotalPages int            `json:"totalPages"`
+	Data       []SearchResult `json:"data"`
+}
+
+// Terms splits the search text into lowercase words, dropping punctuation.
+func (q SearchQuery) Terms() []string {
+	return searchTermPattern.FindAllString(strings.ToLower(q.Text), -1)
+}
+
+func (q SearchQuery) Validate() error {
+	if len(q.Terms()) == 0 {
+		return fmt.Errorf("%w: search text is required", ErrInvalidQuery)
+	}
+	if _, err := ParseStatus(q.Status); err != nil {
+		return fmt.Errorf("%w: invalid status %q", ErrInvalidQuery, q.Status)
+	}
+	if q.Limit <= 0 {
+		return fmt.Errorf("%w: limit must be greater than zero", ErrInvalidQuery)
+	}
+	if q.Page <= 0 {
+		return fmt.Errorf("%w: page must be greater than zero", ErrInvalidQuery)
+	}
+	return nil
+}
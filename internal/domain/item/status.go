@@ -0,0 +1,110 @@
+package item
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidStatus = errors.New("invalid status")
+	// ErrInvalidTransition means the item cannot move from its current status
+	// to the requested one.
+	ErrInvalidTransition = errors.New("invalid status transition")
+	// ErrItemArchived means a write tried to change an archived item, which
+	// is final.
+	ErrItemArchived = errors.New("archived items cannot be changed")
+	// ErrItemNotActive means stock was requested from an item that is not
+	// being sold.
+	ErrItemNotActive = errors.New("only active items can be reserved")
+)
+
+// Status is the lifecycle state of an item.
+type Status string
+
+const (
+	// StatusDraft items are being prepared and are not sold yet.
+	StatusDraft        Status = "DRAFT"
+	StatusActive       Status = "ACTIVE"
+	StatusOutOfStock   Status = "OUT_OF_STOCK"
+	StatusDiscontinued Status = "DISCONTINUED"
+	// StatusArchived is final.
+	StatusArchived Status = "ARCHIVED"
+)
+
+// statusInactive is the status used for items without stock before
+// OUT_OF_STOCK existed. It is still accepted in filters.
+const statusInactive = "INACTIVE"
+
+// transitions lists the statuses each status can move to. Moves between
+// ACTIVE and OUT_OF_STOCK follow the stock and cannot be requested.
+var transitions = map[Status][]Status{
+	StatusDraft:        {StatusActive, StatusArchived},
+	StatusActive:       {StatusOutOfStock, StatusDiscontinued},
+	StatusOutOfStock:   {StatusActive, StatusDiscontinued},
+	StatusDiscontinued: {StatusArchived},
+	StatusArchived:     {},
+}
+
+// ParseStatus parses a status case-insensitively. INACTIVE is read as
+// OUT_OF_STOCK.
+func ParseStatus(raw string) (Status, error) {
+	status := Status(strings.ToUpper(strings.TrimSpace(raw)))
+	if status == statusInactive {
+		return StatusOutOfStock, nil
+	}
+	if _, ok := transitions[status]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalidStatus, raw)
+	}
+	return status, nil
+}
+
+// StockStatus is the status of a sellable item with the given stock.
+func StockStatus(stock int) Status {
+	if stock > 0 {
+		return StatusActive
+	}
+	return StatusOutOfStock
+}
+
+// Sellable reports whether the status follows the stock, that is, whether it
+// is ACTIVE or OUT_OF_STOCK.
+func (s Status) Sellable() bool {
+	return s == StatusActive || s == StatusOutOfStock
+}
+
+// WithStock returns the status of an item in status s once its stock becomes
+// stock. Only sellable items change.
+func (s Status) WithStock(stock int) Status {
+	if s.Sellable() {
+		return StockStatus(stock)
+	}
+	return s
+}
+
+// CanTransition reports whether the state machine allows moving from s to to.
+func (s Status) CanTransition(to Status) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition returns the status an item in status s with the given stock
+// reaches when to is requested. Requesting ACTIVE publishes a draft, which
+// becomes OUT_OF_STOCK when it has no stock; OUT_OF_STOCK itself cannot be
+// requested.
+func (s Status) Transition(to Status, stock int) (Status, error) {
+	if to == StatusOutOfStock || (to == StatusActive && s.Sellable()) {
+		return "", fmt.Errorf("%w: %s and %s follow the stock of the item", ErrInvalidTransition, StatusActive, StatusOutOfStock)
+	}
+	if !s.CanTransition(to) {
+		return "", fmt.Errorf("%w: cannot go from %s to %s", ErrInvalidTransition, s, to)
+	}
+	if to == StatusActive {
+		return StockStatus(stock), nil
+	}
+	return to, nil
+}
@@ -0,0 +1,66 @@
+package item_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+func TestParseStatus(t *testing.T) {
+	status, err := item.ParseStatus("discontinued")
+	require.NoError(t, err)
+	assert.Equal(t, item.StatusDiscontinued, status)
+
+	status, err = item.ParseStatus("INACTIVE")
+	require.NoError(t, err)
+	assert.Equal(t, item.StatusOutOfStock, status)
+
+	_, err = item.ParseStatus("SOLD")
+	assert.ErrorIs(t, err, item.ErrInvalidStatus)
+}
+
+func TestStatus_WithStock(t *testing.T) {
+	assert.Equal(t, item.StatusOutOfStock, item.StatusActive.WithStock(0))
+	assert.Equal(t, item.StatusActive, item.StatusOutOfStock.WithStock(3))
+	assert.Equal(t, item.StatusDraft, item.StatusDraft.WithStock(3))
+	assert.Equal(t, item.StatusDiscontinued, item.StatusDiscontinued.WithStock(0))
+}
+
+func TestStatus_Transition(t *testing.T) {
+	tests := []struct {
+		from  item.Status
+		to    item.Status
+		stock int
+		want  item.Status
+	}{
+		{item.StatusDraft, item.StatusActive, 5, item.StatusActive},
+		{item.StatusDraft, item.StatusActive, 0, item.StatusOutOfStock},
+		{item.StatusDraft, item.StatusArchived, 0, item.StatusArchived},
+		{item.StatusActive, item.StatusDiscontinued, 5, item.StatusDiscontinued},
+		{item.StatusOutOfStock, item.StatusDiscontinued, 0, item.StatusDiscontinued},
+		{item.StatusDiscontinued, item.StatusArchived, 5, item.StatusArchived},
+	}
+	for _, tt := range tests {
+		got, err := tt.from.Transition(tt.to, tt.stock)
+		require.NoError(t, err, "%s -> %s", tt.from, tt.to)
+		assert.Equal(t, tt.want, got, "%s -> %s", tt.from, tt.to)
+	}
+}
+
+func TestStatus_Transition_Invalid(t *testing.T) {
+	invalid := [][2]item.Status{
+		{item.StatusActive, item.StatusOutOfStock},
+		{item.StatusOutOfStock, item.StatusActive},
+		{item.StatusActive, item.StatusDraft},
+		{item.StatusDraft, item.StatusDiscontinued},
+		{item.StatusDiscontinued, item.StatusActive},
+		{item.StatusArchived, item.StatusDraft},
+		{item.StatusArchived, item.StatusArchived},
+	}
+	for _, pair := range invalid {
+		_, err := pair[0].Transition(pair[1], 5)
+		assert.ErrorIs(t, err, item.ErrInvalidTransition, "%s -> %s", pair[0], pair[1])
+	}
+}
@@ -0,0 +1,155 @@
+package item
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInsufficientStock means a movement would leave the stock of an item below
+// zero.
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+// ErrInvalidMovement wraps the validation errors of a stock movement.
+var ErrInvalidMovement = errors.New("invalid stock movement")
+
+// ErrStockNotEditable means a write other than a stock movement tried to
+// change the stock of an item.
+var ErrStockNotEditable = errors.New("stock is changed through stock movements")
+
+type MovementType string
+
+const (
+	MovementReceipt    MovementType = "receipt"
+	MovementSale       MovementType = "sale"
+	MovementReturn     MovementType = "return"
+	MovementAdjustment MovementType = "adjustment"
+	MovementWriteOff   MovementType = "write_off"
+	// transfers between warehouses are recorded as a pair of movements that
+	// leave the total stock unchanged
+	MovementTransferOut MovementType = "transfer_out"
+	MovementTransferIn  MovementType = "transfer_in"
+)
+
+// movementSigns is the sign applied to the quantity of each movement type. An
+// adjustment carries its own sign.
+var movementSigns = map[MovementType]int{
+	MovementReceipt:     1,
+	MovementSale:        -1,
+	MovementReturn:      1,
+	MovementAdjustment:  0,
+	MovementWriteOff:    -1,
+	MovementTransferOut: -1,
+	MovementTransferIn:  1,
+}
+
+// StockMovement is one entry of the stock ledger of an item. The stock of an
+// item is the sum of the deltas of its movements.
+type StockMovement struct {
+	ID     int64        `json:"id" gorm:"primaryKey"`
+	ItemID int          `json:"item_id" gorm:"index"`
+	Type   MovementType `json:"type"`
+	// Quantity is positive, except for adjustments where it is signed.
+	Quantity   int       `json:"quantity"`
+	Delta      int       `json:"delta"`
+	Reason     string    `json:"reason"`
+	StockAfter int       `json:"stock_after"`
+	ActorID    int       `json:"actor_id"`
+	RequestID  string    `json:"request_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	// WarehouseID is the warehouse whose stock moved; zero means the default
+	// one. Both movements of a transfer share its TransferID.
+	WarehouseID int    `json:"warehouse_id,omitempty" gorm:"index"`
+	TransferID  string `json:"transfer_id,omitempty"`
+}
+
+// Validate checks the movement type, quantity and reason, and sets Delta.
+func (m *StockMovement) Validate() error {
+	sign, ok := movementSigns[m.Type]
+	if !ok {
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidMovement, m.Type)
+	}
+	if strings.TrimSpace(m.Reason) == "" {
+		return fmt.Errorf("%w: reason is required", ErrInvalidMovement)
+	}
+	if sign == 0 {
+		if m.Quantity == 0 {
+			return fmt.Errorf("%w: quantity cannot be zero", ErrInvalidMovement)
+		}
+		m.Delta = m.Quantity
+		return nil
+	}
+	if m.Quantity <= 0 {
+		return fmt.Errorf("%w: quantity must be greater than zero", ErrInvalidMovement)
+	}
+	m.Delta = sign * m.Quantity
+	return nil
+}
+
+type MovementQuery struct {
+	ItemID int
+	Type   MovementType
+	Limit  int
+	Page   int
+}
+
+func (q MovementQuery) Validate() error {
+	if _, ok := movementSigns[q.Type]; q.Type != "" && !ok {
+		return fmt.Errorf("%w: unknown movement type %q", ErrInvalidQuery, q.Type)
+	}
+	if q.Limit <= 0 {
+		return fmt.Errorf("%w: limit must be greater than zero", ErrInvalidQuery)
+	}
+	if q.Page <= 0 {
+		return fmt.Errorf("%w: page must be greater than zero", ErrInvalidQuery)
+	}
+	return nil
+}
+
+// StockLevel is the stock of an item in one warehouse. The stock of the item is
+// the sum of its levels. Reserved is the part of the stock held by the
+// reservations of the warehouse.
+type StockLevel struct {
+	ItemID      int       `json:"item_id" gorm:"primaryKey;autoIncrement:false"`
+	WarehouseID int       `json:"warehouse_id" gorm:"primaryKey;autoIncrement:false"`
+	Stock       int       `json:"stock" gorm:"not null;default:0"`
+	Reserved    int       `json:"reserved" gorm:"not null;default:0"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (StockLevel) TableName() string {
+	return "item_stock_levels"
+}
+
+// StockTransfer moves stock of an item between two warehouses.
+type StockTransfer struct {
+	ID              string `json:"id"`
+	ItemID          int    `json:"item_id"`
+	FromWarehouseID int    `json:"from_warehouse_id"`
+	ToWarehouseID   int    `json:"to_warehouse_id"`
+	Quantity        int    `json:"quantity"`
+	Reason          string `json:"reason"`
+}
+
+func (t *StockTransfer) Validate() error {
+	if t.FromWarehouseID == 0 || t.ToWarehouseID == 0 {
+		return fmt.Errorf("%w: from_warehouse_id and to_warehouse_id are required", ErrInvalidMovement)
+	}
+	if t.FromWarehouseID == t.ToWarehouseID {
+		return fmt.Errorf("%w: cannot transfer to the same warehouse", ErrInvalidMovement)
+	}
+	if t.Quantity <= 0 {
+		return fmt.Errorf("%w: quantity must be greater than zero", ErrInvalidMovement)
+	}
+	if strings.TrimSpace(t.Reason) == "" {
+		return fmt.Errorf("%w: reason is required", ErrInvalidMovement)
+	}
+	return nil
+}
+
+// MovementResponse lists stock movements from the newest to the oldest.
+type MovementResponse struct {
+	TotalPages int             `json:"totalPages"`
+	Data       []StockMovement `json:"data"`
+}
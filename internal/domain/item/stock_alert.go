@@ -0,0 +1,41 @@
+package item
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrReorderPointNotFound = errors.New("reorder point not found")
+	ErrInvalidReorderPoint  = errors.New("invalid reorder point")
+)
+
+// CategoryReorderPoint is the reorder point of the items of a category that
+// have none of their own.
+type CategoryReorderPoint struct {
+	CategoryID   int       `json:"category_id" gorm:"primaryKey;autoIncrement:false"`
+	ReorderPoint int       `json:"reorder_point" validate:"min=0"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// StockAlert is raised when the stock of an item falls to its reorder point.
+// No other alert is raised for the item until it is replenished above it.
+type StockAlert struct {
+	ID           int64     `json:"id" gorm:"primaryKey"`
+	ItemID       int       `json:"item_id" gorm:"index"`
+	Code         string    `json:"code"`
+	Stock        int       `json:"stock"`
+	ReorderPoint int       `json:"reorder_point"`
+	CreatedAt    time.Time `json:"created_at"`
+	// SentAt is set once the alert has been delivered to the notifier.
+	SentAt *time.Time `json:"sent_at,omitempty" gorm:"index"`
+	// ClaimedUntil is the end of the lease of the dispatcher sending the
+	// alert; other dispatchers leave it until then.
+	ClaimedUntil *time.Time `json:"-"`
+}
+
+// IsLowStock reports whether stock is at or below reorderPoint. Without a
+// reorder point the stock is never low.
+func IsLowStock(stock int, reorderPoint *int) bool {
+	return reorderPoint != nil && stock <= *reorderPoint
+}
@@ -0,0 +1,70 @@
+package item_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+func TestStockMovement_Validate(t *testing.T) {
+	tests := []struct {
+		movementType item.MovementType
+		quantity     int
+		delta        int
+	}{
+		{item.MovementReceipt, 10, 10},
+		{item.MovementSale, 3, -3},
+		{item.MovementReturn, 1, 1},
+		{item.MovementWriteOff, 2, -2},
+		{item.MovementAdjustment, -4, -4},
+		{item.MovementAdjustment, 4, 4},
+	}
+	for _, tt := range tests {
+		movement := item.StockMovement{Type: tt.movementType, Quantity: tt.quantity, Reason: "count"}
+		assert.NoError(t, movement.Validate())
+		assert.Equal(t, tt.delta, movement.Delta, tt.movementType)
+	}
+}
+
+func TestStockMovement_Validate_Invalid(t *testing.T) {
+	invalid := []item.StockMovement{
+		{Type: "theft", Quantity: 1, Reason: "count"},
+		{Type: item.MovementSale, Quantity: 0, Reason: "count"},
+		{Type: item.MovementSale, Quantity: -1, Reason: "count"},
+		{Type: item.MovementAdjustment, Quantity: 0, Reason: "count"},
+		{Type: item.MovementReceipt, Quantity: 1, Reason: "  "},
+	}
+	for _, movement := range invalid {
+		assert.ErrorIs(t, movement.Validate(), item.ErrInvalidMovement, movement)
+	}
+}
+
+func TestStockTransfer_Validate(t *testing.T) {
+	assert.NoError(t, (&item.StockTransfer{FromWarehouseID: 1, ToWarehouseID: 2, Quantity: 3, Reason: "rebalance"}).Validate())
+
+	invalid := []item.StockTransfer{
+		{ToWarehouseID: 2, Quantity: 3, Reason: "rebalance"},
+		{FromWarehouseID: 1, ToWarehouseID: 1, Quantity: 3, Reason: "rebalance"},
+		{FromWarehouseID: 1, ToWarehouseID: 2, Quantity: 0, Reason: "rebalance"},
+		{FromWarehouseID: 1, ToWarehouseID: 2, Quantity: 3, Reason: " "},
+	}
+	for _, transfer := range invalid {
+		assert.ErrorIs(t, transfer.Validate(), item.ErrInvalidMovement, transfer)
+	}
+}
+
+func TestMovementQuery_Validate(t *testing.T) {
+	assert.NoError(t, item.MovementQuery{ItemID: 1, Limit: 20, Page: 1}.Validate())
+	assert.NoError(t, item.MovementQuery{ItemID: 1, Type: item.MovementSale, Limit: 20, Page: 1}.Validate())
+	assert.ErrorIs(t, item.MovementQuery{ItemID: 1, Type: "theft", Limit: 20, Page: 1}.Validate(), item.ErrInvalidQuery)
+	assert.ErrorIs(t, item.MovementQuery{ItemID: 1, Limit: 0, Page: 1}.Validate(), item.ErrInvalidQuery)
+}
+
+func TestIsLowStock(t *testing.T) {
+	five := 5
+	assert.False(t, item.IsLowStock(3, nil))
+	assert.True(t, item.IsLowStock(5, &five))
+	assert.True(t, item.IsLowStock(0, &five))
+	assert.False(t, item.IsLowStock(6, &five))
+}
@@ -0,0 +1,77 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidAPIKey  = errors.New("invalid API key")
+	ErrAPIKeyNotFound = errors.New("API key not found")
+	ErrInvalidScope   = errors.New("invalid API key scope")
+	ErrInvalidExpiry  = errors.New("API key expiry must be in the future")
+	// ErrAPIKeyCaller is returned when an API key tries to create another key,
+	// which would outlive the key that created it.
+	ErrAPIKeyCaller = errors.New("API keys cannot create API keys")
+)
+
+// APIKey lets a machine client act as the user who created it, limited to the
+// permissions in Scopes that the role of the user still grants. Only the hash
+// of the key is stored; Prefix identifies the key in listings.
+type APIKey struct {
+	ID         string       `json:"id" gorm:"primaryKey"`
+	UserID     int          `json:"-" gorm:"index;not null"`
+	Name       string       `json:"name" gorm:"not null"`
+	Prefix     string       `json:"prefix" gorm:"not null"`
+	KeyHash    string       `json:"-" gorm:"uniqueIndex;not null"`
+	Scopes     []Permission `json:"scopes" gorm:"serializer:json;not null"`
+	ExpiresAt  *time.Time   `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time   `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+	RevokedAt  *time.Time   `json:"-"`
+}
+
+// Active reports whether the key authenticates requests at now.
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// CreatedAPIKey is returned once, when the key is created; the key itself
+// cannot be read again.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// Principal is who a request acts as. Scopes is nil when the request is not
+// limited to a set of permissions, as with access tokens.
+type Principal struct {
+	UserID int
+	Role   Role
+	Scopes []Permission
+}
+
+// ParsePermission returns the permission named raw.
+func ParsePermission(raw string) (Permission, error) {
+	perm := Permission(strings.ToLower(strings.TrimSpace(raw)))
+	if !RoleAdmin.Can(perm) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidScope, raw)
+	}
+	return perm, nil
+}
+
+// ScopesAllow reports whether scopes contain perm. Nil scopes allow
+// everything.
+func ScopesAllow(scopes []Permission, perm Permission) bool {
+	if scopes == nil {
+		return true
+	}
+	for _, scope := range scopes {
+		if scope == perm {
+			return true
+		}
+	}
+	return false
+}
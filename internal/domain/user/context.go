@@ -0,0 +1,47 @@
+package user
+
+import "context"
+
+type contextKey string
+
+const (
+	userIDContextKey contextKey = "userID"
+	roleContextKey   contextKey = "role"
+	scopesContextKey contextKey = "scopes"
+)
+
+// WithPrincipal returns a copy of ctx acting as principal. The scopes are only
+// stored when the principal is limited to them.
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	ctx = context.WithValue(ctx, userIDContextKey, principal.UserID)
+	ctx = context.WithValue(ctx, roleContextKey, principal.Role)
+	if principal.Scopes != nil {
+		ctx = context.WithValue(ctx, scopesContextKey, principal.Scopes)
+	}
+	return ctx
+}
+
+// UserIDFromContext returns the ID of the authenticated user of ctx, or 0 when
+// there is none.
+func UserIDFromContext(ctx context.Context) int {
+	userID, _ := ctx.Value(userIDContextKey).(int)
+	return userID
+}
+
+// RoleFromContext returns the role of the authenticated user of ctx.
+func RoleFromContext(ctx context.Context) Role {
+	role, _ := ctx.Value(roleContextKey).(Role)
+	return role
+}
+
+// ScopesFromContext returns the permissions the request is limited to, or nil
+// when it is not limited.
+func ScopesFromContext(ctx context.Context) []Permission {
+	scopes, _ := ctx.Value(scopesContextKey).([]Permission)
+	return scopes
+}
+
+// IsAdmin reports whether the authenticated user of ctx is an admin.
+func IsAdmin(ctx context.Context) bool {
+	return RoleFromContext(ctx) == RoleAdmin
+}
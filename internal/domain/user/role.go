@@ -0,0 +1,62 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidRole  = errors.New("invalid role")
+	ErrUserNotFound = errors.New("user not found")
+)
+
+type Role string
+
+const (
+	RoleAdmin  Role = "admin"
+	RoleEditor Role = "editor"
+	RoleViewer Role = "viewer"
+)
+
+// Permission is what a route requires from the role of the caller.
+type Permission string
+
+const (
+	PermItemsRead  Permission = "items:read"
+	PermItemsWrite Permission = "items:write"
+	// PermItemsDelete also covers restoring deleted items.
+	PermItemsDelete     Permission = "items:delete"
+	PermExportsRun      Permission = "exports:run"
+	PermWarehousesWrite Permission = "warehouses:write"
+	PermUsersManage     Permission = "users:manage"
+	// PermAPIKeysManage covers the API keys of the caller, not of other users.
+	PermAPIKeysManage Permission = "api_keys:manage"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleAdmin: {
+		PermItemsRead, PermItemsWrite, PermItemsDelete, PermExportsRun, PermWarehousesWrite, PermUsersManage,
+		PermAPIKeysManage,
+	},
+	RoleEditor: {PermItemsRead, PermItemsWrite, PermItemsDelete, PermExportsRun, PermAPIKeysManage},
+	RoleViewer: {PermItemsRead, PermAPIKeysManage},
+}
+
+func ParseRole(raw string) (Role, error) {
+	role := Role(strings.ToLower(strings.TrimSpace(raw)))
+	if _, ok := rolePermissions[role]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalidRole, raw)
+	}
+	return role, nil
+}
+
+// Can reports whether the role grants perm. Unknown roles grant nothing.
+func (r Role) Can(perm Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == perm {
+			return true
+		}
+	}
+	return false
+}
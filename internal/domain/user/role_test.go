@@ -0,0 +1,44 @@
+package user_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+)
+
+func TestParseRole(t *testing.T) {
+	role, err := user.ParseRole(" Editor ")
+	require.NoError(t, err)
+	assert.Equal(t, user.RoleEditor, role)
+
+	_, err = user.ParseRole("owner")
+	assert.ErrorIs(t, err, user.ErrInvalidRole)
+}
+
+func TestRole_Can(t *testing.T) {
+	assert.True(t, user.RoleAdmin.Can(user.PermUsersManage))
+	assert.True(t, user.RoleEditor.Can(user.PermItemsWrite))
+	assert.True(t, user.RoleEditor.Can(user.PermItemsDelete))
+	assert.False(t, user.RoleEditor.Can(user.PermWarehousesWrite))
+	assert.True(t, user.RoleViewer.Can(user.PermItemsRead))
+	assert.False(t, user.RoleViewer.Can(user.PermExportsRun))
+	assert.False(t, user.Role("").Can(user.PermItemsRead))
+}
+
+func TestParsePermission(t *testing.T) {
+	perm, err := user.ParsePermission("Items:Read")
+	require.NoError(t, err)
+	assert.Equal(t, user.PermItemsRead, perm)
+
+	_, err = user.ParsePermission("items:*")
+	assert.ErrorIs(t, err, user.ErrInvalidScope)
+}
+
+func TestScopesAllow(t *testing.T) {
+	assert.True(t, user.ScopesAllow(nil, user.PermItemsDelete))
+	assert.True(t, user.ScopesAllow([]user.Permission{user.PermItemsRead}, user.PermItemsRead))
+	assert.False(t, user.ScopesAllow([]user.Permission{user.PermItemsRead}, user.PermItemsWrite))
+	assert.False(t, user.ScopesAllow([]user.Permission{}, user.PermItemsRead))
+}
@@ -0,0 +1,59 @@
+package user
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused means a refresh token was presented again after
+	// being exchanged, so it may have been stolen; its whole family is revoked.
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+)
+
+// RefreshToken is a refresh token handed out to a user. Only the hash of the
+// token is stored. Every refresh exchanges the token for a new one of the same
+// family, which starts at login.
+type RefreshToken struct {
+	ID        string    `gorm:"primaryKey"`
+	FamilyID  string    `gorm:"index;not null"`
+	UserID    int       `gorm:"index;not null"`
+	TokenHash string    `gorm:"uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"index;not null"`
+	CreatedAt time.Time
+	// AccessTokenID is the jti of the access token issued with the refresh
+	// token, denied when the family is revoked.
+	AccessTokenID        string
+	AccessTokenExpiresAt time.Time
+	RotatedAt            *time.Time
+	RevokedAt            *time.Time
+}
+
+// RevokedToken denies an access token, by its jti, until it expires.
+type RevokedToken struct {
+	ID        string    `gorm:"primaryKey"`
+	ExpiresAt time.Time `gorm:"index;not null"`
+}
+
+// TokenPair is returned by a login or a refresh.
+type TokenPair struct {
+	AccessToken  string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	// ExpiresIn is the lifetime of the access token in seconds.
+	ExpiresIn int `json:"expires_in"`
+}
+
+// SigningKey signs the access tokens, identified by ID in their kid header.
+// Keys are rotated: a new key is published before it signs, and an old one is
+// kept until the tokens it signed have expired.
+type SigningKey struct {
+	ID        string `gorm:"primaryKey"`
+	Algorithm string `gorm:"not null"`
+	// PrivateKey is PKCS #8 DER, sealed with the key-encryption key of the
+	// configuration when Encrypted. Keys stored before encryption are plain.
+	PrivateKey []byte    `gorm:"not null"`
+	Encrypted  bool      `gorm:"not null;default:false"`
+	CreatedAt  time.Time `gorm:"not null"`
+	ExpiresAt  time.Time `gorm:"index;not null"`
+}
@@ -4,6 +4,8 @@ type User struct {
 	ID       int    `json:"id" gorm:"primaryKey"`
 	Username string `json:"username" gorm:"unique" validate:"required,min=3,max=32"`
 	Password string `json:"password" validate:"required,min=8,max=32"`
+	// Role is viewer on registration; admins change it through the API.
+	Role Role `json:"role" gorm:"not null;default:'viewer'"`
 }
 
 type Credentials struct {
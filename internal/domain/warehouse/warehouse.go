@@ -0,0 +1,28 @@
+package warehouse
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrWarehouseNotFound = errors.New("warehouse not found")
+	ErrInvalidWarehouse  = errors.New("invalid warehouse")
+	ErrCodeExists        = errors.New("warehouse with this code already exists")
+	ErrCodeImmutable     = errors.New("the code of a warehouse cannot be changed")
+	// ErrWarehouseNotEmpty means the warehouse still holds stock of some item.
+	ErrWarehouseNotEmpty = errors.New("warehouse still holds stock")
+	ErrDefaultWarehouse  = errors.New("the default warehouse cannot be deleted")
+)
+
+// Warehouse is a location holding stock. Items without an explicit warehouse
+// are stocked in the default one, created by the migrations.
+type Warehouse struct {
+	ID        int       `json:"id" gorm:"primaryKey"`
+	Code      string    `json:"code" gorm:"uniqueIndex" validate:"required,alphanum,max=32"`
+	Name      string    `json:"name" validate:"required,max=100"`
+	Address   string    `json:"address,omitempty" validate:"omitempty,max=255"`
+	Default   bool      `json:"default" gorm:"column:is_default;not null;default:false"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
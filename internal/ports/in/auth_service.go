@@ -2,11 +2,20 @@ package in
 
 import (
 	"context"
+	"time"
 
 	"github.com/teamcubation/go-items-challenge/internal/domain/user"
 )
 
 type AuthService interface {
 	RegisterUser(ctx context.Context, user *user.User) (*user.User, error)
-	Login(ctx context.Context, crd user.Credentials) (string, error)
+	Login(ctx context.Context, crd user.Credentials) (*user.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (*user.TokenPair, error)
+	Logout(ctx context.Context, refreshToken string) error
+	SetUserRole(ctx context.Context, id int, role user.Role) error
+
+	CreateAPIKey(ctx context.Context, name string, scopes []user.Permission, expiresAt *time.Time) (*user.CreatedAPIKey, error)
+	ListAPIKeys(ctx context.Context) ([]user.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) error
+	AuthenticateAPIKey(ctx context.Context, key string) (*user.Principal, error)
 }
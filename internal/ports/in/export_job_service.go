@@ -0,0 +1,15 @@
+package in
+
+import (
+	"context"
+	"io"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+type ExportJobService interface {
+	CreateExportJob(ctx context.Context, format string, query item.ListQuery) (*item.ExportJob, error)
+	GetExportJob(ctx context.Context, id string) (*item.ExportJob, error)
+	// OpenExportFile returns the file of a completed job. The caller closes it.
+	OpenExportFile(ctx context.Context, id string) (*item.ExportJob, io.ReadSeekCloser, error)
+}
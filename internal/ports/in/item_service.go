@@ -2,6 +2,7 @@ package in
 
 import (
 	"context"
+	"time"
 
 	"github.com/teamcubation/go-items-challenge/internal/domain/item"
 )
@@ -9,8 +10,34 @@ import (
 type ItemService interface {
 	CreateItem(ctx context.Context, itm *item.Item) (*item.Item, error)
 	UpdateItem(ctx context.Context, itm *item.Item) (*item.Item, error)
-	DeleteItem(ctx context.Context, id int) (*item.Item, error)
+	PatchItem(ctx context.Context, id int, version int, patchType item.PatchType, patch []byte) (*item.Item, error)
+	DeleteItem(ctx context.Context, id int, version int) (*item.Item, error)
+	RestoreItem(ctx context.Context, id int, version int) (*item.Item, error)
+	ItemHistory(ctx context.Context, query item.HistoryQuery) (*item.HistoryResponse, error)
 	GetItemByID(ctx context.Context, id int) (*item.Item, error)
-	ListItems(ctx context.Context, status string, limit int, page int) ([]*item.Item, int, error)
+	GetItemAsOf(ctx context.Context, id int, at time.Time) (*item.Item, error)
+	RevertItem(ctx context.Context, id int, revision int, version int) (*item.Item, error)
+	TransitionItem(ctx context.Context, id int, to item.Status, version int) (*item.Item, error)
+	RecordStockMovement(ctx context.Context, id int, movement *item.StockMovement) (*item.StockMovement, error)
+	ListStockMovements(ctx context.Context, query item.MovementQuery) (*item.MovementResponse, error)
+	ReserveStock(ctx context.Context, id int, quantity int, ttl time.Duration, warehouseID int) (*item.Reservation, error)
+	GetReservation(ctx context.Context, id string) (*item.Reservation, error)
+	ConfirmReservation(ctx context.Context, id string) (*item.Reservation, error)
+	ReleaseReservation(ctx context.Context, id string) (*item.Reservation, error)
+	TransferStock(ctx context.Context, id int, transfer *item.StockTransfer) (*item.StockTransfer, error)
+	ListStockLevels(ctx context.Context, id int) ([]item.StockLevel, error)
+	GetCategoryReorderPoint(ctx context.Context, categoryID int) (*item.CategoryReorderPoint, error)
+	SetCategoryReorderPoint(ctx context.Context, point *item.CategoryReorderPoint) (*item.CategoryReorderPoint, error)
+	DeleteCategoryReorderPoint(ctx context.Context, categoryID int) error
+	GrantItem(ctx context.Context, id int, userID int) (*item.ItemGrant, error)
+	RevokeItemGrant(ctx context.Context, id int, userID int) error
+	ListItemGrants(ctx context.Context, id int) ([]item.ItemGrant, error)
+	ListItems(ctx context.Context, query item.ListQuery) (*item.Response, error)
+	ExportItems(ctx context.Context, query item.ListQuery, fn func(itm *item.Item) error) error
 	ItemExistsByCode(ctx context.Context, code string) bool
+	SearchItems(ctx context.Context, query item.SearchQuery) (*item.SearchResponse, error)
+	BulkCreateItems(ctx context.Context, items []*item.Item, mode item.BulkMode) (*item.BulkReport, error)
+	BulkUpdateItems(ctx context.Context, items []*item.Item, mode item.BulkMode) (*item.BulkReport, error)
+	BulkDeleteItems(ctx context.Context, entries []item.BulkDeleteEntry, mode item.BulkMode) (*item.BulkReport, error)
+	ImportItems(ctx context.Context, rows []item.ImportRow, mode item.BulkMode, dryRun bool) (*item.BulkReport, error)
 }
@@ -7,6 +7,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	user "github.com/teamcubation/go-items-challenge/internal/domain/user"
 )
 
@@ -15,23 +17,115 @@ type AuthService struct {
 	mock.Mock
 }
 
+// AuthenticateAPIKey provides a mock function with given fields: ctx, key
+func (_m *AuthService) AuthenticateAPIKey(ctx context.Context, key string) (*user.Principal, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthenticateAPIKey")
+	}
+
+	var r0 *user.Principal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*user.Principal, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *user.Principal); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.Principal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAPIKey provides a mock function with given fields: ctx, name, scopes, expiresAt
+func (_m *AuthService) CreateAPIKey(ctx context.Context, name string, scopes []user.Permission, expiresAt *time.Time) (*user.CreatedAPIKey, error) {
+	ret := _m.Called(ctx, name, scopes, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAPIKey")
+	}
+
+	var r0 *user.CreatedAPIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []user.Permission, *time.Time) (*user.CreatedAPIKey, error)); ok {
+		return rf(ctx, name, scopes, expiresAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []user.Permission, *time.Time) *user.CreatedAPIKey); ok {
+		r0 = rf(ctx, name, scopes, expiresAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.CreatedAPIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []user.Permission, *time.Time) error); ok {
+		r1 = rf(ctx, name, scopes, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAPIKeys provides a mock function with given fields: ctx
+func (_m *AuthService) ListAPIKeys(ctx context.Context) ([]user.APIKey, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAPIKeys")
+	}
+
+	var r0 []user.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]user.APIKey, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []user.APIKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]user.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Login provides a mock function with given fields: ctx, crd
-func (_m *AuthService) Login(ctx context.Context, crd user.Credentials) (string, error) {
+func (_m *AuthService) Login(ctx context.Context, crd user.Credentials) (*user.TokenPair, error) {
 	ret := _m.Called(ctx, crd)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
 	}
 
-	var r0 string
+	var r0 *user.TokenPair
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, user.Credentials) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, user.Credentials) (*user.TokenPair, error)); ok {
 		return rf(ctx, crd)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, user.Credentials) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, user.Credentials) *user.TokenPair); ok {
 		r0 = rf(ctx, crd)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.TokenPair)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, user.Credentials) error); ok {
@@ -43,6 +137,54 @@ func (_m *AuthService) Login(ctx context.Context, crd user.Credentials) (string,
 	return r0, r1
 }
 
+// Logout provides a mock function with given fields: ctx, refreshToken
+func (_m *AuthService) Logout(ctx context.Context, refreshToken string) error {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Refresh provides a mock function with given fields: ctx, refreshToken
+func (_m *AuthService) Refresh(ctx context.Context, refreshToken string) (*user.TokenPair, error) {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 *user.TokenPair
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*user.TokenPair, error)); ok {
+		return rf(ctx, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *user.TokenPair); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.TokenPair)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RegisterUser provides a mock function with given fields: ctx, _a1
 func (_m *AuthService) RegisterUser(ctx context.Context, _a1 *user.User) (*user.User, error) {
 	ret := _m.Called(ctx, _a1)
@@ -73,6 +215,42 @@ func (_m *AuthService) RegisterUser(ctx context.Context, _a1 *user.User) (*user.
 	return r0, r1
 }
 
+// RevokeAPIKey provides a mock function with given fields: ctx, id
+func (_m *AuthService) RevokeAPIKey(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAPIKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetUserRole provides a mock function with given fields: ctx, id, role
+func (_m *AuthService) SetUserRole(ctx context.Context, id int, role user.Role) error {
+	ret := _m.Called(ctx, id, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, user.Role) error); ok {
+		r0 = rf(ctx, id, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewAuthService creates a new instance of AuthService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAuthService(t interface {
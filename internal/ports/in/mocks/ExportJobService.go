@@ -0,0 +1,131 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	io "io"
+
+	item "github.com/teamcubation/go-items-challenge/internal/domain/item"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExportJobService is an autogenerated mock type for the ExportJobService type
+type ExportJobService struct {
+	mock.Mock
+}
+
+// CreateExportJob provides a mock function with given fields: ctx, format, query
+func (_m *ExportJobService) CreateExportJob(ctx context.Context, format string, query item.ListQuery) (*item.ExportJob, error) {
+	ret := _m.Called(ctx, format, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateExportJob")
+	}
+
+	var r0 *item.ExportJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, item.ListQuery) (*item.ExportJob, error)); ok {
+		return rf(ctx, format, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, item.ListQuery) *item.ExportJob); ok {
+		r0 = rf(ctx, format, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.ExportJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, item.ListQuery) error); ok {
+		r1 = rf(ctx, format, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExportJob provides a mock function with given fields: ctx, id
+func (_m *ExportJobService) GetExportJob(ctx context.Context, id string) (*item.ExportJob, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExportJob")
+	}
+
+	var r0 *item.ExportJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*item.ExportJob, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *item.ExportJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.ExportJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OpenExportFile provides a mock function with given fields: ctx, id
+func (_m *ExportJobService) OpenExportFile(ctx context.Context, id string) (*item.ExportJob, io.ReadSeekCloser, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenExportFile")
+	}
+
+	var r0 *item.ExportJob
+	var r1 io.ReadSeekCloser
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*item.ExportJob, io.ReadSeekCloser, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *item.ExportJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.ExportJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) io.ReadSeekCloser); ok {
+		r1 = rf(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadSeekCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewExportJobService creates a new instance of ExportJobService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExportJobService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExportJobService {
+	mock := &ExportJobService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -8,6 +8,8 @@ import (
 	item "github.com/teamcubation/go-items-challenge/internal/domain/item"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // ItemService is an autogenerated mock type for the ItemService type
@@ -15,6 +17,126 @@ type ItemService struct {
 	mock.Mock
 }
 
+// BulkCreateItems provides a mock function with given fields: ctx, items, mode
+func (_m *ItemService) BulkCreateItems(ctx context.Context, items []*item.Item, mode item.BulkMode) (*item.BulkReport, error) {
+	ret := _m.Called(ctx, items, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkCreateItems")
+	}
+
+	var r0 *item.BulkReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*item.Item, item.BulkMode) (*item.BulkReport, error)); ok {
+		return rf(ctx, items, mode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*item.Item, item.BulkMode) *item.BulkReport); ok {
+		r0 = rf(ctx, items, mode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.BulkReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*item.Item, item.BulkMode) error); ok {
+		r1 = rf(ctx, items, mode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BulkDeleteItems provides a mock function with given fields: ctx, entries, mode
+func (_m *ItemService) BulkDeleteItems(ctx context.Context, entries []item.BulkDeleteEntry, mode item.BulkMode) (*item.BulkReport, error) {
+	ret := _m.Called(ctx, entries, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkDeleteItems")
+	}
+
+	var r0 *item.BulkReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []item.BulkDeleteEntry, item.BulkMode) (*item.BulkReport, error)); ok {
+		return rf(ctx, entries, mode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []item.BulkDeleteEntry, item.BulkMode) *item.BulkReport); ok {
+		r0 = rf(ctx, entries, mode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.BulkReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []item.BulkDeleteEntry, item.BulkMode) error); ok {
+		r1 = rf(ctx, entries, mode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BulkUpdateItems provides a mock function with given fields: ctx, items, mode
+func (_m *ItemService) BulkUpdateItems(ctx context.Context, items []*item.Item, mode item.BulkMode) (*item.BulkReport, error) {
+	ret := _m.Called(ctx, items, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkUpdateItems")
+	}
+
+	var r0 *item.BulkReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*item.Item, item.BulkMode) (*item.BulkReport, error)); ok {
+		return rf(ctx, items, mode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*item.Item, item.BulkMode) *item.BulkReport); ok {
+		r0 = rf(ctx, items, mode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.BulkReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*item.Item, item.BulkMode) error); ok {
+		r1 = rf(ctx, items, mode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConfirmReservation provides a mock function with given fields: ctx, id
+func (_m *ItemService) ConfirmReservation(ctx context.Context, id string) (*item.Reservation, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmReservation")
+	}
+
+	var r0 *item.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*item.Reservation, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *item.Reservation); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateItem provides a mock function with given fields: ctx, itm
 func (_m *ItemService) CreateItem(ctx context.Context, itm *item.Item) (*item.Item, error) {
 	ret := _m.Called(ctx, itm)
@@ -45,9 +167,27 @@ func (_m *ItemService) CreateItem(ctx context.Context, itm *item.Item) (*item.It
 	return r0, r1
 }
 
-// DeleteItem provides a mock function with given fields: ctx, id
-func (_m *ItemService) DeleteItem(ctx context.Context, id int) (*item.Item, error) {
-	ret := _m.Called(ctx, id)
+// DeleteCategoryReorderPoint provides a mock function with given fields: ctx, categoryID
+func (_m *ItemService) DeleteCategoryReorderPoint(ctx context.Context, categoryID int) error {
+	ret := _m.Called(ctx, categoryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCategoryReorderPoint")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, categoryID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteItem provides a mock function with given fields: ctx, id, version
+func (_m *ItemService) DeleteItem(ctx context.Context, id int, version int) (*item.Item, error) {
+	ret := _m.Called(ctx, id, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteItem")
@@ -55,19 +195,97 @@ func (_m *ItemService) DeleteItem(ctx context.Context, id int) (*item.Item, erro
 
 	var r0 *item.Item
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) (*item.Item, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*item.Item, error)); ok {
+		return rf(ctx, id, version)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int) *item.Item); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *item.Item); ok {
+		r0 = rf(ctx, id, version)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*item.Item)
 		}
 	}
 
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportItems provides a mock function with given fields: ctx, query, fn
+func (_m *ItemService) ExportItems(ctx context.Context, query item.ListQuery, fn func(*item.Item) error) error {
+	ret := _m.Called(ctx, query, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportItems")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, item.ListQuery, func(*item.Item) error) error); ok {
+		r0 = rf(ctx, query, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetCategoryReorderPoint provides a mock function with given fields: ctx, categoryID
+func (_m *ItemService) GetCategoryReorderPoint(ctx context.Context, categoryID int) (*item.CategoryReorderPoint, error) {
+	ret := _m.Called(ctx, categoryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCategoryReorderPoint")
+	}
+
+	var r0 *item.CategoryReorderPoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*item.CategoryReorderPoint, error)); ok {
+		return rf(ctx, categoryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *item.CategoryReorderPoint); ok {
+		r0 = rf(ctx, categoryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.CategoryReorderPoint)
+		}
+	}
+
 	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = rf(ctx, id)
+		r1 = rf(ctx, categoryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetItemAsOf provides a mock function with given fields: ctx, id, at
+func (_m *ItemService) GetItemAsOf(ctx context.Context, id int, at time.Time) (*item.Item, error) {
+	ret := _m.Called(ctx, id, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItemAsOf")
+	}
+
+	var r0 *item.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) (*item.Item, error)); ok {
+		return rf(ctx, id, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) *item.Item); ok {
+		r0 = rf(ctx, id, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time) error); ok {
+		r1 = rf(ctx, id, at)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -105,6 +323,96 @@ func (_m *ItemService) GetItemByID(ctx context.Context, id int) (*item.Item, err
 	return r0, r1
 }
 
+// GetReservation provides a mock function with given fields: ctx, id
+func (_m *ItemService) GetReservation(ctx context.Context, id string) (*item.Reservation, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReservation")
+	}
+
+	var r0 *item.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*item.Reservation, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *item.Reservation); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrantItem provides a mock function with given fields: ctx, id, userID
+func (_m *ItemService) GrantItem(ctx context.Context, id int, userID int) (*item.ItemGrant, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GrantItem")
+	}
+
+	var r0 *item.ItemGrant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*item.ItemGrant, error)); ok {
+		return rf(ctx, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *item.ItemGrant); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.ItemGrant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportItems provides a mock function with given fields: ctx, rows, mode, dryRun
+func (_m *ItemService) ImportItems(ctx context.Context, rows []item.ImportRow, mode item.BulkMode, dryRun bool) (*item.BulkReport, error) {
+	ret := _m.Called(ctx, rows, mode, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportItems")
+	}
+
+	var r0 *item.BulkReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []item.ImportRow, item.BulkMode, bool) (*item.BulkReport, error)); ok {
+		return rf(ctx, rows, mode, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []item.ImportRow, item.BulkMode, bool) *item.BulkReport); ok {
+		r0 = rf(ctx, rows, mode, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.BulkReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []item.ImportRow, item.BulkMode, bool) error); ok {
+		r1 = rf(ctx, rows, mode, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ItemExistsByCode provides a mock function with given fields: ctx, code
 func (_m *ItemService) ItemExistsByCode(ctx context.Context, code string) bool {
 	ret := _m.Called(ctx, code)
@@ -123,41 +431,472 @@ func (_m *ItemService) ItemExistsByCode(ctx context.Context, code string) bool {
 	return r0
 }
 
-// ListItems provides a mock function with given fields: ctx, status, limit, page
-func (_m *ItemService) ListItems(ctx context.Context, status string, limit int, page int) ([]*item.Item, int, error) {
-	ret := _m.Called(ctx, status, limit, page)
+// ItemHistory provides a mock function with given fields: ctx, query
+func (_m *ItemService) ItemHistory(ctx context.Context, query item.HistoryQuery) (*item.HistoryResponse, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ItemHistory")
+	}
+
+	var r0 *item.HistoryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, item.HistoryQuery) (*item.HistoryResponse, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, item.HistoryQuery) *item.HistoryResponse); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.HistoryResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, item.HistoryQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListItemGrants provides a mock function with given fields: ctx, id
+func (_m *ItemService) ListItemGrants(ctx context.Context, id int) ([]item.ItemGrant, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListItemGrants")
+	}
+
+	var r0 []item.ItemGrant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]item.ItemGrant, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []item.ItemGrant); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]item.ItemGrant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListItems provides a mock function with given fields: ctx, query
+func (_m *ItemService) ListItems(ctx context.Context, query item.ListQuery) (*item.Response, error) {
+	ret := _m.Called(ctx, query)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListItems")
 	}
 
-	var r0 []*item.Item
-	var r1 int
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]*item.Item, int, error)); ok {
-		return rf(ctx, status, limit, page)
+	var r0 *item.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, item.ListQuery) (*item.Response, error)); ok {
+		return rf(ctx, query)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []*item.Item); ok {
-		r0 = rf(ctx, status, limit, page)
+	if rf, ok := ret.Get(0).(func(context.Context, item.ListQuery) *item.Response); ok {
+		r0 = rf(ctx, query)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*item.Item)
+			r0 = ret.Get(0).(*item.Response)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int); ok {
-		r1 = rf(ctx, status, limit, page)
+	if rf, ok := ret.Get(1).(func(context.Context, item.ListQuery) error); ok {
+		r1 = rf(ctx, query)
 	} else {
-		r1 = ret.Get(1).(int)
+		r1 = ret.Error(1)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
-		r2 = rf(ctx, status, limit, page)
+	return r0, r1
+}
+
+// ListStockLevels provides a mock function with given fields: ctx, id
+func (_m *ItemService) ListStockLevels(ctx context.Context, id int) ([]item.StockLevel, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStockLevels")
+	}
+
+	var r0 []item.StockLevel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]item.StockLevel, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []item.StockLevel); ok {
+		r0 = rf(ctx, id)
 	} else {
-		r2 = ret.Error(2)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]item.StockLevel)
+		}
 	}
 
-	return r0, r1, r2
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListStockMovements provides a mock function with given fields: ctx, query
+func (_m *ItemService) ListStockMovements(ctx context.Context, query item.MovementQuery) (*item.MovementResponse, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStockMovements")
+	}
+
+	var r0 *item.MovementResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, item.MovementQuery) (*item.MovementResponse, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, item.MovementQuery) *item.MovementResponse); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.MovementResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, item.MovementQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchItem provides a mock function with given fields: ctx, id, version, patchType, patch
+func (_m *ItemService) PatchItem(ctx context.Context, id int, version int, patchType item.PatchType, patch []byte) (*item.Item, error) {
+	ret := _m.Called(ctx, id, version, patchType, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchItem")
+	}
+
+	var r0 *item.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, item.PatchType, []byte) (*item.Item, error)); ok {
+		return rf(ctx, id, version, patchType, patch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, item.PatchType, []byte) *item.Item); ok {
+		r0 = rf(ctx, id, version, patchType, patch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, item.PatchType, []byte) error); ok {
+		r1 = rf(ctx, id, version, patchType, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordStockMovement provides a mock function with given fields: ctx, id, movement
+func (_m *ItemService) RecordStockMovement(ctx context.Context, id int, movement *item.StockMovement) (*item.StockMovement, error) {
+	ret := _m.Called(ctx, id, movement)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordStockMovement")
+	}
+
+	var r0 *item.StockMovement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *item.StockMovement) (*item.StockMovement, error)); ok {
+		return rf(ctx, id, movement)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *item.StockMovement) *item.StockMovement); ok {
+		r0 = rf(ctx, id, movement)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.StockMovement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *item.StockMovement) error); ok {
+		r1 = rf(ctx, id, movement)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseReservation provides a mock function with given fields: ctx, id
+func (_m *ItemService) ReleaseReservation(ctx context.Context, id string) (*item.Reservation, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseReservation")
+	}
+
+	var r0 *item.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*item.Reservation, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *item.Reservation); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReserveStock provides a mock function with given fields: ctx, id, quantity, ttl, warehouseID
+func (_m *ItemService) ReserveStock(ctx context.Context, id int, quantity int, ttl time.Duration, warehouseID int) (*item.Reservation, error) {
+	ret := _m.Called(ctx, id, quantity, ttl, warehouseID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveStock")
+	}
+
+	var r0 *item.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, time.Duration, int) (*item.Reservation, error)); ok {
+		return rf(ctx, id, quantity, ttl, warehouseID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, time.Duration, int) *item.Reservation); ok {
+		r0 = rf(ctx, id, quantity, ttl, warehouseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, time.Duration, int) error); ok {
+		r1 = rf(ctx, id, quantity, ttl, warehouseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreItem provides a mock function with given fields: ctx, id, version
+func (_m *ItemService) RestoreItem(ctx context.Context, id int, version int) (*item.Item, error) {
+	ret := _m.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreItem")
+	}
+
+	var r0 *item.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*item.Item, error)); ok {
+		return rf(ctx, id, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *item.Item); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevertItem provides a mock function with given fields: ctx, id, revision, version
+func (_m *ItemService) RevertItem(ctx context.Context, id int, revision int, version int) (*item.Item, error) {
+	ret := _m.Called(ctx, id, revision, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevertItem")
+	}
+
+	var r0 *item.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) (*item.Item, error)); ok {
+		return rf(ctx, id, revision, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) *item.Item); ok {
+		r0 = rf(ctx, id, revision, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+		r1 = rf(ctx, id, revision, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeItemGrant provides a mock function with given fields: ctx, id, userID
+func (_m *ItemService) RevokeItemGrant(ctx context.Context, id int, userID int) error {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeItemGrant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchItems provides a mock function with given fields: ctx, query
+func (_m *ItemService) SearchItems(ctx context.Context, query item.SearchQuery) (*item.SearchResponse, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchItems")
+	}
+
+	var r0 *item.SearchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, item.SearchQuery) (*item.SearchResponse, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, item.SearchQuery) *item.SearchResponse); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.SearchResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, item.SearchQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetCategoryReorderPoint provides a mock function with given fields: ctx, point
+func (_m *ItemService) SetCategoryReorderPoint(ctx context.Context, point *item.CategoryReorderPoint) (*item.CategoryReorderPoint, error) {
+	ret := _m.Called(ctx, point)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCategoryReorderPoint")
+	}
+
+	var r0 *item.CategoryReorderPoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *item.CategoryReorderPoint) (*item.CategoryReorderPoint, error)); ok {
+		return rf(ctx, point)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *item.CategoryReorderPoint) *item.CategoryReorderPoint); ok {
+		r0 = rf(ctx, point)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.CategoryReorderPoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *item.CategoryReorderPoint) error); ok {
+		r1 = rf(ctx, point)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TransferStock provides a mock function with given fields: ctx, id, transfer
+func (_m *ItemService) TransferStock(ctx context.Context, id int, transfer *item.StockTransfer) (*item.StockTransfer, error) {
+	ret := _m.Called(ctx, id, transfer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransferStock")
+	}
+
+	var r0 *item.StockTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *item.StockTransfer) (*item.StockTransfer, error)); ok {
+		return rf(ctx, id, transfer)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *item.StockTransfer) *item.StockTransfer); ok {
+		r0 = rf(ctx, id, transfer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.StockTransfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *item.StockTransfer) error); ok {
+		r1 = rf(ctx, id, transfer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TransitionItem provides a mock function with given fields: ctx, id, to, version
+func (_m *ItemService) TransitionItem(ctx context.Context, id int, to item.Status, version int) (*item.Item, error) {
+	ret := _m.Called(ctx, id, to, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransitionItem")
+	}
+
+	var r0 *item.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, item.Status, int) (*item.Item, error)); ok {
+		return rf(ctx, id, to, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, item.Status, int) *item.Item); ok {
+		r0 = rf(ctx, id, to, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, item.Status, int) error); ok {
+		r1 = rf(ctx, id, to, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // UpdateItem provides a mock function with given fields: ctx, itm
@@ -0,0 +1,168 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	warehouse "github.com/teamcubation/go-items-challenge/internal/domain/warehouse"
+)
+
+// WarehouseService is an autogenerated mock type for the WarehouseService type
+type WarehouseService struct {
+	mock.Mock
+}
+
+// CreateWarehouse provides a mock function with given fields: ctx, w
+func (_m *WarehouseService) CreateWarehouse(ctx context.Context, w *warehouse.Warehouse) (*warehouse.Warehouse, error) {
+	ret := _m.Called(ctx, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWarehouse")
+	}
+
+	var r0 *warehouse.Warehouse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *warehouse.Warehouse) (*warehouse.Warehouse, error)); ok {
+		return rf(ctx, w)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *warehouse.Warehouse) *warehouse.Warehouse); ok {
+		r0 = rf(ctx, w)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*warehouse.Warehouse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *warehouse.Warehouse) error); ok {
+		r1 = rf(ctx, w)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteWarehouse provides a mock function with given fields: ctx, id
+func (_m *WarehouseService) DeleteWarehouse(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWarehouse")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetWarehouse provides a mock function with given fields: ctx, id
+func (_m *WarehouseService) GetWarehouse(ctx context.Context, id int) (*warehouse.Warehouse, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWarehouse")
+	}
+
+	var r0 *warehouse.Warehouse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*warehouse.Warehouse, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *warehouse.Warehouse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*warehouse.Warehouse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWarehouses provides a mock function with given fields: ctx
+func (_m *WarehouseService) ListWarehouses(ctx context.Context) ([]warehouse.Warehouse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWarehouses")
+	}
+
+	var r0 []warehouse.Warehouse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]warehouse.Warehouse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []warehouse.Warehouse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]warehouse.Warehouse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateWarehouse provides a mock function with given fields: ctx, id, w
+func (_m *WarehouseService) UpdateWarehouse(ctx context.Context, id int, w *warehouse.Warehouse) (*warehouse.Warehouse, error) {
+	ret := _m.Called(ctx, id, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWarehouse")
+	}
+
+	var r0 *warehouse.Warehouse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *warehouse.Warehouse) (*warehouse.Warehouse, error)); ok {
+		return rf(ctx, id, w)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *warehouse.Warehouse) *warehouse.Warehouse); ok {
+		r0 = rf(ctx, id, w)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*warehouse.Warehouse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *warehouse.Warehouse) error); ok {
+		r1 = rf(ctx, id, w)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewWarehouseService creates a new instance of WarehouseService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWarehouseService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WarehouseService {
+	mock := &WarehouseService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,15 @@
+package in
+
+import (
+	"context"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/warehouse"
+)
+
+type WarehouseService interface {
+	CreateWarehouse(ctx context.Context, w *warehouse.Warehouse) (*warehouse.Warehouse, error)
+	GetWarehouse(ctx context.Context, id int) (*warehouse.Warehouse, error)
+	ListWarehouses(ctx context.Context) ([]warehouse.Warehouse, error)
+	UpdateWarehouse(ctx context.Context, id int, w *warehouse.Warehouse) (*warehouse.Warehouse, error)
+	DeleteWarehouse(ctx context.Context, id int) error
+}
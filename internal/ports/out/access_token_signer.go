@@ -0,0 +1,14 @@
+package out
+
+import (
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+)
+
+// AccessTokenSigner signs the access tokens handed out at login and refresh.
+type AccessTokenSigner interface {
+	// SignAccessToken signs an access token identified by jti, and returns it
+	// with its expiry.
+	SignAccessToken(userID int, role user.Role, jti string) (string, time.Time, error)
+}
@@ -0,0 +1,22 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+)
+
+// APIKeyRepository stores the API keys of the users.
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, key *user.APIKey) error
+	// GetAPIKeyByHash returns nil when there is no key with that hash.
+	GetAPIKeyByHash(ctx context.Context, hash string) (*user.APIKey, error)
+	// ListAPIKeys returns the keys of the user that are not revoked, newest
+	// first.
+	ListAPIKeys(ctx context.Context, userID int) ([]user.APIKey, error)
+	// RevokeAPIKey returns user.ErrAPIKeyNotFound when the user has no
+	// unrevoked key with the ID.
+	RevokeAPIKey(ctx context.Context, userID int, id string, at time.Time) error
+	TouchAPIKey(ctx context.Context, id string, at time.Time) error
+}
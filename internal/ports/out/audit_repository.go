@@ -0,0 +1,21 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+// AuditRepository stores the append-only history of item changes. Entries are
+// written in the transaction carried by ctx, if any.
+type AuditRepository interface {
+	CreateAuditEntry(ctx context.Context, entry *item.AuditEntry) error
+	ListAuditEntries(ctx context.Context, query item.HistoryQuery) (*item.HistoryResponse, error)
+	// FindRevision returns the entry that created the given version of the
+	// item, or nil.
+	FindRevision(ctx context.Context, itemID int, version int) (*item.AuditEntry, error)
+	// FindRevisionAt returns the last entry of the item recorded at or before
+	// at, or nil.
+	FindRevisionAt(ctx context.Context, itemID int, at time.Time) (*item.AuditEntry, error)
+}
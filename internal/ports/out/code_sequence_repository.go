@@ -0,0 +1,11 @@
+package out
+
+import "context"
+
+// CodeSequenceRepository numbers the generated item codes.
+type CodeSequenceRepository interface {
+	// NextCodeSequence returns the next number of the sequence named key,
+	// starting at 1. Numbers are never handed out twice, even when the
+	// transaction that took one rolls back.
+	NextCodeSequence(ctx context.Context, key string) (int64, error)
+}
@@ -0,0 +1,37 @@
+package out
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+type ExportJobRepository interface {
+	CreateExportJob(ctx context.Context, job *item.ExportJob) error
+	// GetExportJob returns nil when the job does not exist.
+	GetExportJob(ctx context.Context, id string) (*item.ExportJob, error)
+	// UpdateExportJob returns item.ErrExportJobLeaseLost when the job is no
+	// longer held by the claim in job.ClaimedBy.
+	UpdateExportJob(ctx context.Context, job *item.ExportJob) error
+	// ClaimExportJob marks the oldest pending job as running under claim and
+	// returns it, or nil when no job is pending. A job is claimed by one
+	// caller only.
+	ClaimExportJob(ctx context.Context, claim string) (*item.ExportJob, error)
+	// HeartbeatExportJob renews the lease of claim on the job, or returns
+	// item.ErrExportJobLeaseLost when the claim no longer holds it.
+	HeartbeatExportJob(ctx context.Context, id, claim string, at time.Time) error
+	// RequeueStaleExportJobs moves the running jobs whose last heartbeat is
+	// before staleBefore, as their worker died, back to pending and returns
+	// how many were moved.
+	RequeueStaleExportJobs(ctx context.Context, staleBefore time.Time) (int, error)
+}
+
+// ExportStorage stores the files written by export jobs. Files written with
+// Create only become visible to Open once the writer is closed.
+type ExportStorage interface {
+	Create(name string) (io.WriteCloser, error)
+	Open(name string) (io.ReadSeekCloser, error)
+	Remove(name string) error
+}
@@ -0,0 +1,38 @@
+package out
+
+import (
+	"io"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+// ItemExporter writes items in one export format.
+type ItemExporter interface {
+	ContentType() string
+	FileExtension() string
+	NewEncoder(w io.Writer) (ItemEncoder, error)
+}
+
+// ItemEncoder writes a stream of items. Close must be called once every item
+// has been encoded to write the end of the document; it does not close the
+// underlying writer.
+type ItemEncoder interface {
+	Encode(itm *item.Item) error
+	Flush() error
+	Close() error
+}
+
+// ItemExporters finds an exporter by its case-insensitive format name.
+type ItemExporters interface {
+	Lookup(format string) (ItemExporter, bool)
+}
+
+// NegotiatedItemExporters also lists the formats and picks an exporter for
+// the Accept header of a request.
+type NegotiatedItemExporters interface {
+	ItemExporters
+	Formats() []string
+	// Negotiate returns the format name and the exporter of the preferred
+	// media type of accept.
+	Negotiate(accept string) (string, ItemExporter, bool)
+}
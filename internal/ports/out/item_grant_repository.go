@@ -0,0 +1,19 @@
+package out
+
+import (
+	"context"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+// ItemGrantRepository stores the users each item is shared with.
+type ItemGrantRepository interface {
+	// CreateItemGrant does nothing if the item is already shared with the
+	// user.
+	CreateItemGrant(ctx context.Context, grant *item.ItemGrant) error
+	// DeleteItemGrant returns item.ErrGrantNotFound if the item is not shared
+	// with the user.
+	DeleteItemGrant(ctx context.Context, itemID, userID int) error
+	ListItemGrants(ctx context.Context, itemID int) ([]item.ItemGrant, error)
+	HasItemGrant(ctx context.Context, itemID, userID int) (bool, error)
+}
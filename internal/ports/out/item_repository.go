@@ -2,6 +2,7 @@ package out
 
 import (
 	"context"
+	"time"
 
 	"github.com/teamcubation/go-items-challenge/internal/domain/item"
 	"github.com/teamcubation/go-items-challenge/internal/domain/user"
@@ -10,13 +11,48 @@ import (
 type ItemRepository interface {
 	CreateItem(ctx context.Context, itm *item.Item) (*item.Item, error)
 	GetItemByID(ctx context.Context, id int) (*item.Item, error)
+	// FindItem returns the item, or nil when there is none. Soft-deleted items
+	// are only returned with includeDeleted.
+	FindItem(ctx context.Context, id int, includeDeleted bool) (*item.Item, error)
+	// AdjustStock adds the deltas to the on-hand and reserved stock of the item
+	// and updates its status, failing with item.ErrInsufficientStock if more
+	// stock would be reserved than is on hand.
+	AdjustStock(ctx context.Context, id int, stockDelta, reservedDelta int) (*item.Item, error)
+	// SetLowStock sets the low stock flag of the item and reports whether it
+	// changed, so that only one caller sees each crossing. A change bumps the
+	// version of the item.
+	SetLowStock(ctx context.Context, id int, lowStock bool) (bool, error)
+	// UpdateStatus sets the status of the item if it is still at version.
+	UpdateStatus(ctx context.Context, id int, status item.Status, version int) (*item.Item, error)
+	GetItemByCode(ctx context.Context, code string) (*item.Item, error)
 	UpdateItem(ctx context.Context, itm *item.Item) (*item.Item, error)
-	DeleteItem(ctx context.Context, id int) (*item.Item, error)
+	DeleteItem(ctx context.Context, id int, version int) (*item.Item, error)
+	RestoreItem(ctx context.Context, id int, version int) (*item.Item, error)
+	PurgeDeletedItems(ctx context.Context, before time.Time) (int, error)
 	ItemExistsByCode(ctx context.Context, code string) bool
-	ListItems(ctx context.Context, status string, limit int, page int) (*item.Response, error)
+	ListItems(ctx context.Context, query item.ListQuery) (*item.Response, error)
+	IterateItems(ctx context.Context, query item.ListQuery, fn func(itm *item.Item) error) error
+	CountItems(ctx context.Context, query item.ListQuery) (int, error)
+	ItemSearcher
+	Transactor
+}
+
+// Transactor runs fn in a transaction. Repository calls made with the context
+// received by fn take part in it.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type ItemSearcher interface {
+	SearchItems(ctx context.Context, query item.SearchQuery) (*item.SearchResponse, error)
 }
 
 type UserRepository interface {
 	CreateUser(ctx context.Context, u *user.User) error
 	GetUserByUsername(ctx context.Context, username string) (*user.User, error)
+	// GetUserByID returns nil when there is no user with the ID.
+	GetUserByID(ctx context.Context, id int) (*user.User, error)
+	// UpdateUserRole returns user.ErrUserNotFound when there is no user with
+	// the ID.
+	UpdateUserRole(ctx context.Context, id int, role user.Role) error
 }
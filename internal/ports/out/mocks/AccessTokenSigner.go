@@ -0,0 +1,65 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	user "github.com/teamcubation/go-items-challenge/internal/domain/user"
+)
+
+// AccessTokenSigner is an autogenerated mock type for the AccessTokenSigner type
+type AccessTokenSigner struct {
+	mock.Mock
+}
+
+// SignAccessToken provides a mock function with given fields: userID, role, jti
+func (_m *AccessTokenSigner) SignAccessToken(userID int, role user.Role, jti string) (string, time.Time, error) {
+	ret := _m.Called(userID, role, jti)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignAccessToken")
+	}
+
+	var r0 string
+	var r1 time.Time
+	var r2 error
+	if rf, ok := ret.Get(0).(func(int, user.Role, string) (string, time.Time, error)); ok {
+		return rf(userID, role, jti)
+	}
+	if rf, ok := ret.Get(0).(func(int, user.Role, string) string); ok {
+		r0 = rf(userID, role, jti)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, user.Role, string) time.Time); ok {
+		r1 = rf(userID, role, jti)
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	if rf, ok := ret.Get(2).(func(int, user.Role, string) error); ok {
+		r2 = rf(userID, role, jti)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewAccessTokenSigner creates a new instance of AccessTokenSigner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAccessTokenSigner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AccessTokenSigner {
+	mock := &AccessTokenSigner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,139 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	item "github.com/teamcubation/go-items-challenge/internal/domain/item"
+
+	time "time"
+)
+
+// AuditRepository is an autogenerated mock type for the AuditRepository type
+type AuditRepository struct {
+	mock.Mock
+}
+
+// CreateAuditEntry provides a mock function with given fields: ctx, entry
+func (_m *AuditRepository) CreateAuditEntry(ctx context.Context, entry *item.AuditEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAuditEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *item.AuditEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindRevision provides a mock function with given fields: ctx, itemID, version
+func (_m *AuditRepository) FindRevision(ctx context.Context, itemID int, version int) (*item.AuditEntry, error) {
+	ret := _m.Called(ctx, itemID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRevision")
+	}
+
+	var r0 *item.AuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*item.AuditEntry, error)); ok {
+		return rf(ctx, itemID, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *item.AuditEntry); ok {
+		r0 = rf(ctx, itemID, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, itemID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindRevisionAt provides a mock function with given fields: ctx, itemID, at
+func (_m *AuditRepository) FindRevisionAt(ctx context.Context, itemID int, at time.Time) (*item.AuditEntry, error) {
+	ret := _m.Called(ctx, itemID, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRevisionAt")
+	}
+
+	var r0 *item.AuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) (*item.AuditEntry, error)); ok {
+		return rf(ctx, itemID, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) *item.AuditEntry); ok {
+		r0 = rf(ctx, itemID, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time) error); ok {
+		r1 = rf(ctx, itemID, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAuditEntries provides a mock function with given fields: ctx, query
+func (_m *AuditRepository) ListAuditEntries(ctx context.Context, query item.HistoryQuery) (*item.HistoryResponse, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuditEntries")
+	}
+
+	var r0 *item.HistoryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, item.HistoryQuery) (*item.HistoryResponse, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, item.HistoryQuery) *item.HistoryResponse); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.HistoryResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, item.HistoryQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAuditRepository creates a new instance of AuditRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditRepository {
+	mock := &AuditRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
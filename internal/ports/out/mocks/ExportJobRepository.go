@@ -0,0 +1,173 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	item "github.com/teamcubation/go-items-challenge/internal/domain/item"
+
+	time "time"
+)
+
+// ExportJobRepository is an autogenerated mock type for the ExportJobRepository type
+type ExportJobRepository struct {
+	mock.Mock
+}
+
+// ClaimExportJob provides a mock function with given fields: ctx, claim
+func (_m *ExportJobRepository) ClaimExportJob(ctx context.Context, claim string) (*item.ExportJob, error) {
+	ret := _m.Called(ctx, claim)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimExportJob")
+	}
+
+	var r0 *item.ExportJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*item.ExportJob, error)); ok {
+		return rf(ctx, claim)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *item.ExportJob); ok {
+		r0 = rf(ctx, claim)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.ExportJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, claim)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateExportJob provides a mock function with given fields: ctx, job
+func (_m *ExportJobRepository) CreateExportJob(ctx context.Context, job *item.ExportJob) error {
+	ret := _m.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateExportJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *item.ExportJob) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetExportJob provides a mock function with given fields: ctx, id
+func (_m *ExportJobRepository) GetExportJob(ctx context.Context, id string) (*item.ExportJob, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExportJob")
+	}
+
+	var r0 *item.ExportJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*item.ExportJob, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *item.ExportJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.ExportJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HeartbeatExportJob provides a mock function with given fields: ctx, id, claim, at
+func (_m *ExportJobRepository) HeartbeatExportJob(ctx context.Context, id string, claim string, at time.Time) error {
+	ret := _m.Called(ctx, id, claim, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HeartbeatExportJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, id, claim, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RequeueStaleExportJobs provides a mock function with given fields: ctx, staleBefore
+func (_m *ExportJobRepository) RequeueStaleExportJobs(ctx context.Context, staleBefore time.Time) (int, error) {
+	ret := _m.Called(ctx, staleBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequeueStaleExportJobs")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return rf(ctx, staleBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, staleBefore)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, staleBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateExportJob provides a mock function with given fields: ctx, job
+func (_m *ExportJobRepository) UpdateExportJob(ctx context.Context, job *item.ExportJob) error {
+	ret := _m.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateExportJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *item.ExportJob) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewExportJobRepository creates a new instance of ExportJobRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExportJobRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExportJobRepository {
+	mock := &ExportJobRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
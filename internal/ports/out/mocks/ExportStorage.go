@@ -0,0 +1,106 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExportStorage is an autogenerated mock type for the ExportStorage type
+type ExportStorage struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: name
+func (_m *ExportStorage) Create(name string) (io.WriteCloser, error) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 io.WriteCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (io.WriteCloser, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) io.WriteCloser); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.WriteCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Open provides a mock function with given fields: name
+func (_m *ExportStorage) Open(name string) (io.ReadSeekCloser, error) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Open")
+	}
+
+	var r0 io.ReadSeekCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (io.ReadSeekCloser, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) io.ReadSeekCloser); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadSeekCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Remove provides a mock function with given fields: name
+func (_m *ExportStorage) Remove(name string) error {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remove")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewExportStorage creates a new instance of ExportStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExportStorage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExportStorage {
+	mock := &ExportStorage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,123 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	item "github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+// ItemGrantRepository is an autogenerated mock type for the ItemGrantRepository type
+type ItemGrantRepository struct {
+	mock.Mock
+}
+
+// CreateItemGrant provides a mock function with given fields: ctx, grant
+func (_m *ItemGrantRepository) CreateItemGrant(ctx context.Context, grant *item.ItemGrant) error {
+	ret := _m.Called(ctx, grant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateItemGrant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *item.ItemGrant) error); ok {
+		r0 = rf(ctx, grant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteItemGrant provides a mock function with given fields: ctx, itemID, userID
+func (_m *ItemGrantRepository) DeleteItemGrant(ctx context.Context, itemID int, userID int) error {
+	ret := _m.Called(ctx, itemID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteItemGrant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, itemID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HasItemGrant provides a mock function with given fields: ctx, itemID, userID
+func (_m *ItemGrantRepository) HasItemGrant(ctx context.Context, itemID int, userID int) (bool, error) {
+	ret := _m.Called(ctx, itemID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasItemGrant")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (bool, error)); ok {
+		return rf(ctx, itemID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) bool); ok {
+		r0 = rf(ctx, itemID, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, itemID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListItemGrants provides a mock function with given fields: ctx, itemID
+func (_m *ItemGrantRepository) ListItemGrants(ctx context.Context, itemID int) ([]item.ItemGrant, error) {
+	ret := _m.Called(ctx, itemID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListItemGrants")
+	}
+
+	var r0 []item.ItemGrant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]item.ItemGrant, error)); ok {
+		return rf(ctx, itemID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []item.ItemGrant); ok {
+		r0 = rf(ctx, itemID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]item.ItemGrant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, itemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewItemGrantRepository creates a new instance of ItemGrantRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewItemGrantRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ItemGrantRepository {
+	mock := &ItemGrantRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -7,6 +7,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 	item "github.com/teamcubation/go-items-challenge/internal/domain/item"
+
+	time "time"
 )
 
 // ItemRepository is an autogenerated mock type for the ItemRepository type
@@ -14,6 +16,64 @@ type ItemRepository struct {
 	mock.Mock
 }
 
+// AdjustStock provides a mock function with given fields: ctx, id, stockDelta, reservedDelta
+func (_m *ItemRepository) AdjustStock(ctx context.Context, id int, stockDelta int, reservedDelta int) (*item.Item, error) {
+	ret := _m.Called(ctx, id, stockDelta, reservedDelta)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdjustStock")
+	}
+
+	var r0 *item.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) (*item.Item, error)); ok {
+		return rf(ctx, id, stockDelta, reservedDelta)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) *item.Item); ok {
+		r0 = rf(ctx, id, stockDelta, reservedDelta)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+		r1 = rf(ctx, id, stockDelta, reservedDelta)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountItems provides a mock function with given fields: ctx, query
+func (_m *ItemRepository) CountItems(ctx context.Context, query item.ListQuery) (int, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountItems")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, item.ListQuery) (int, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, item.ListQuery) int); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, item.ListQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateItem provides a mock function with given fields: ctx, itm
 func (_m *ItemRepository) CreateItem(ctx context.Context, itm *item.Item) (*item.Item, error) {
 	ret := _m.Called(ctx, itm)
@@ -44,9 +104,9 @@ func (_m *ItemRepository) CreateItem(ctx context.Context, itm *item.Item) (*item
 	return r0, r1
 }
 
-// DeleteItem provides a mock function with given fields: ctx, id
-func (_m *ItemRepository) DeleteItem(ctx context.Context, id int) (*item.Item, error) {
-	ret := _m.Called(ctx, id)
+// DeleteItem provides a mock function with given fields: ctx, id, version
+func (_m *ItemRepository) DeleteItem(ctx context.Context, id int, version int) (*item.Item, error) {
+	ret := _m.Called(ctx, id, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteItem")
@@ -54,19 +114,79 @@ func (_m *ItemRepository) DeleteItem(ctx context.Context, id int) (*item.Item, e
 
 	var r0 *item.Item
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) (*item.Item, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*item.Item, error)); ok {
+		return rf(ctx, id, version)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int) *item.Item); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *item.Item); ok {
+		r0 = rf(ctx, id, version)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*item.Item)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindItem provides a mock function with given fields: ctx, id, includeDeleted
+func (_m *ItemRepository) FindItem(ctx context.Context, id int, includeDeleted bool) (*item.Item, error) {
+	ret := _m.Called(ctx, id, includeDeleted)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindItem")
+	}
+
+	var r0 *item.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, bool) (*item.Item, error)); ok {
+		return rf(ctx, id, includeDeleted)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, bool) *item.Item); ok {
+		r0 = rf(ctx, id, includeDeleted)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, bool) error); ok {
+		r1 = rf(ctx, id, includeDeleted)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetItemByCode provides a mock function with given fields: ctx, code
+func (_m *ItemRepository) GetItemByCode(ctx context.Context, code string) (*item.Item, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItemByCode")
+	}
+
+	var r0 *item.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*item.Item, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *item.Item); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -122,9 +242,27 @@ func (_m *ItemRepository) ItemExistsByCode(ctx context.Context, code string) boo
 	return r0
 }
 
-// ListItems provides a mock function with given fields: ctx, status, limit, page
-func (_m *ItemRepository) ListItems(ctx context.Context, status string, limit int, page int) (*item.Response, error) {
-	ret := _m.Called(ctx, status, limit, page)
+// IterateItems provides a mock function with given fields: ctx, query, fn
+func (_m *ItemRepository) IterateItems(ctx context.Context, query item.ListQuery, fn func(*item.Item) error) error {
+	ret := _m.Called(ctx, query, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IterateItems")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, item.ListQuery, func(*item.Item) error) error); ok {
+		r0 = rf(ctx, query, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListItems provides a mock function with given fields: ctx, query
+func (_m *ItemRepository) ListItems(ctx context.Context, query item.ListQuery) (*item.Response, error) {
+	ret := _m.Called(ctx, query)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListItems")
@@ -132,19 +270,135 @@ func (_m *ItemRepository) ListItems(ctx context.Context, status string, limit in
 
 	var r0 *item.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) (*item.Response, error)); ok {
-		return rf(ctx, status, limit, page)
+	if rf, ok := ret.Get(0).(func(context.Context, item.ListQuery) (*item.Response, error)); ok {
+		return rf(ctx, query)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) *item.Response); ok {
-		r0 = rf(ctx, status, limit, page)
+	if rf, ok := ret.Get(0).(func(context.Context, item.ListQuery) *item.Response); ok {
+		r0 = rf(ctx, query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*item.Response)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
-		r1 = rf(ctx, status, limit, page)
+	if rf, ok := ret.Get(1).(func(context.Context, item.ListQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeDeletedItems provides a mock function with given fields: ctx, before
+func (_m *ItemRepository) PurgeDeletedItems(ctx context.Context, before time.Time) (int, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedItems")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreItem provides a mock function with given fields: ctx, id, version
+func (_m *ItemRepository) RestoreItem(ctx context.Context, id int, version int) (*item.Item, error) {
+	ret := _m.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreItem")
+	}
+
+	var r0 *item.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*item.Item, error)); ok {
+		return rf(ctx, id, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *item.Item); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchItems provides a mock function with given fields: ctx, query
+func (_m *ItemRepository) SearchItems(ctx context.Context, query item.SearchQuery) (*item.SearchResponse, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchItems")
+	}
+
+	var r0 *item.SearchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, item.SearchQuery) (*item.SearchResponse, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, item.SearchQuery) *item.SearchResponse); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.SearchResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, item.SearchQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetLowStock provides a mock function with given fields: ctx, id, lowStock
+func (_m *ItemRepository) SetLowStock(ctx context.Context, id int, lowStock bool) (bool, error) {
+	ret := _m.Called(ctx, id, lowStock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLowStock")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, bool) (bool, error)); ok {
+		return rf(ctx, id, lowStock)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, bool) bool); ok {
+		r0 = rf(ctx, id, lowStock)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, bool) error); ok {
+		r1 = rf(ctx, id, lowStock)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -182,6 +436,54 @@ func (_m *ItemRepository) UpdateItem(ctx context.Context, itm *item.Item) (*item
 	return r0, r1
 }
 
+// UpdateStatus provides a mock function with given fields: ctx, id, status, version
+func (_m *ItemRepository) UpdateStatus(ctx context.Context, id int, status item.Status, version int) (*item.Item, error) {
+	ret := _m.Called(ctx, id, status, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 *item.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, item.Status, int) (*item.Item, error)); ok {
+		return rf(ctx, id, status, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, item.Status, int) *item.Item); ok {
+		r0 = rf(ctx, id, status, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, item.Status, int) error); ok {
+		r1 = rf(ctx, id, status, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithinTransaction provides a mock function with given fields: ctx, fn
+func (_m *ItemRepository) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithinTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewItemRepository creates a new instance of ItemRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewItemRepository(t interface {
@@ -0,0 +1,127 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	item "github.com/teamcubation/go-items-challenge/internal/domain/item"
+
+	time "time"
+)
+
+// ReservationRepository is an autogenerated mock type for the ReservationRepository type
+type ReservationRepository struct {
+	mock.Mock
+}
+
+// ClaimExpiredReservations provides a mock function with given fields: ctx, now, limit
+func (_m *ReservationRepository) ClaimExpiredReservations(ctx context.Context, now time.Time, limit int) ([]item.Reservation, error) {
+	ret := _m.Called(ctx, now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimExpiredReservations")
+	}
+
+	var r0 []item.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]item.Reservation, error)); ok {
+		return rf(ctx, now, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []item.Reservation); ok {
+		r0 = rf(ctx, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]item.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateReservation provides a mock function with given fields: ctx, reservation
+func (_m *ReservationRepository) CreateReservation(ctx context.Context, reservation *item.Reservation) error {
+	ret := _m.Called(ctx, reservation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReservation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *item.Reservation) error); ok {
+		r0 = rf(ctx, reservation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetReservation provides a mock function with given fields: ctx, id, forUpdate
+func (_m *ReservationRepository) GetReservation(ctx context.Context, id string, forUpdate bool) (*item.Reservation, error) {
+	ret := _m.Called(ctx, id, forUpdate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReservation")
+	}
+
+	var r0 *item.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*item.Reservation, error)); ok {
+		return rf(ctx, id, forUpdate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *item.Reservation); ok {
+		r0 = rf(ctx, id, forUpdate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, id, forUpdate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateReservation provides a mock function with given fields: ctx, reservation
+func (_m *ReservationRepository) UpdateReservation(ctx context.Context, reservation *item.Reservation) error {
+	ret := _m.Called(ctx, reservation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateReservation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *item.Reservation) error); ok {
+		r0 = rf(ctx, reservation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewReservationRepository creates a new instance of ReservationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReservationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReservationRepository {
+	mock := &ReservationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,47 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	item "github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+// StockAlertNotifier is an autogenerated mock type for the StockAlertNotifier type
+type StockAlertNotifier struct {
+	mock.Mock
+}
+
+// NotifyLowStock provides a mock function with given fields: ctx, alert
+func (_m *StockAlertNotifier) NotifyLowStock(ctx context.Context, alert *item.StockAlert) error {
+	ret := _m.Called(ctx, alert)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotifyLowStock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *item.StockAlert) error); ok {
+		r0 = rf(ctx, alert)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewStockAlertNotifier creates a new instance of StockAlertNotifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStockAlertNotifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StockAlertNotifier {
+	mock := &StockAlertNotifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
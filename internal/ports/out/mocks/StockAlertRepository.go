@@ -0,0 +1,181 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	item "github.com/teamcubation/go-items-challenge/internal/domain/item"
+
+	time "time"
+)
+
+// StockAlertRepository is an autogenerated mock type for the StockAlertRepository type
+type StockAlertRepository struct {
+	mock.Mock
+}
+
+// ClaimPendingStockAlerts provides a mock function with given fields: ctx, limit, until
+func (_m *StockAlertRepository) ClaimPendingStockAlerts(ctx context.Context, limit int, until time.Time) ([]item.StockAlert, error) {
+	ret := _m.Called(ctx, limit, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimPendingStockAlerts")
+	}
+
+	var r0 []item.StockAlert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) ([]item.StockAlert, error)); ok {
+		return rf(ctx, limit, until)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) []item.StockAlert); ok {
+		r0 = rf(ctx, limit, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]item.StockAlert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time) error); ok {
+		r1 = rf(ctx, limit, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateStockAlert provides a mock function with given fields: ctx, alert
+func (_m *StockAlertRepository) CreateStockAlert(ctx context.Context, alert *item.StockAlert) error {
+	ret := _m.Called(ctx, alert)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStockAlert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *item.StockAlert) error); ok {
+		r0 = rf(ctx, alert)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCategoryReorderPoint provides a mock function with given fields: ctx, categoryID
+func (_m *StockAlertRepository) DeleteCategoryReorderPoint(ctx context.Context, categoryID int) error {
+	ret := _m.Called(ctx, categoryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCategoryReorderPoint")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, categoryID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetCategoryReorderPoint provides a mock function with given fields: ctx, categoryID
+func (_m *StockAlertRepository) GetCategoryReorderPoint(ctx context.Context, categoryID int) (*item.CategoryReorderPoint, error) {
+	ret := _m.Called(ctx, categoryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCategoryReorderPoint")
+	}
+
+	var r0 *item.CategoryReorderPoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*item.CategoryReorderPoint, error)); ok {
+		return rf(ctx, categoryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *item.CategoryReorderPoint); ok {
+		r0 = rf(ctx, categoryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.CategoryReorderPoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, categoryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkStockAlertSent provides a mock function with given fields: ctx, id, at
+func (_m *StockAlertRepository) MarkStockAlertSent(ctx context.Context, id int64, at time.Time) error {
+	ret := _m.Called(ctx, id, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkStockAlertSent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) error); ok {
+		r0 = rf(ctx, id, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReleaseStockAlerts provides a mock function with given fields: ctx, ids
+func (_m *StockAlertRepository) ReleaseStockAlerts(ctx context.Context, ids []int64) error {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseStockAlerts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetCategoryReorderPoint provides a mock function with given fields: ctx, point
+func (_m *StockAlertRepository) SetCategoryReorderPoint(ctx context.Context, point *item.CategoryReorderPoint) error {
+	ret := _m.Called(ctx, point)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCategoryReorderPoint")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *item.CategoryReorderPoint) error); ok {
+		r0 = rf(ctx, point)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewStockAlertRepository creates a new instance of StockAlertRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStockAlertRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StockAlertRepository {
+	mock := &StockAlertRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,77 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	item "github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+// StockMovementRepository is an autogenerated mock type for the StockMovementRepository type
+type StockMovementRepository struct {
+	mock.Mock
+}
+
+// CreateStockMovement provides a mock function with given fields: ctx, movement
+func (_m *StockMovementRepository) CreateStockMovement(ctx context.Context, movement *item.StockMovement) error {
+	ret := _m.Called(ctx, movement)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStockMovement")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *item.StockMovement) error); ok {
+		r0 = rf(ctx, movement)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListStockMovements provides a mock function with given fields: ctx, query
+func (_m *StockMovementRepository) ListStockMovements(ctx context.Context, query item.MovementQuery) (*item.MovementResponse, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStockMovements")
+	}
+
+	var r0 *item.MovementResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, item.MovementQuery) (*item.MovementResponse, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, item.MovementQuery) *item.MovementResponse); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.MovementResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, item.MovementQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewStockMovementRepository creates a new instance of StockMovementRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStockMovementRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StockMovementRepository {
+	mock := &StockMovementRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,263 @@
+// Code generated by mockery v2.50.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	item "github.com/teamcubation/go-items-challenge/internal/domain/item"
+
+	warehouse "github.com/teamcubation/go-items-challenge/internal/domain/warehouse"
+)
+
+// WarehouseRepository is an autogenerated mock type for the WarehouseRepository type
+type WarehouseRepository struct {
+	mock.Mock
+}
+
+// AdjustStockLevel provides a mock function with given fields: ctx, itemID, warehouseID, stockDelta, reservedDelta
+func (_m *WarehouseRepository) AdjustStockLevel(ctx context.Context, itemID int, warehouseID int, stockDelta int, reservedDelta int) (*item.StockLevel, error) {
+	ret := _m.Called(ctx, itemID, warehouseID, stockDelta, reservedDelta)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdjustStockLevel")
+	}
+
+	var r0 *item.StockLevel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, int) (*item.StockLevel, error)); ok {
+		return rf(ctx, itemID, warehouseID, stockDelta, reservedDelta)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, int) *item.StockLevel); ok {
+		r0 = rf(ctx, itemID, warehouseID, stockDelta, reservedDelta)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*item.StockLevel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int, int) error); ok {
+		r1 = rf(ctx, itemID, warehouseID, stockDelta, reservedDelta)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateWarehouse provides a mock function with given fields: ctx, w
+func (_m *WarehouseRepository) CreateWarehouse(ctx context.Context, w *warehouse.Warehouse) error {
+	ret := _m.Called(ctx, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWarehouse")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *warehouse.Warehouse) error); ok {
+		r0 = rf(ctx, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteWarehouse provides a mock function with given fields: ctx, id
+func (_m *WarehouseRepository) DeleteWarehouse(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWarehouse")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDefaultWarehouse provides a mock function with given fields: ctx
+func (_m *WarehouseRepository) GetDefaultWarehouse(ctx context.Context) (*warehouse.Warehouse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDefaultWarehouse")
+	}
+
+	var r0 *warehouse.Warehouse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*warehouse.Warehouse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *warehouse.Warehouse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*warehouse.Warehouse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWarehouse provides a mock function with given fields: ctx, id
+func (_m *WarehouseRepository) GetWarehouse(ctx context.Context, id int) (*warehouse.Warehouse, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWarehouse")
+	}
+
+	var r0 *warehouse.Warehouse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*warehouse.Warehouse, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *warehouse.Warehouse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*warehouse.Warehouse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListStockLevels provides a mock function with given fields: ctx, itemID
+func (_m *WarehouseRepository) ListStockLevels(ctx context.Context, itemID int) ([]item.StockLevel, error) {
+	ret := _m.Called(ctx, itemID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStockLevels")
+	}
+
+	var r0 []item.StockLevel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]item.StockLevel, error)); ok {
+		return rf(ctx, itemID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []item.StockLevel); ok {
+		r0 = rf(ctx, itemID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]item.StockLevel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, itemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWarehouses provides a mock function with given fields: ctx
+func (_m *WarehouseRepository) ListWarehouses(ctx context.Context) ([]warehouse.Warehouse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWarehouses")
+	}
+
+	var r0 []warehouse.Warehouse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]warehouse.Warehouse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []warehouse.Warehouse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]warehouse.Warehouse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateWarehouse provides a mock function with given fields: ctx, w
+func (_m *WarehouseRepository) UpdateWarehouse(ctx context.Context, w *warehouse.Warehouse) error {
+	ret := _m.Called(ctx, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWarehouse")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *warehouse.Warehouse) error); ok {
+		r0 = rf(ctx, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WarehouseExistsByCode provides a mock function with given fields: ctx, code
+func (_m *WarehouseRepository) WarehouseExistsByCode(ctx context.Context, code string) (bool, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WarehouseExistsByCode")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewWarehouseRepository creates a new instance of WarehouseRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWarehouseRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WarehouseRepository {
+	mock := &WarehouseRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,21 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+// ReservationRepository stores stock reservations. Reservations are read and
+// written in the transaction carried by ctx, if any.
+type ReservationRepository interface {
+	CreateReservation(ctx context.Context, reservation *item.Reservation) error
+	// GetReservation returns the reservation, or nil when there is none. With
+	// forUpdate the row stays locked until the transaction ends.
+	GetReservation(ctx context.Context, id string, forUpdate bool) (*item.Reservation, error)
+	UpdateReservation(ctx context.Context, reservation *item.Reservation) error
+	// ClaimExpiredReservations locks up to limit held reservations expired at
+	// now, skipping those locked by other transactions.
+	ClaimExpiredReservations(ctx context.Context, now time.Time, limit int) ([]item.Reservation, error)
+}
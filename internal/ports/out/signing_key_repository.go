@@ -0,0 +1,21 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+)
+
+// SigningKeyRepository stores the keys that sign the access tokens, shared by
+// every instance of the server.
+type SigningKeyRepository interface {
+	// WithRotationLock runs fn in a transaction holding a lock shared by every
+	// instance, so that only one of them rotates the keys at a time.
+	WithRotationLock(ctx context.Context, fn func(ctx context.Context) error) error
+	CreateSigningKey(ctx context.Context, key *user.SigningKey) error
+	// ListSigningKeys returns the keys that have not expired at now, newest
+	// first.
+	ListSigningKeys(ctx context.Context, now time.Time) ([]user.SigningKey, error)
+	DeleteExpiredSigningKeys(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,32 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+// StockAlertRepository stores the reorder points of the categories and the
+// low stock alerts waiting to be sent.
+type StockAlertRepository interface {
+	// GetCategoryReorderPoint returns the reorder point of the category, or nil
+	// when it has none.
+	GetCategoryReorderPoint(ctx context.Context, categoryID int) (*item.CategoryReorderPoint, error)
+	SetCategoryReorderPoint(ctx context.Context, point *item.CategoryReorderPoint) error
+	DeleteCategoryReorderPoint(ctx context.Context, categoryID int) error
+
+	CreateStockAlert(ctx context.Context, alert *item.StockAlert) error
+	// ClaimPendingStockAlerts leases up to limit unsent alerts, oldest first,
+	// until the given time, skipping those whose lease has not ended.
+	ClaimPendingStockAlerts(ctx context.Context, limit int, until time.Time) ([]item.StockAlert, error)
+	MarkStockAlertSent(ctx context.Context, id int64, at time.Time) error
+	// ReleaseStockAlerts ends the lease of the unsent alerts, so the next
+	// claim picks them up again.
+	ReleaseStockAlerts(ctx context.Context, ids []int64) error
+}
+
+// StockAlertNotifier delivers low stock alerts, e.g. to a webhook.
+type StockAlertNotifier interface {
+	NotifyLowStock(ctx context.Context, alert *item.StockAlert) error
+}
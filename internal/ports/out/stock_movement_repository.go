@@ -0,0 +1,14 @@
+package out
+
+import (
+	"context"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+)
+
+// StockMovementRepository stores the stock ledger of the items. Movements are
+// written in the transaction carried by ctx, if any.
+type StockMovementRepository interface {
+	CreateStockMovement(ctx context.Context, movement *item.StockMovement) error
+	ListStockMovements(ctx context.Context, query item.MovementQuery) (*item.MovementResponse, error)
+}
@@ -0,0 +1,29 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/user"
+)
+
+// TokenRepository stores the refresh tokens and the denylist of access
+// tokens.
+type TokenRepository interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+
+	CreateRefreshToken(ctx context.Context, token *user.RefreshToken) error
+	// GetRefreshTokenByHash locks and returns the refresh token, or nil when
+	// there is none with that hash.
+	GetRefreshTokenByHash(ctx context.Context, hash string) (*user.RefreshToken, error)
+	MarkRefreshTokenRotated(ctx context.Context, id string, at time.Time) error
+	// RevokeRefreshTokenFamily revokes the tokens of the family that are not
+	// revoked yet and returns them.
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string, at time.Time) ([]user.RefreshToken, error)
+
+	DenyAccessTokens(ctx context.Context, tokens []user.RevokedToken) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+	// DeleteExpiredTokens removes the refresh tokens and denied access tokens
+	// that expired before now.
+	DeleteExpiredTokens(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,30 @@
+package out
+
+import (
+	"context"
+
+	"github.com/teamcubation/go-items-challenge/internal/domain/item"
+	"github.com/teamcubation/go-items-challenge/internal/domain/warehouse"
+)
+
+// WarehouseRepository stores the warehouses and the stock of each item in
+// them. Writes use the transaction carried by ctx, if any.
+type WarehouseRepository interface {
+	CreateWarehouse(ctx context.Context, w *warehouse.Warehouse) error
+	// GetWarehouse returns the warehouse, or nil when there is none.
+	GetWarehouse(ctx context.Context, id int) (*warehouse.Warehouse, error)
+	GetDefaultWarehouse(ctx context.Context) (*warehouse.Warehouse, error)
+	ListWarehouses(ctx context.Context) ([]warehouse.Warehouse, error)
+	UpdateWarehouse(ctx context.Context, w *warehouse.Warehouse) error
+	// DeleteWarehouse deletes the warehouse and its empty stock levels, failing
+	// with warehouse.ErrWarehouseNotEmpty while it holds stock.
+	DeleteWarehouse(ctx context.Context, id int) error
+	WarehouseExistsByCode(ctx context.Context, code string) (bool, error)
+
+	// AdjustStockLevel adds stockDelta to the stock and reservedDelta to the
+	// reserved stock of the item in the warehouse, failing with
+	// item.ErrInsufficientStock if the reserved stock would go negative or
+	// exceed the stock.
+	AdjustStockLevel(ctx context.Context, itemID, warehouseID, stockDelta, reservedDelta int) (*item.StockLevel, error)
+	ListStockLevels(ctx context.Context, itemID int) ([]item.StockLevel, error)
+}
@@ -31,7 +31,13 @@ func getLogger() *logrus.Logger {
 	return baseLogger
 }
 
+// Context returns the request context with a logger tagged with the request
+// ID, taken from the x-request-id header or generated. A context that already
+// carries a request logger, e.g. set by Middleware, is returned unchanged.
 func Context(r *http.Request) context.Context {
+	if _, ok := r.Context().Value(loggerKey{}).(*logrus.Entry); ok {
+		return r.Context()
+	}
 	reqID := r.Header.Get(RequestIDKey)
 	if reqID == "" {
 		reqID = uuid.New().String()
@@ -55,6 +61,27 @@ func Println(ctx context.Context, args ...interface{}) {
 	GetFromContext(ctx).Println(args...)
 }
 
+// Middleware adds the request logger of Context to every request and echoes
+// the request ID in the response.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := Context(r)
+		w.Header().Set(RequestIDKey, RequestID(ctx))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequestID returns the ID of the request whose logger is in ctx, or an empty
+// string.
+func RequestID(ctx context.Context) string {
+	logger, ok := ctx.Value(loggerKey{}).(*logrus.Entry)
+	if !ok {
+		return ""
+	}
+	reqID, _ := logger.Data["request_id"].(string)
+	return reqID
+}
+
 func defaultLogger() *logrus.Entry {
 	return getLogger().WithField("default", "true")
 }